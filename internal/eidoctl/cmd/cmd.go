@@ -6,12 +6,22 @@ import (
 	"io"
 	"os"
 
+	"github.com/kiosk404/eidolon/internal/eidoctl/cmd/config"
 	"github.com/kiosk404/eidolon/internal/eidoctl/cmd/info"
-	"github.com/kiosk404/eidolon/internal/eidoctl/cmd/init"
+	initcmd "github.com/kiosk404/eidolon/internal/eidoctl/cmd/init"
 	"github.com/kiosk404/eidolon/internal/eidoctl/cmd/join"
+	"github.com/kiosk404/eidolon/internal/eidoctl/cmd/leave"
+	"github.com/kiosk404/eidolon/internal/eidoctl/cmd/node"
+	"github.com/kiosk404/eidolon/internal/eidoctl/cmd/preflight"
+	"github.com/kiosk404/eidolon/internal/eidoctl/cmd/reset"
+	"github.com/kiosk404/eidolon/internal/eidoctl/cmd/skill"
+	"github.com/kiosk404/eidolon/internal/eidoctl/cmd/status"
+	"github.com/kiosk404/eidolon/internal/eidoctl/cmd/task"
+	"github.com/kiosk404/eidolon/internal/eidoctl/cmd/token"
 	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
 	"github.com/kiosk404/eidolon/internal/eidoctl/types"
 	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	"github.com/kiosk404/eidolon/internal/pkg/options"
 	genericapiserver "github.com/kiosk404/eidolon/internal/pkg/server"
 	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
 	"github.com/kiosk404/eidolon/pkg/utils/cliflag"
@@ -54,8 +64,9 @@ func NewHivCtlCommand(in io.Reader, out, err io.Writer) *cobra.Command {
 	// Normalize all flags that are coming from other packages or pre-configurations
 	flags.SetNormalizeFunc(cliflag.WordSepNormalizeFunc)
 
+	configFlags := options.NewConfigFlags(true)
 	addProfilingFlags(flags)
-	addGlobalFlags(flags)
+	addGlobalFlags(flags, configFlags)
 
 	_ = viper.BindPFlags(cmds.PersistentFlags())
 	cobra.OnInitialize(func() {
@@ -67,20 +78,44 @@ func NewHivCtlCommand(in io.Reader, out, err io.Writer) *cobra.Command {
 	cmds.SetGlobalNormalizationFunc(cliflag.WarnWordSepNormalizeFunc)
 
 	ioStreams := genericclioptions.IOStreams{In: in, Out: out, ErrOut: err}
-	f := cmdutil.NewDefaultFactory()
+	f := cmdutil.NewDefaultFactory(configFlags)
 
 	groups := templates.CommandGroups{
 		{
 			Message: "Basic Commands:",
 			Commands: []*cobra.Command{
-				init.NewCmdInit(f, ioStreams),
+				initcmd.NewCmdInit(f, ioStreams),
 				join.NewCmdJoin(f, ioStreams),
+				leave.NewCmdLeave(f, ioStreams),
+				reset.NewCmdReset(f, ioStreams),
+			},
+		},
+		{
+			Message: "Node Management Commands:",
+			Commands: []*cobra.Command{
+				skill.NewCmdSkill(f, ioStreams),
+			},
+		},
+		{
+			Message: "Cluster Management Commands:",
+			Commands: []*cobra.Command{
+				token.NewCmdToken(f, ioStreams),
+				node.NewCmdNode(f, ioStreams),
+				task.NewCmdTask(f, ioStreams),
 			},
 		},
 		{
 			Message: "Diagnostic Commands:",
 			Commands: []*cobra.Command{
 				info.NewCmdInfo(f, ioStreams),
+				preflight.NewCmdPreflight(f, ioStreams),
+				status.NewCmdStatus(f, ioStreams),
+			},
+		},
+		{
+			Message: "Settings Commands:",
+			Commands: []*cobra.Command{
+				config.NewCmdConfig(f, ioStreams),
 			},
 		},
 	}
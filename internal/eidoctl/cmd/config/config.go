@@ -0,0 +1,53 @@
+package config
+
+import (
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	"github.com/kiosk404/eidolon/internal/pkg/clientcmd"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/spf13/cobra"
+)
+
+var configLong = templates.LongDesc(`
+		Modify the eidoctl configuration file, ~/.eidolon/config by default.
+
+		The file lists the hiveminds eidoctl knows, the credentials to reach them with
+		and named contexts pairing a hivemind with credentials and a default space. The
+		current context is used unless another one is selected with --context.
+
+		The file is picked in this order:
+
+		1. The --eidoconfig flag.
+		2. The $EIDOLON_CONFIG environment variable.
+		3. ~/.eidolon/config.
+
+		The --context, --hivemind-api and --hivemind-addr flags take precedence over the
+		$EIDOLON_CONTEXT, $EIDOLON_HIVEMIND_API and $EIDOLON_HIVEMIND_ADDR environment
+		variables, which take precedence over the settings of the file.`)
+
+// NewCmdConfig returns new initialized instance of 'config' sub command.
+func NewCmdConfig(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "config SUBCOMMAND",
+		DisableFlagsInUseLine: true,
+		Short:                 "Modify the eidoctl configuration file",
+		Long:                  configLong,
+		Run:                   cmdutil.DefaultSubCommandRun(ioStreams.ErrOut),
+	}
+
+	cmd.AddCommand(NewCmdView(f, ioStreams))
+	cmd.AddCommand(NewCmdGetContexts(f, ioStreams))
+	cmd.AddCommand(NewCmdCurrentContext(f, ioStreams))
+	cmd.AddCommand(NewCmdUseContext(f, ioStreams))
+	cmd.AddCommand(NewCmdSetContext(f, ioStreams))
+	cmd.AddCommand(NewCmdDeleteContext(f, ioStreams))
+	cmd.AddCommand(NewCmdSetHivemind(f, ioStreams))
+	cmd.AddCommand(NewCmdSetCredentials(f, ioStreams))
+
+	return cmd
+}
+
+// save writes cfg back to the configuration file of f.
+func save(f cmdutil.Factory, cfg *clientcmd.Config) error {
+	return clientcmd.WriteToFile(cfg, f.ConfigPath())
+}
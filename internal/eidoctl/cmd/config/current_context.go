@@ -0,0 +1,48 @@
+package config
+
+import (
+	"fmt"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/spf13/cobra"
+)
+
+// CurrentContext is an options struct to support 'config current-context' sub command.
+type CurrentContext struct {
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewCmdCurrentContext returns new initialized instance of 'config current-context' sub command.
+func NewCmdCurrentContext(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &CurrentContext{Factory: f, IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:                   "current-context",
+		DisableFlagsInUseLine: true,
+		Short:                 "Print the current context",
+		Long:                  "Print the name of the context eidoctl uses when none is selected with --context.",
+		Example:               "  eidoctl config current-context",
+		Args:                  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Run())
+		},
+	}
+
+	return cmd
+}
+
+// Run executes a config current-context sub command using the specified options.
+func (o *CurrentContext) Run() error {
+	cfg, err := o.Factory.ToRawConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.CurrentContext == "" {
+		return fmt.Errorf("current-context is not set")
+	}
+	fmt.Fprintln(o.Out, cfg.CurrentContext)
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"fmt"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/spf13/cobra"
+)
+
+// DeleteContext is an options struct to support 'config delete-context' sub command.
+type DeleteContext struct {
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewCmdDeleteContext returns new initialized instance of 'config delete-context' sub command.
+func NewCmdDeleteContext(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &DeleteContext{Factory: f, IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:                   "delete-context CONTEXT_NAME",
+		DisableFlagsInUseLine: true,
+		Short:                 "Delete a context",
+		Long:                  "Delete a context from the configuration file. The hivemind and the credentials it uses are kept.",
+		Example:               "  eidoctl config delete-context staging",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				cmdutil.CheckErr(cmdutil.UsageErrorf(cmd, "a single context name is required"))
+			}
+			cmdutil.CheckErr(o.Run(args))
+		},
+	}
+
+	return cmd
+}
+
+// Run executes a config delete-context sub command using the specified options.
+func (o *DeleteContext) Run(args []string) error {
+	cfg, err := o.Factory.ToRawConfig()
+	if err != nil {
+		return err
+	}
+
+	name := args[0]
+	if !cfg.DeleteContext(name) {
+		return fmt.Errorf("context %q does not exist in %s", name, cfg.Path())
+	}
+	if cfg.CurrentContext == name {
+		cfg.CurrentContext = ""
+		fmt.Fprintf(o.ErrOut, "warning: this removed the current context, run 'eidoctl config use-context' to select another one\n")
+	}
+	if err := save(o.Factory, cfg); err != nil {
+		return err
+	}
+	fmt.Fprintf(o.Out, "Deleted context %q.\n", name)
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"fmt"
+	"io"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	"github.com/kiosk404/eidolon/internal/pkg/clientcmd"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/kiosk404/eidolon/pkg/cli/printers"
+	"github.com/spf13/cobra"
+)
+
+var getContextsExample = templates.Examples(`
+		# List all the contexts
+		eidoctl config get-contexts
+
+		# Also show the address of the hivemind of every context
+		eidoctl config get-contexts -o wide
+
+		# Describe one context
+		eidoctl config get-contexts production`)
+
+// ContextInfo is a context as listed by 'config get-contexts'.
+type ContextInfo struct {
+	Current bool   `json:"current" yaml:"current"`
+	Name    string `json:"name"    yaml:"name"`
+	Server  string `json:"server"  yaml:"server"`
+
+	clientcmd.Context `json:",inline" yaml:",inline"`
+}
+
+// ContextInfoList is what 'config get-contexts' reports.
+type ContextInfoList struct {
+	Items []*ContextInfo `json:"items" yaml:"items"`
+}
+
+// GetContexts is an options struct to support 'config get-contexts' sub command.
+type GetContexts struct {
+	printer printers.ResourcePrinter
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewCmdGetContexts returns new initialized instance of 'config get-contexts' sub command.
+func NewCmdGetContexts(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &GetContexts{Factory: f, IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:                   "get-contexts [NAME...]",
+		DisableFlagsInUseLine: true,
+		Short:                 "List the contexts of the configuration file",
+		Long:                  "List the contexts of the configuration file, or only the named ones. The current context is marked with a '*'.",
+		Example:               getContextsExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete())
+			cmdutil.CheckErr(o.Run(args))
+		},
+	}
+
+	return cmd
+}
+
+// Complete completes all the required options.
+func (o *GetContexts) Complete() error {
+	var err error
+	o.printer, err = cmdutil.PrinterForCommand(printContexts)
+
+	return err
+}
+
+// Run executes a config get-contexts sub command using the specified options.
+func (o *GetContexts) Run(args []string) error {
+	cfg, err := o.Factory.ToRawConfig()
+	if err != nil {
+		return err
+	}
+
+	names := args
+	if len(names) == 0 {
+		for _, named := range cfg.Contexts {
+			names = append(names, named.Name)
+		}
+	}
+
+	list := &ContextInfoList{Items: make([]*ContextInfo, 0, len(names))}
+	for _, name := range names {
+		ctx := cfg.Context(name)
+		if ctx == nil {
+			return fmt.Errorf("context %q does not exist in %s", name, cfg.Path())
+		}
+		info := &ContextInfo{Current: name == cfg.CurrentContext, Name: name, Context: *ctx}
+		if h := cfg.Hivemind(ctx.Hivemind); h != nil {
+			info.Server = h.Server
+		}
+		list.Items = append(list.Items, info)
+	}
+
+	return o.printer.PrintObj(list, o.Out)
+}
+
+func printContexts(obj any, w io.Writer, wide bool) error {
+	list, ok := obj.(*ContextInfoList)
+	if !ok {
+		return fmt.Errorf("unexpected object %T", obj)
+	}
+
+	table := printers.NewTable(
+		printers.Column{Name: "CURRENT"},
+		printers.Column{Name: "NAME"},
+		printers.Column{Name: "HIVEMIND"},
+		printers.Column{Name: "USER"},
+		printers.Column{Name: "SPACE"},
+		printers.Column{Name: "SERVER", Wide: true},
+	)
+	for _, c := range list.Items {
+		// A space keeps the cell blank, empty cells print as <none>.
+		current := " "
+		if c.Current {
+			current = "*"
+		}
+		table.AddRow(current, c.Name, c.Hivemind, c.User, c.Space, c.Server)
+	}
+
+	return table.Print(w, wide)
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"fmt"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	"github.com/kiosk404/eidolon/internal/pkg/clientcmd"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/spf13/cobra"
+)
+
+var setContextExample = templates.Examples(`
+		# Add a context for the production hivemind and switch to it
+		eidoctl config set-hivemind production --server=https://hivemind.example.com:11789 \
+			--grpc-server=hivemind.example.com:11788 --certificate-authority=ca.crt
+		eidoctl config set-context production --hivemind=production --user=admin
+		eidoctl config use-context production
+
+		# Change the default space of the current context
+		eidoctl config set-context --current --space=research`)
+
+// SetContext is an options struct to support 'config set-context' sub command.
+type SetContext struct {
+	Current  bool
+	Hivemind string
+	User     string
+	Space    string
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewCmdSetContext returns new initialized instance of 'config set-context' sub command.
+func NewCmdSetContext(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &SetContext{Factory: f, IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:                   "set-context [CONTEXT_NAME | --current] [--hivemind=NAME] [--user=NAME] [--space=SPACE]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Add or modify a context",
+		Long: templates.LongDesc(`
+		Add a context to the configuration file, or modify an existing one. Only the
+		fields whose flag is given are changed, an empty value clears the field.`),
+		Example: setContextExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			if o.Current == (len(args) == 1) || len(args) > 1 {
+				cmdutil.CheckErr(cmdutil.UsageErrorf(cmd, "either a context name or --current is required"))
+			}
+			cmdutil.CheckErr(o.Run(cmd, args))
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.Current, "current", o.Current, "Modify the current context")
+	cmd.Flags().StringVar(&o.Hivemind, "hivemind", o.Hivemind, "The hivemind of the context")
+	cmd.Flags().StringVar(&o.User, "user", o.User, "The credentials of the context")
+	cmd.Flags().StringVar(&o.Space, "space", o.Space, "The default space of the context")
+
+	return cmd
+}
+
+// Run executes a config set-context sub command using the specified options.
+func (o *SetContext) Run(cmd *cobra.Command, args []string) error {
+	cfg, err := o.Factory.ToRawConfig()
+	if err != nil {
+		return err
+	}
+
+	var name string
+	if o.Current {
+		if name = cfg.CurrentContext; name == "" {
+			return fmt.Errorf("current-context is not set")
+		}
+	} else {
+		name = args[0]
+	}
+
+	ctx := &clientcmd.Context{}
+	exists := false
+	if c := cfg.Context(name); c != nil {
+		ctx, exists = c, true
+	} else if o.Current {
+		return fmt.Errorf("the current context %q does not exist in %s", name, cfg.Path())
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("hivemind") {
+		ctx.Hivemind = o.Hivemind
+	}
+	if flags.Changed("user") {
+		ctx.User = o.User
+	}
+	if flags.Changed("space") {
+		ctx.Space = o.Space
+	}
+	cfg.SetContext(name, ctx)
+
+	if err := save(o.Factory, cfg); err != nil {
+		return err
+	}
+	if exists {
+		fmt.Fprintf(o.Out, "Context %q modified.\n", name)
+	} else {
+		fmt.Fprintf(o.Out, "Context %q created.\n", name)
+	}
+
+	return nil
+}
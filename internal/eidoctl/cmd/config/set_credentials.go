@@ -0,0 +1,106 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	"github.com/kiosk404/eidolon/internal/pkg/clientcmd"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/spf13/cobra"
+)
+
+var setCredentialsExample = templates.Examples(`
+		# Add credentials authenticating with a client certificate
+		eidoctl config set-credentials admin --client-certificate=admin.crt --client-key=admin.key`)
+
+// SetCredentials is an options struct to support 'config set-credentials' sub command.
+type SetCredentials struct {
+	ClientCertificate string
+	ClientKey         string
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewCmdSetCredentials returns new initialized instance of 'config set-credentials' sub command.
+func NewCmdSetCredentials(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &SetCredentials{Factory: f, IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:                   "set-credentials NAME [--client-certificate=PATH --client-key=PATH]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Add or modify credentials",
+		Long: templates.LongDesc(`
+		Add credentials to the configuration file, or modify existing ones. Only the
+		fields whose flag is given are changed, an empty value clears the field.
+
+		The client certificate and key are presented to hiveminds that verify client
+		certificates.`),
+		Example: setCredentialsExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				cmdutil.CheckErr(cmdutil.UsageErrorf(cmd, "a single credentials name is required"))
+			}
+			cmdutil.CheckErr(o.Run(cmd, args))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.ClientCertificate, "client-certificate", o.ClientCertificate,
+		"Path to the client certificate")
+	cmd.Flags().StringVar(&o.ClientKey, "client-key", o.ClientKey, "Path to the key of the client certificate")
+
+	return cmd
+}
+
+// Run executes a config set-credentials sub command using the specified options.
+func (o *SetCredentials) Run(cmd *cobra.Command, args []string) error {
+	cfg, err := o.Factory.ToRawConfig()
+	if err != nil {
+		return err
+	}
+
+	name := args[0]
+	u := &clientcmd.AuthInfo{}
+	exists := false
+	if existing := cfg.User(name); existing != nil {
+		u, exists = existing, true
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("client-certificate") {
+		if u.ClientCertificate, err = absPath(o.ClientCertificate); err != nil {
+			return err
+		}
+	}
+	if flags.Changed("client-key") {
+		if u.ClientKey, err = absPath(o.ClientKey); err != nil {
+			return err
+		}
+	}
+	if (u.ClientCertificate == "") != (u.ClientKey == "") {
+		return fmt.Errorf("--client-certificate and --client-key must be set together")
+	}
+	cfg.SetUser(name, u)
+
+	if err := save(o.Factory, cfg); err != nil {
+		return err
+	}
+	if exists {
+		fmt.Fprintf(o.Out, "User %q modified.\n", name)
+	} else {
+		fmt.Fprintf(o.Out, "User %q created.\n", name)
+	}
+
+	return nil
+}
+
+// absPath makes path absolute, leaving an empty path empty.
+func absPath(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+
+	return filepath.Abs(path)
+}
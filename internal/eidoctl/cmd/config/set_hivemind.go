@@ -0,0 +1,136 @@
+package config
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	"github.com/kiosk404/eidolon/internal/pkg/clientcmd"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/spf13/cobra"
+)
+
+var setHivemindExample = templates.Examples(`
+		# Add a hivemind served over TLS and embed the CA that verifies it
+		eidoctl config set-hivemind production --server=https://hivemind.example.com:11789 \
+			--grpc-server=hivemind.example.com:11788 --certificate-authority=ca.crt --embed-certs
+
+		# Change the REST address of an existing hivemind
+		eidoctl config set-hivemind local --server=http://127.0.0.1:11789`)
+
+// SetHivemind is an options struct to support 'config set-hivemind' sub command.
+type SetHivemind struct {
+	Server                string
+	GRPCServer            string
+	CertificateAuthority  string
+	EmbedCerts            bool
+	InsecureSkipTLSVerify bool
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewCmdSetHivemind returns new initialized instance of 'config set-hivemind' sub command.
+func NewCmdSetHivemind(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &SetHivemind{Factory: f, IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:                   "set-hivemind NAME [--server=URL] [--grpc-server=HOST:PORT] [--certificate-authority=PATH [--embed-certs]] [--insecure-skip-tls-verify]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Add or modify a hivemind",
+		Long: templates.LongDesc(`
+		Add a hivemind to the configuration file, or modify an existing one. Only the
+		fields whose flag is given are changed.`),
+		Example: setHivemindExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				cmdutil.CheckErr(cmdutil.UsageErrorf(cmd, "a single hivemind name is required"))
+			}
+			cmdutil.CheckErr(o.Validate())
+			cmdutil.CheckErr(o.Run(cmd, args))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.Server, "server", o.Server, "URL of the hivemind REST API")
+	cmd.Flags().StringVar(&o.GRPCServer, "grpc-server", o.GRPCServer, "Address of the hivemind gRPC endpoint (host:port)")
+	cmd.Flags().StringVar(&o.CertificateAuthority, "certificate-authority", o.CertificateAuthority,
+		"Path to the CA bundle that verifies the hivemind")
+	cmd.Flags().BoolVar(&o.EmbedCerts, "embed-certs", o.EmbedCerts,
+		"Store the content of --certificate-authority in the configuration file instead of its path")
+	cmd.Flags().BoolVar(&o.InsecureSkipTLSVerify, "insecure-skip-tls-verify", o.InsecureSkipTLSVerify,
+		"Do not verify the hivemind certificate. This is insecure")
+
+	return cmd
+}
+
+// Validate makes sure there is no discrepancy in command options.
+func (o *SetHivemind) Validate() error {
+	if o.EmbedCerts && o.CertificateAuthority == "" {
+		return fmt.Errorf("--embed-certs requires --certificate-authority")
+	}
+	if o.InsecureSkipTLSVerify && o.CertificateAuthority != "" {
+		return fmt.Errorf("--insecure-skip-tls-verify and --certificate-authority are mutually exclusive")
+	}
+
+	return nil
+}
+
+// Run executes a config set-hivemind sub command using the specified options.
+func (o *SetHivemind) Run(cmd *cobra.Command, args []string) error {
+	cfg, err := o.Factory.ToRawConfig()
+	if err != nil {
+		return err
+	}
+
+	name := args[0]
+	h := &clientcmd.Hivemind{}
+	exists := false
+	if existing := cfg.Hivemind(name); existing != nil {
+		h, exists = existing, true
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("server") {
+		h.Server = o.Server
+	}
+	if flags.Changed("grpc-server") {
+		h.GRPCServer = o.GRPCServer
+	}
+	if flags.Changed("insecure-skip-tls-verify") {
+		h.InsecureSkipTLSVerify = o.InsecureSkipTLSVerify
+		if h.InsecureSkipTLSVerify {
+			h.CertificateAuthority, h.CertificateAuthorityData = "", ""
+		}
+	}
+	if o.CertificateAuthority != "" {
+		h.InsecureSkipTLSVerify = false
+		if o.EmbedCerts {
+			data, err := os.ReadFile(o.CertificateAuthority)
+			if err != nil {
+				return err
+			}
+			h.CertificateAuthority = ""
+			h.CertificateAuthorityData = base64.StdEncoding.EncodeToString(data)
+		} else {
+			if h.CertificateAuthority, err = filepath.Abs(o.CertificateAuthority); err != nil {
+				return err
+			}
+			h.CertificateAuthorityData = ""
+		}
+	}
+	cfg.SetHivemind(name, h)
+
+	if err := save(o.Factory, cfg); err != nil {
+		return err
+	}
+	if exists {
+		fmt.Fprintf(o.Out, "Hivemind %q modified.\n", name)
+	} else {
+		fmt.Fprintf(o.Out, "Hivemind %q created.\n", name)
+	}
+
+	return nil
+}
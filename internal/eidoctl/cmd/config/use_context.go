@@ -0,0 +1,57 @@
+package config
+
+import (
+	"fmt"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/spf13/cobra"
+)
+
+// UseContext is an options struct to support 'config use-context' sub command.
+type UseContext struct {
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewCmdUseContext returns new initialized instance of 'config use-context' sub command.
+func NewCmdUseContext(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &UseContext{Factory: f, IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:                   "use-context CONTEXT_NAME",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"use"},
+		Short:                 "Set the current context",
+		Long:                  "Set the context eidoctl uses when none is selected with --context.",
+		Example:               "  eidoctl config use-context production",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				cmdutil.CheckErr(cmdutil.UsageErrorf(cmd, "a single context name is required"))
+			}
+			cmdutil.CheckErr(o.Run(args))
+		},
+	}
+
+	return cmd
+}
+
+// Run executes a config use-context sub command using the specified options.
+func (o *UseContext) Run(args []string) error {
+	cfg, err := o.Factory.ToRawConfig()
+	if err != nil {
+		return err
+	}
+
+	name := args[0]
+	if cfg.Context(name) == nil {
+		return fmt.Errorf("context %q does not exist in %s", name, cfg.Path())
+	}
+	cfg.CurrentContext = name
+	if err := save(o.Factory, cfg); err != nil {
+		return err
+	}
+	fmt.Fprintf(o.Out, "Switched to context %q.\n", name)
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package config
+
+import (
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	"github.com/kiosk404/eidolon/internal/pkg/clientcmd"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/kiosk404/eidolon/pkg/cli/printers"
+	"github.com/spf13/cobra"
+)
+
+const redactedData = "DATA+OMITTED"
+
+var viewExample = templates.Examples(`
+		# Show the configuration file
+		eidoctl config view
+
+		# Show only the entries of the current context
+		eidoctl config view --minify
+
+		# Print the REST address of the current context
+		eidoctl config view --minify -o jsonpath='{.hiveminds[0].hivemind.server}'`)
+
+// View is an options struct to support 'config view' sub command.
+type View struct {
+	Minify bool
+	Raw    bool
+
+	printer printers.ResourcePrinter
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewCmdView returns new initialized instance of 'config view' sub command.
+func NewCmdView(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &View{Factory: f, IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:                   "view",
+		DisableFlagsInUseLine: true,
+		Short:                 "Show the eidoctl configuration file",
+		Long: templates.LongDesc(`
+		Show the eidoctl configuration file as YAML. Embedded certificates are hidden
+		unless --raw is given.`),
+		Example: viewExample,
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete())
+			cmdutil.CheckErr(o.Run())
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.Minify, "minify", o.Minify, "Only show the entries used by the current context")
+	cmd.Flags().BoolVar(&o.Raw, "raw", o.Raw, "Show embedded certificates")
+
+	return cmd
+}
+
+// Complete completes all the required options.
+func (o *View) Complete() error {
+	var err error
+	o.printer, err = cmdutil.PrinterForCommand(nil)
+
+	return err
+}
+
+// Run executes a config view sub command using the specified options.
+func (o *View) Run() error {
+	cfg, err := o.Factory.ToRawConfig()
+	if err != nil {
+		return err
+	}
+
+	if o.Minify {
+		clientConfig, err := o.Factory.ToClientConfig()
+		if err != nil {
+			return err
+		}
+		cfg = minify(cfg, clientConfig.Context)
+	}
+	if !o.Raw {
+		cfg = redact(cfg)
+	}
+
+	return o.printer.PrintObj(cfg, o.Out)
+}
+
+// minify returns the context called name of cfg and the entries it uses.
+func minify(cfg *clientcmd.Config, name string) *clientcmd.Config {
+	out := clientcmd.NewConfig()
+	ctx := cfg.Context(name)
+	if ctx == nil {
+		return out
+	}
+
+	out.CurrentContext = name
+	out.SetContext(name, ctx)
+	if h := cfg.Hivemind(ctx.Hivemind); h != nil {
+		out.SetHivemind(ctx.Hivemind, h)
+	}
+	if u := cfg.User(ctx.User); u != nil {
+		out.SetUser(ctx.User, u)
+	}
+
+	return out
+}
+
+// redact returns a copy of cfg with its embedded data replaced.
+func redact(cfg *clientcmd.Config) *clientcmd.Config {
+	out := clientcmd.NewConfig()
+	out.CurrentContext = cfg.CurrentContext
+	out.Contexts = cfg.Contexts
+	out.Users = cfg.Users
+
+	for _, named := range cfg.Hiveminds {
+		h := *named.Hivemind
+		if h.CertificateAuthorityData != "" {
+			h.CertificateAuthorityData = redactedData
+		}
+		out.SetHivemind(named.Name, &h)
+	}
+
+	return out
+}
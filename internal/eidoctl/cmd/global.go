@@ -1,20 +1,22 @@
 package cmd
 
 import (
-	"github.com/spf13/pflag"
-)
+	"fmt"
+	"strings"
 
-var (
-	globalEidolonHiveMindAddr string
+	"github.com/kiosk404/eidolon/internal/eidoctl/types"
+	"github.com/kiosk404/eidolon/internal/pkg/options"
+	"github.com/kiosk404/eidolon/pkg/cli/printers"
+	"github.com/spf13/pflag"
 )
 
-func addGlobalFlags(flags *pflag.FlagSet) {
-	flags.StringVar(&globalEidolonHiveMindAddr,
-		"hivemind-addr",
-		"127.0.0.1:11788",
-		"Address of the hivemind central server (host:port)")
-}
+var globalOutput string
 
-func GetHIveMindAddr() string {
-	return globalEidolonHiveMindAddr
+func addGlobalFlags(flags *pflag.FlagSet, configFlags *options.ConfigFlags) {
+	configFlags.AddFlags(flags)
+	flags.StringVarP(&globalOutput,
+		types.FlagOutput,
+		"o",
+		printers.Default,
+		fmt.Sprintf("Output format. One of: %s", strings.Join(printers.AllowedFormats, "|")))
 }
@@ -3,12 +3,13 @@ package info
 import (
 	"context"
 	"fmt"
-	"reflect"
+	"io"
 	"strconv"
 
 	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
 	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
 	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/kiosk404/eidolon/pkg/cli/printers"
 	"github.com/kiosk404/eidolon/pkg/utils/iputil"
 	hoststat "github.com/likexian/host-stat-go"
 	"github.com/spf13/cobra"
@@ -16,16 +17,25 @@ import (
 
 var infoExample = templates.Examples(`
 		# Print the host information
-		eidoctl info`)
+		eidoctl info
+
+		# Print the host information as YAML
+		eidoctl info -o yaml`)
+
+// HostInfo is the information printed by 'info'.
+type HostInfo struct {
+	HostName  string `json:"hostname"   yaml:"hostname"`
+	IPAddress string `json:"ip_address" yaml:"ipAddress"`
+	OSRelease string `json:"os_release" yaml:"osRelease"`
+	CPUCore   uint64 `json:"cpu_core"   yaml:"cpuCore"`
+	MemTotal  string `json:"mem_total"  yaml:"memTotal"`
+	MemFree   string `json:"mem_free"   yaml:"memFree"`
+}
 
 // Info is an options struct to support 'info' sub command.
 type Info struct {
-	HostName  string
-	IPAddress string
-	OSRelease string
-	CPUCore   uint64
-	MemTotal  string
-	MemFree   string
+	printer printers.ResourcePrinter
+
 	genericclioptions.IOStreams
 }
 
@@ -48,6 +58,7 @@ func NewCmdInfo(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra
 		Long:                  "Print the host information.",
 		Example:               infoExample,
 		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete())
 			cmdutil.CheckErr(o.Run(cmd.Context(), args))
 		},
 		SuggestFor: []string{},
@@ -56,9 +67,17 @@ func NewCmdInfo(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra
 	return cmd
 }
 
+// Complete completes all the required options.
+func (o *Info) Complete() error {
+	var err error
+	o.printer, err = cmdutil.PrinterForCommand(printHostInfo)
+
+	return err
+}
+
 // Run executes an info sub command using the specified options.
 func (o *Info) Run(ctx context.Context, args []string) error {
-	var info Info
+	var info HostInfo
 
 	hostInfo, err := hoststat.GetHostInfo()
 	if err != nil {
@@ -84,15 +103,28 @@ func (o *Info) Run(ctx context.Context, args []string) error {
 
 	info.CPUCore = cpuStat.CoreCount
 
-	s := reflect.ValueOf(&info).Elem()
-	typeOfInfo := s.Type()
+	return o.printer.PrintObj(&info, o.Out)
+}
 
-	for i := 0; i < s.NumField(); i++ {
-		f := s.Field(i)
+func printHostInfo(obj any, w io.Writer, _ bool) error {
+	info, ok := obj.(*HostInfo)
+	if !ok {
+		return fmt.Errorf("unexpected object %T", obj)
+	}
 
-		v := fmt.Sprintf("%v", f.Interface())
-		if v != "" {
-			fmt.Fprintf(o.Out, "%12s %v\n", typeOfInfo.Field(i).Name+":", f.Interface())
+	for _, f := range []struct {
+		name  string
+		value any
+	}{
+		{"HostName", info.HostName},
+		{"IPAddress", info.IPAddress},
+		{"OSRelease", info.OSRelease},
+		{"CPUCore", info.CPUCore},
+		{"MemTotal", info.MemTotal},
+		{"MemFree", info.MemFree},
+	} {
+		if v := fmt.Sprintf("%v", f.value); v != "" {
+			fmt.Fprintf(w, "%12s %v\n", f.name+":", v)
 		}
 	}
 
@@ -2,12 +2,19 @@ package init
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
 
 	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/preflight"
 	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	"github.com/kiosk404/eidolon/internal/pkg/clientcmd"
+	"github.com/kiosk404/eidolon/internal/pkg/workspace"
 	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
-	"github.com/kiosk404/eidolon/pkg/utils/homedir"
 	"github.com/spf13/cobra"
 )
 
@@ -20,14 +27,22 @@ var initExample = templates.Examples(`
 
 		# Initialize the eidolon agent with a custom skills directory
 		eidoctl init --skills=/path/to/skills
-`)
+
+		# Regenerate the node identity of an initialized workspace
+		eidoctl init --force`)
 
 type Init struct {
 	Workspace string
 	SkillsDir string
 	DataDir   string
 	Force     bool
-	Factory   cmdutil.Factory
+
+	// IgnoreChecks lists pre-flight checks whose failures only warn.
+	IgnoreChecks []string
+
+	clientConfig *clientcmd.ClientConfig
+
+	Factory cmdutil.Factory
 	genericclioptions.IOStreams
 }
 
@@ -39,24 +54,33 @@ func NewCmdInit(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra
 		DisableFlagsInUseLine: true,
 		Aliases:               []string{},
 		Short:                 "Initialize this machine as a golem worker node",
-		Long: `Perpare the local machine to join a hivemind realm as a golem worker node.
-		This command creates the required directories (workspace, skills, data) and generates 
-		a node identity file if one does not exist. and validates the system to ensure it is 
-		ready to join the hivemind.	
-		
-		If the node is already initialized, use the --force flag to force re-initialization.
-		`,
+		Long: templates.LongDesc(`
+		Prepare the local machine to join a hivemind realm as a golem worker node.
+
+		This command validates the system to ensure it is ready to join the hivemind, creates
+		the required directories (workspace, skills, data, logs and cache), generates the node
+		identity and writes the initial golem configuration.
+
+		The node identity is an ed25519 key pair stored in the workspace. The node ID is derived
+		from its public key and stays the same until the identity is regenerated.
+
+		If the node is already initialized, use the --force flag to regenerate the identity and
+		the configuration.`),
 		Example: initExample,
 		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete())
+			cmdutil.CheckErr(o.Validate())
 			cmdutil.CheckErr(o.Run(cmd.Context(), args))
 		},
 		SuggestFor: []string{},
 	}
 
 	cmd.Flags().StringVar(&o.Workspace, "workspace", o.Workspace, "The workspace directory to use")
-	cmd.Flags().StringVar(&o.SkillsDir, "skills", o.SkillsDir, "The skills directory to use")
-	cmd.Flags().StringVar(&o.DataDir, "data", o.DataDir, "The data directory to use")
-	cmd.Flags().BoolVar(&o.Force, "force", o.Force, "Force initialization even if the workspace directory is not empty")
+	cmd.Flags().StringVar(&o.SkillsDir, "skills", o.SkillsDir, "The skills directory to use, defaults to <workspace>/skills")
+	cmd.Flags().StringVar(&o.DataDir, "data", o.DataDir, "The data directory to use, defaults to <workspace>/data")
+	cmd.Flags().BoolVar(&o.Force, "force", o.Force, "Regenerate the identity and configuration of an initialized workspace")
+	cmd.Flags().StringSliceVar(&o.IgnoreChecks, "ignore-checks", o.IgnoreChecks,
+		"Pre-flight checks whose failures are reported as warnings, 'all' ignores every check")
 
 	return cmd
 }
@@ -65,70 +89,191 @@ func NewInitOptions(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *I
 	return &Init{
 		Factory:   f,
 		IOStreams: ioStreams,
-		Workspace: homedir.HomeDir() + "/.eidolon",
-		SkillsDir: homedir.HomeDir() + "/.eidolon/skills",
-		DataDir:   homedir.HomeDir() + "/.eidolon/data",
+		Workspace: workspace.DefaultDir(),
 		Force:     false,
 	}
 }
 
+// Complete completes all the required options.
+func (o *Init) Complete() error {
+	if o.Workspace == "" {
+		return fmt.Errorf("--workspace must not be empty")
+	}
+
+	var err error
+	if o.clientConfig, err = o.Factory.ToClientConfig(); err != nil {
+		return err
+	}
+	if o.Workspace, err = filepath.Abs(o.Workspace); err != nil {
+		return err
+	}
+	if o.SkillsDir == "" {
+		o.SkillsDir = filepath.Join(o.Workspace, workspace.SkillsDir)
+	}
+	if o.DataDir == "" {
+		o.DataDir = filepath.Join(o.Workspace, workspace.DataDir)
+	}
+	if o.SkillsDir, err = filepath.Abs(o.SkillsDir); err != nil {
+		return err
+	}
+	o.DataDir, err = filepath.Abs(o.DataDir)
+
+	return err
+}
+
+// Validate makes sure there is no discrepancy in command options.
+func (o *Init) Validate() error {
+	return preflight.ValidateIgnore(o.IgnoreChecks)
+}
+
 func (o *Init) Run(ctx context.Context, args []string) error {
+	ws := workspace.New(o.Workspace)
+	if err := o.checkWorkspace(ws); err != nil {
+		return err
+	}
+
 	fmt.Fprintf(o.Out, "\nInitializing eidolon agent...\n")
 
+	fmt.Fprintf(o.Out, "\nRunning system validations...\n")
+	hasWarning, err := o.runChecks(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.Out, "\nCreating directories...\n")
+	cfg := &workspace.Config{
+		Workspace:    o.Workspace,
+		SkillsDir:    o.SkillsDir,
+		DataDir:      o.DataDir,
+		LogDir:       filepath.Join(o.DataDir, workspace.LogDir),
+		CacheDir:     filepath.Join(o.DataDir, workspace.CacheDir),
+		HivemindAddr: o.clientConfig.Hivemind.GRPCServer,
+	}
 	dirs := []struct {
 		label string
 		path  string
+		perm  os.FileMode
 	}{
-		{"workspace directory", o.Workspace},
-		{"skills directory", o.SkillsDir},
-		{"data directory", o.DataDir},
-		{"log directory", o.DataDir + "/logs"},
-		{"cache directory", o.DataDir + "/cache"},
+		{"workspace directory", cfg.Workspace, 0o700},
+		{"skills directory", cfg.SkillsDir, 0o755},
+		{"data directory", cfg.DataDir, 0o700},
+		{"log directory", cfg.LogDir, 0o750},
+		{"cache directory", cfg.CacheDir, 0o700},
+	}
+	for _, d := range dirs {
+		if err := mkdir(d.path, d.perm); err != nil {
+			return fmt.Errorf("create %s failed: %w", d.label, err)
+		}
+		fmt.Fprintf(o.Out, "✔ %s %s\n", d.label, d.path)
 	}
 
-	if o.SkillsDir != "" {
-		dirs = append(dirs, struct {
-			label string
-			path  string
-		}{label: "skills directory", path: o.SkillsDir})
+	fmt.Fprintf(o.Out, "\nGenerating node identity...\n")
+	id, err := workspace.NewIdentity()
+	if err != nil {
+		return fmt.Errorf("generate node identity failed: %w", err)
 	}
+	if err := ws.SaveIdentity(id); err != nil {
+		return fmt.Errorf("save node identity failed: %w", err)
+	}
+	fmt.Fprintf(o.Out, "✔ node %s %s\n", id.NodeID, ws.IdentityPath())
 
-	for _, d := range dirs {
-		fmt.Fprintf(o.Out, "Creating %s: %s\n", d.label, d.path)
+	cfg.NodeID = id.NodeID
+	if err := ws.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("save golem configuration failed: %w", err)
 	}
+	fmt.Fprintf(o.Out, "✔ configuration %s\n", ws.ConfigPath())
 
-	fmt.Fprintf(o.Out, "\n.Generating node identity...\n")
+	fmt.Fprintf(o.Out, "\nInitialization complete!\n")
+	if hasWarning {
+		fmt.Fprintf(o.Out, "\nSome checks passed with warnings, see above for details.\n")
+	}
+	fmt.Fprintf(o.Out, "\nYou can now join the hivemind by running 'eidoctl join'.\n")
 
-	fmt.Fprintf(o.Out, "\n.Running system validations...\n")
+	return nil
+}
 
-	checker := o.Factory.NodeChecker()
+// checkWorkspace refuses to touch a workspace that is in use unless --force is set,
+// and a joined workspace in any case since a new identity would not match the
+// membership.
+func (o *Init) checkWorkspace(ws *workspace.Workspace) error {
+	if m, err := ws.LoadMembership(); err == nil {
+		return fmt.Errorf("this node already joined %s as %s, run 'eidoctl leave' to initialize it again",
+			m.HivemindAddr, m.NodeID)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
 
-	results, err := checker.RunAll(ctx)
+	entries, err := os.ReadDir(ws.Dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	// The default workspace also holds the eidoctl configuration file.
+	configPath, err := filepath.Abs(o.Factory.ConfigPath())
 	if err != nil {
-		return fmt.Errorf("node check failed!error:%w", err)
+		return err
+	}
+	entries = slices.DeleteFunc(entries, func(e fs.DirEntry) bool {
+		return ws.Path(e.Name()) == configPath
+	})
+	if len(entries) > 0 && !o.Force {
+		if id, err := ws.LoadIdentity(); err == nil {
+			return fmt.Errorf("this node is already initialized as %s, use --force to regenerate its identity", id.NodeID)
+		}
+
+		return fmt.Errorf("workspace %s is not empty, use --force to initialize it anyway", ws.Dir)
 	}
 
-	hasWarning := false
+	return nil
+}
+
+// runChecks runs the pre-flight checks and reports whether any of them warned.
+func (o *Init) runChecks(ctx context.Context) (bool, error) {
+	opts := preflight.NewOptions()
+	opts.Workspace = o.Workspace
+	opts.DataDir = o.DataDir
+	opts.HivemindAddr = o.clientConfig.Hivemind.GRPCServer
+	opts.HivemindAPI = o.clientConfig.Hivemind.Server
+	opts.Ignore = o.IgnoreChecks
+
+	results, err := o.Factory.NodeChecker(opts).RunAll(ctx)
+	if err != nil {
+		return false, fmt.Errorf("node check failed!error:%w", err)
+	}
+
+	if failed := preflight.Print(o.Out, results); failed > 0 {
+		return false, fmt.Errorf("%d pre-flight checks failed, fix them or use --ignore-checks", failed)
+	}
 
 	for _, r := range results {
-		mark := "✔"
-		if !r.Passed && r.Status == "warning" {
-			mark = "⚠"
-			hasWarning = true
-		} else {
-			mark = "✖"
+		if r.Status == preflight.StatusWarning {
+			return true, nil
 		}
-		fmt.Fprintf(o.Out, "%s %s %s\n", mark, r.Name, r.Message)
 	}
 
-	fmt.Fprintf(o.Out, "\n.Initialization complete!\n")
-	if hasWarning {
-		fmt.Fprintf(o.Out, "\nSome checks failed with warning, please check the logs for details.\n")
-	}
-	fmt.Fprintf(o.Out, "\nYou can now join the hivemind by running 'eidoctl join'.\n")
-	return nil
+	return false, nil
 }
 
-func (o *Init) Validate() error {
-	return nil
+// mkdir creates path with perm. Only directories created here get perm, the
+// mode of existing ones is left alone.
+func mkdir(path string, perm os.FileMode) error {
+	info, err := os.Stat(path)
+	if err == nil {
+		if !info.IsDir() {
+			return fmt.Errorf("%s is not a directory", path)
+		}
+
+		return nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	if err := os.MkdirAll(path, perm); err != nil {
+		return err
+	}
+
+	// MkdirAll is subject to the umask.
+	return os.Chmod(path, perm)
 }
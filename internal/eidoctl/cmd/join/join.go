@@ -0,0 +1,385 @@
+package join
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/preflight"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+	"github.com/kiosk404/eidolon/internal/pkg/skill"
+	"github.com/kiosk404/eidolon/internal/pkg/workspace"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/kiosk404/eidolon/pkg/errorx"
+	"github.com/kiosk404/eidolon/pkg/utils/certutil"
+	"github.com/spf13/cobra"
+)
+
+var joinExample = templates.Examples(`
+		# Join a hivemind realm using a secret token
+		eidoctl join --token=<TOKEN>
+
+		# Join with a custom server address, verifying it with the hivemind CA
+		eidoctl join --token=<TOKEN> --hivemind-addr=10.0.0.1:11788 --ca-file=/etc/eidolon/ca.crt
+
+		# Join with a custom node name
+		eidoctl join --token=<TOKEN> --node-name=golem-1`)
+
+type Join struct {
+	Token string
+
+	NodeName string
+
+	Timeout time.Duration
+
+	SkipChecks bool
+
+	// IgnoreChecks lists pre-flight checks whose failures only warn.
+	IgnoreChecks []string
+
+	// Workspace is the directory the credentials and membership are written to.
+	Workspace string
+
+	// CAFile verifies the hivemind; without it the connection is not encrypted
+	// unless InsecureSkipTLSVerify is set.
+	CAFile string
+
+	InsecureSkipTLSVerify bool
+
+	hivemindAddr string
+	hivemindAPI  string
+	token        protocol.BootstrapToken
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+func NewJoinOptions(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *Join {
+	return &Join{
+		Timeout:   30 * time.Second,
+		Workspace: workspace.DefaultDir(),
+		Factory:   f,
+		IOStreams: ioStreams,
+	}
+}
+
+func NewCmdJoin(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewJoinOptions(f, ioStreams)
+
+	cmd := &cobra.Command{
+		Use:                   "join",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{},
+		Short:                 "Join this node to a hivemind using a secret token",
+		Long: templates.LongDesc(`
+		Register this node as a golem worker node in a hivemind realm using a secret token.
+
+		The hivemind issues bootstrap tokens with 'eidoctl token create'. Provide one via the
+		--token flag to authenticate and complete registration. Before joining the hivemind realm
+		the command runs pre-flight checks to verify that the node meets the realm's eligibility
+		requirements.
+
+		On success the node ID, the client certificate issued by the hivemind and the hivemind CA
+		are written to the workspace directory, where the golem picks them up.`),
+		Example: joinExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete())
+			cmdutil.CheckErr(o.Validate())
+			cmdutil.CheckErr(o.Run(cmd.Context(), args))
+		},
+		SuggestFor: []string{"jion"},
+	}
+
+	cmd.Flags().StringVar(&o.Token, "token", o.Token, "The secret token to use for joining the hivemind realm")
+	cmd.Flags().StringVar(&o.NodeName, "node-name", o.NodeName, "The name of this node, defaults to the hostname")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", o.Timeout, "The timeout duration for the join operation")
+	cmd.Flags().BoolVar(&o.SkipChecks, "skip-checks", o.SkipChecks, "Skip pre-flight checks")
+	cmd.Flags().StringSliceVar(&o.IgnoreChecks, "ignore-checks", o.IgnoreChecks,
+		"Pre-flight checks whose failures are reported as warnings, 'all' ignores every check")
+	cmd.Flags().StringVar(&o.Workspace, "workspace", o.Workspace, "The workspace directory to store the node credentials in")
+	cmd.Flags().StringVar(&o.CAFile, "ca-file", o.CAFile, "CA certificate to verify the hivemind with, enables TLS")
+	cmd.Flags().BoolVar(&o.InsecureSkipTLSVerify, "insecure-skip-tls-verify", o.InsecureSkipTLSVerify,
+		"Use TLS without verifying the hivemind certificate. This is insecure")
+
+	return cmd
+}
+
+// Complete completes all the required options.
+func (o *Join) Complete() error {
+	clientConfig, err := o.Factory.ToClientConfig()
+	if err != nil {
+		return err
+	}
+	o.hivemindAddr = clientConfig.Hivemind.GRPCServer
+	o.hivemindAPI = clientConfig.Hivemind.Server
+
+	return nil
+}
+
+// Validate makes sure there is no discrepancy in command options.
+func (o *Join) Validate() error {
+	if o.Token == "" {
+		return fmt.Errorf("--token is required")
+	}
+	token, err := protocol.ParseBootstrapToken(o.Token)
+	if err != nil {
+		return fmt.Errorf("invalid --token: %w", err)
+	}
+	o.token = token
+
+	if _, _, err := net.SplitHostPort(o.hivemindAddr); err != nil {
+		return fmt.Errorf("invalid --hivemind-addr %q: %w", o.hivemindAddr, err)
+	}
+	if o.Timeout <= 0 {
+		return fmt.Errorf("--timeout must be positive")
+	}
+	if o.Workspace == "" {
+		return fmt.Errorf("--workspace must not be empty")
+	}
+	if o.CAFile != "" && o.InsecureSkipTLSVerify {
+		return fmt.Errorf("--ca-file and --insecure-skip-tls-verify are mutually exclusive")
+	}
+	if err := preflight.ValidateIgnore(o.IgnoreChecks); err != nil {
+		return fmt.Errorf("invalid --ignore-checks: %w", err)
+	}
+
+	return nil
+}
+
+func (o *Join) Run(ctx context.Context, args []string) error {
+	ws := workspace.New(o.Workspace)
+	if m, err := ws.LoadMembership(); err == nil {
+		return fmt.Errorf("this node already joined %s as %s, run 'eidoctl leave' to join again",
+			m.HivemindAddr, m.NodeID)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+
+	if !o.SkipChecks {
+		if err := o.runChecks(ctx); err != nil {
+			return err
+		}
+	}
+
+	info, err := o.Factory.NodeInfoCollector().Collect(ctx)
+	if err != nil {
+		return fmt.Errorf("collect node info failed: %w", err)
+	}
+	if o.NodeName != "" {
+		info.Name = o.NodeName
+	}
+	// Ask for the ID generated by 'eidoctl init', the hivemind assigns one otherwise.
+	id, err := ws.LoadIdentity()
+	if err == nil {
+		info.ID = id.NodeID
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	skills, err := o.installedSkills(ws)
+	if err != nil {
+		return err
+	}
+
+	tlsConfig, caPEM, err := o.tlsConfig()
+	if err != nil {
+		return err
+	}
+	if tlsConfig == nil {
+		fmt.Fprintf(o.ErrOut, "warning: connecting to %s without TLS, the token is sent in clear text\n", o.hivemindAddr)
+	}
+
+	fmt.Fprintf(o.Out, "Connecting to hivemind %s...\n", o.hivemindAddr)
+	connector := o.Factory.HivemindConnector()
+	if err := connector.Connect(ctx, o.hivemindAddr, tlsConfig); err != nil {
+		return err
+	}
+	defer connector.Close()
+
+	// Signing the request with the identity key proves to the hivemind that
+	// the node owns the ID it asks for.
+	var keyPEM, csrPEM []byte
+	if id != nil {
+		keyPEM, csrPEM, err = certutil.NewCSRForKey(info.Name, id.PrivateKey)
+	} else {
+		keyPEM, csrPEM, err = certutil.NewCSR(info.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("generate node key failed: %w", err)
+	}
+
+	fmt.Fprintf(o.Out, "Joining as %s with token %s...\n", info.Name, o.token.ID)
+	resp, err := connector.Join(ctx, &protocol.JoinRequest{
+		Token:           o.token.String(),
+		NodeInfo:        *info,
+		InstalledSkills: skills,
+		CSR:             csrPEM,
+	})
+	if err != nil {
+		return fmt.Errorf("join hivemind failed: %w", err)
+	}
+
+	m := &workspace.Membership{
+		NodeID:       resp.NodeID,
+		NodeName:     info.Name,
+		HivemindAddr: o.hivemindAddr,
+		Tags:         resp.Tags,
+		JoinedAt:     time.Now(),
+	}
+	if err := o.saveCredentials(ws, m, resp, keyPEM, caPEM); err != nil {
+		return err
+	}
+	if err := ws.SaveMembership(m); err != nil {
+		return fmt.Errorf("save membership failed: %w", err)
+	}
+	if err := o.updateConfig(ws, resp.NodeID); err != nil {
+		return err
+	}
+	if info.ID != "" && info.ID != resp.NodeID {
+		fmt.Fprintf(o.ErrOut, "warning: the hivemind assigned the ID %s instead of %s\n", resp.NodeID, info.ID)
+	}
+
+	fmt.Fprintf(o.Out, "\nThis node has joined the hivemind as %s.\n", resp.NodeID)
+	fmt.Fprintf(o.Out, "Credentials were written to %s.\n", ws.Path(workspace.PKIDir))
+
+	return nil
+}
+
+// runChecks runs the pre-flight checks and fails on any check that failed.
+// The hivemind must be reachable to join it.
+func (o *Join) runChecks(ctx context.Context) error {
+	fmt.Fprintf(o.Out, "Running pre-flight checks...\n")
+
+	opts := preflight.NewOptions()
+	opts.Workspace = o.Workspace
+	opts.DataDir = filepath.Join(o.Workspace, "data")
+	opts.HivemindAddr = o.hivemindAddr
+	opts.HivemindAPI = o.hivemindAPI
+	opts.HivemindRequired = true
+	opts.Ignore = o.IgnoreChecks
+
+	results, err := o.Factory.NodeChecker(opts).RunAll(ctx)
+	if err != nil {
+		return fmt.Errorf("node check failed!error:%w", err)
+	}
+
+	if failed := preflight.Print(o.Out, results); failed > 0 {
+		return fmt.Errorf("%d pre-flight checks failed, fix them or use --ignore-checks", failed)
+	}
+
+	return nil
+}
+
+// installedSkills returns the skills found in the skills directory of the
+// workspace. Invalid packages are reported and left out.
+func (o *Join) installedSkills(ws *workspace.Workspace) ([]protocol.SkillInfo, error) {
+	cfg := ws.DefaultConfig()
+	if c, err := ws.LoadConfig(); err == nil {
+		cfg = c
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	skills, err := skill.Scan(cfg.SkillsDir)
+	var agg errorx.Aggregate
+	if errors.As(err, &agg) {
+		for _, e := range agg.Errors() {
+			fmt.Fprintf(o.ErrOut, "warning: skipping invalid skill package: %s\n", e.Error())
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("scan skills failed: %w", err)
+	}
+
+	infos := make([]protocol.SkillInfo, len(skills))
+	for i, s := range skills {
+		infos[i] = s.Info()
+	}
+	if len(infos) > 0 {
+		fmt.Fprintf(o.Out, "Found %d skills in %s.\n", len(infos), cfg.SkillsDir)
+	}
+
+	return infos, nil
+}
+
+// updateConfig points the golem configuration written by 'eidoctl init', if any,
+// at the hivemind the node joined.
+func (o *Join) updateConfig(ws *workspace.Workspace, nodeID string) error {
+	cfg, err := ws.LoadConfig()
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	cfg.NodeID = nodeID
+	cfg.HivemindAddr = o.hivemindAddr
+	if err := ws.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("save golem configuration failed: %w", err)
+	}
+
+	return nil
+}
+
+// tlsConfig returns the TLS configuration to reach the hivemind with, nil for a
+// plain text connection, and the PEM of --ca-file.
+func (o *Join) tlsConfig() (*tls.Config, []byte, error) {
+	host, _, _ := net.SplitHostPort(o.hivemindAddr)
+
+	switch {
+	case o.CAFile != "":
+		caPEM, err := os.ReadFile(o.CAFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, nil, fmt.Errorf("no certificates found in %s", o.CAFile)
+		}
+
+		return &tls.Config{MinVersion: tls.VersionTLS12, ServerName: host, RootCAs: pool}, caPEM, nil
+	case o.InsecureSkipTLSVerify:
+		return &tls.Config{MinVersion: tls.VersionTLS12, ServerName: host, InsecureSkipVerify: true}, nil, nil //nolint:gosec
+	default:
+		return nil, nil, nil
+	}
+}
+
+// saveCredentials writes the key, certificate and CA to the workspace and
+// records their paths in m.
+func (o *Join) saveCredentials(ws *workspace.Workspace, m *workspace.Membership,
+	resp *protocol.JoinResponse, keyPEM, caPEM []byte,
+) error {
+	if len(resp.CACertificate) > 0 {
+		caPEM = resp.CACertificate
+	}
+	if len(caPEM) > 0 {
+		if err := workspace.WriteFile(ws.CACertPath(), caPEM, 0o644); err != nil {
+			return fmt.Errorf("save CA certificate failed: %w", err)
+		}
+		m.CAFile = ws.CACertPath()
+	}
+
+	if len(resp.Certificate) > 0 {
+		if err := workspace.WriteFile(ws.KeyPath(), keyPEM, 0o600); err != nil {
+			return fmt.Errorf("save node key failed: %w", err)
+		}
+		if err := workspace.WriteFile(ws.CertPath(), resp.Certificate, 0o644); err != nil {
+			return fmt.Errorf("save node certificate failed: %w", err)
+		}
+		m.CertFile, m.KeyFile = ws.CertPath(), ws.KeyPath()
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package leave
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/internal/pkg/client/hivemind"
+	"github.com/kiosk404/eidolon/internal/pkg/workspace"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/spf13/cobra"
+)
+
+var leaveExample = templates.Examples(`
+		# Leave the hivemind once the running tasks have finished
+		eidoctl leave
+
+		# Give the running tasks ten minutes, then cancel them
+		eidoctl leave --timeout=10m --force
+
+		# Leave without asking for confirmation
+		eidoctl leave --yes`)
+
+// Leave is an options struct to support 'leave' sub command.
+type Leave struct {
+	Workspace string
+
+	// Timeout bounds the wait for running tasks; 0 waits until they finish.
+	Timeout time.Duration
+
+	// Force cancels the tasks still running when Timeout expires.
+	Force bool
+
+	Yes bool
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewLeaveOptions returns an initialized Leave instance.
+func NewLeaveOptions(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *Leave {
+	return &Leave{
+		Workspace: workspace.DefaultDir(),
+		Factory:   f,
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdLeave returns new initialized instance of 'leave' sub command.
+func NewCmdLeave(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewLeaveOptions(f, ioStreams)
+
+	cmd := &cobra.Command{
+		Use:                   "leave",
+		DisableFlagsInUseLine: true,
+		Short:                 "Remove this node from the hivemind it joined",
+		Long: templates.LongDesc(`
+		Tell the hivemind to drain this node and deregister it, then forget the membership.
+
+		The hivemind stops scheduling tasks onto the node right away and deregisters it once the
+		running tasks have finished. The command waits for that, then removes the membership and
+		the credentials issued at join time from the workspace. The node identity is kept, so
+		the node can join again with a new token. Use 'eidoctl reset' to remove it as well.`),
+		Example: leaveExample,
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate())
+			cmdutil.CheckErr(o.Run(cmd.Context(), args))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.Workspace, "workspace", o.Workspace, "The workspace directory of the node")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", o.Timeout,
+		"How long the hivemind waits for running tasks, 0 waits until they finish")
+	cmd.Flags().BoolVar(&o.Force, "force", o.Force, "Cancel the tasks still running when --timeout expires")
+	cmd.Flags().BoolVarP(&o.Yes, "yes", "y", o.Yes, "Do not ask for confirmation")
+
+	return cmd
+}
+
+// Validate makes sure there is no discrepancy in command options.
+func (o *Leave) Validate() error {
+	if o.Timeout < 0 {
+		return fmt.Errorf("--timeout must not be negative")
+	}
+	if o.Force && o.Timeout == 0 {
+		return fmt.Errorf("--force requires --timeout")
+	}
+
+	return nil
+}
+
+// Run executes a leave sub command using the specified options.
+func (o *Leave) Run(ctx context.Context, args []string) error {
+	ws := workspace.New(o.Workspace)
+	m, err := ws.LoadMembership()
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("this node has not joined a hivemind")
+	} else if err != nil {
+		return err
+	}
+
+	if !o.Yes && !cmdutil.Confirm(o.In, o.Out,
+		fmt.Sprintf("Node %s will leave the hivemind %s. Continue?", m.NodeID, m.HivemindAddr)) {
+		return fmt.Errorf("aborted")
+	}
+
+	client, err := o.Factory.HivemindClient()
+	if err != nil {
+		return err
+	}
+
+	req := &v1.DrainNodeRequest{Force: o.Force}
+	if o.Timeout > 0 {
+		req.Timeout = o.Timeout.String()
+	}
+	fmt.Fprintf(o.Out, "Draining node %s...\n", m.NodeID)
+	_, err = client.Nodes().Drain(ctx, m.NodeID, req)
+	switch {
+	case hivemind.IsNotFound(err):
+		fmt.Fprintf(o.ErrOut, "warning: the hivemind does not know node %s\n", m.NodeID)
+	case err != nil && !hivemind.IsConflict(err):
+		return fmt.Errorf("drain node failed: %w", err)
+	default:
+		// A conflict means the node is draining already, wait for that drain instead.
+		fmt.Fprintf(o.Out, "Waiting for the running tasks to finish...\n")
+		if err := cmdutil.WaitNodeDeregistered(ctx, client, m.NodeID); err != nil {
+			return err
+		}
+	}
+
+	for _, path := range []string{ws.MembershipPath(), ws.Path(workspace.PKIDir)} {
+		if err := os.RemoveAll(path); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(o.Out, "Node %s has left the hivemind.\n", m.NodeID)
+
+	return nil
+}
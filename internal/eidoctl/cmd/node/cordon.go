@@ -0,0 +1,99 @@
+package node
+
+import (
+	"context"
+	"fmt"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cordonExample = templates.Examples(`
+		# Stop placing new tasks on a node
+		eidoctl node cordon golem-3f2a9c0d1e4b5a67`)
+
+	uncordonExample = templates.Examples(`
+		# Place new tasks on a node again
+		eidoctl node uncordon golem-3f2a9c0d1e4b5a67`)
+)
+
+// Cordon is an options struct to support 'node cordon' and 'node uncordon' sub commands.
+type Cordon struct {
+	// Cordoned is the scheduling state the nodes are put in.
+	Cordoned bool
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewCmdCordon returns new initialized instance of 'node cordon' sub command.
+func NewCmdCordon(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &Cordon{Cordoned: true, Factory: f, IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:                   "cordon NODE_ID [NODE_ID...]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Mark golem nodes as unschedulable",
+		Long:                  "Mark golem nodes as unschedulable. The tasks already running on them are not affected.",
+		Example:               cordonExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 0 {
+				cmdutil.CheckErr(cmdutil.UsageErrorf(cmd, "at least one node ID is required"))
+			}
+			cmdutil.CheckErr(o.Run(cmd.Context(), args))
+		},
+	}
+
+	return cmd
+}
+
+// NewCmdUncordon returns new initialized instance of 'node uncordon' sub command.
+func NewCmdUncordon(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &Cordon{Cordoned: false, Factory: f, IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:                   "uncordon NODE_ID [NODE_ID...]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Mark golem nodes as schedulable",
+		Long:                  "Mark golem nodes as schedulable again. Uncordoning a draining node aborts the drain.",
+		Example:               uncordonExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 0 {
+				cmdutil.CheckErr(cmdutil.UsageErrorf(cmd, "at least one node ID is required"))
+			}
+			cmdutil.CheckErr(o.Run(cmd.Context(), args))
+		},
+	}
+
+	return cmd
+}
+
+// Run executes a node cordon or uncordon sub command using the specified options.
+func (o *Cordon) Run(ctx context.Context, args []string) error {
+	client, err := o.Factory.HivemindClient()
+	if err != nil {
+		return err
+	}
+
+	for _, id := range args {
+		if o.Cordoned {
+			_, err = client.Nodes().Cordon(ctx, id)
+		} else {
+			_, err = client.Nodes().Uncordon(ctx, id)
+		}
+		if err != nil {
+			return err
+		}
+
+		if o.Cordoned {
+			fmt.Fprintf(o.Out, "node/%s cordoned\n", id)
+		} else {
+			fmt.Fprintf(o.Out, "node/%s uncordoned\n", id)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package node
+
+import (
+	"context"
+	"fmt"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/spf13/cobra"
+)
+
+var deleteExample = templates.Examples(`
+		# Remove an offline node from the registry
+		eidoctl node delete golem-3f2a9c0d1e4b5a67
+
+		# Remove a node and cancel the tasks it still runs
+		eidoctl node delete golem-3f2a9c0d1e4b5a67 --force`)
+
+// Delete is an options struct to support 'node delete' sub command.
+type Delete struct {
+	// Force cancels the tasks still assigned to or running on the nodes.
+	Force bool
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewCmdDelete returns new initialized instance of 'node delete' sub command.
+func NewCmdDelete(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &Delete{Factory: f, IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:                   "delete NODE_ID [NODE_ID...]",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"rm"},
+		Short:                 "Deregister golem nodes",
+		Long: templates.LongDesc(`
+		Deregister golem nodes right away, without waiting for their tasks. A node that
+		still has tasks is refused unless --force is set, which cancels them; use
+		'eidoctl node drain' to let them finish instead.
+
+		A deleted golem that is still running registers again on its next connection.`),
+		Example: deleteExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 0 {
+				cmdutil.CheckErr(cmdutil.UsageErrorf(cmd, "at least one node ID is required"))
+			}
+			cmdutil.CheckErr(o.Run(cmd.Context(), args))
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.Force, "force", o.Force, "Cancel the tasks still assigned to the nodes")
+
+	return cmd
+}
+
+// Run executes a node delete sub command using the specified options.
+func (o *Delete) Run(ctx context.Context, args []string) error {
+	client, err := o.Factory.HivemindClient()
+	if err != nil {
+		return err
+	}
+
+	for _, id := range args {
+		if err := client.Nodes().Delete(ctx, id, o.Force); err != nil {
+			return err
+		}
+		fmt.Fprintf(o.Out, "node/%s deleted\n", id)
+	}
+
+	return nil
+}
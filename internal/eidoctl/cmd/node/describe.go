@@ -0,0 +1,217 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/internal/pkg/client/hivemind"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/kiosk404/eidolon/pkg/cli/printers"
+	"github.com/spf13/cobra"
+)
+
+var describeExample = templates.Examples(`
+		# Show the details of a node
+		eidoctl node describe golem-3f2a9c0d1e4b5a67
+
+		# Show the last 30 tasks of a node
+		eidoctl node describe golem-3f2a9c0d1e4b5a67 --tasks=30
+
+		# Print the skills of a node as YAML
+		eidoctl node describe golem-3f2a9c0d1e4b5a67 -o yaml`)
+
+// NodeDescription is what 'node describe' reports.
+type NodeDescription struct {
+	v1.Node `json:",inline" yaml:",inline"`
+
+	// RecentTasks are the latest tasks assigned to the node, newest first.
+	RecentTasks []*v1.Task `json:"recent_tasks" yaml:"recentTasks"`
+}
+
+// Describe is an options struct to support 'node describe' sub command.
+type Describe struct {
+	Tasks int
+
+	printer printers.ResourcePrinter
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewCmdDescribe returns new initialized instance of 'node describe' sub command.
+func NewCmdDescribe(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &Describe{Tasks: 10, Factory: f, IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:                   "describe NODE_ID",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"get"},
+		Short:                 "Show the details of a golem node",
+		Long: templates.LongDesc(`
+		Show the details of a golem node: its system, load and health score, the
+		capabilities it advertises, its installed skills, supported features and tags,
+		and the tasks recently assigned to it.`),
+		Example: describeExample,
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete())
+			cmdutil.CheckErr(o.Validate())
+			cmdutil.CheckErr(o.Run(cmd.Context(), args))
+		},
+	}
+
+	cmd.Flags().IntVar(&o.Tasks, "tasks", o.Tasks, "The number of recent tasks to show, 0 hides them")
+
+	return cmd
+}
+
+// Complete completes all the required options.
+func (o *Describe) Complete() error {
+	var err error
+	o.printer, err = cmdutil.PrinterForCommand(printDescription)
+
+	return err
+}
+
+// Validate makes sure there is no discrepancy in command options.
+func (o *Describe) Validate() error {
+	if o.Tasks < 0 || o.Tasks > v1.MaxListLimit {
+		return fmt.Errorf("--tasks must be between 0 and %d", v1.MaxListLimit)
+	}
+
+	return nil
+}
+
+// Run executes a node describe sub command using the specified options.
+func (o *Describe) Run(ctx context.Context, args []string) error {
+	client, err := o.Factory.HivemindClient()
+	if err != nil {
+		return err
+	}
+
+	node, err := client.Nodes().Get(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	desc := &NodeDescription{Node: *node, RecentTasks: []*v1.Task{}}
+
+	if o.Tasks > 0 {
+		tasks, err := client.Tasks().List(ctx, hivemind.TaskListOptions{
+			ListOptions: v1.ListOptions{Limit: o.Tasks},
+			NodeID:      node.ID,
+		})
+		if err != nil {
+			return err
+		}
+		desc.RecentTasks = tasks.Items
+	}
+
+	return o.printer.PrintObj(desc, o.Out)
+}
+
+func printDescription(obj any, out io.Writer, wide bool) error {
+	d, ok := obj.(*NodeDescription)
+	if !ok {
+		return fmt.Errorf("unexpected object %T", obj)
+	}
+	n, sys := &d.Node, d.SystemInfo
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+
+	fmt.Fprintf(w, "ID:\t%s\n", n.ID)
+	fmt.Fprintf(w, "Name:\t%s\n", n.Name)
+	fmt.Fprintf(w, "Address:\t%s\n", n.Address)
+	fmt.Fprintf(w, "Version:\t%s\n", n.Version)
+	fmt.Fprintf(w, "Status:\t%s\n", n.Status)
+	fmt.Fprintf(w, "Scheduling:\t%s\n", scheduling(n))
+	fmt.Fprintf(w, "Health:\t%.2f\n", n.HealthScore)
+	fmt.Fprintf(w, "System:\t%s/%s, %s, %d cores, %d MB memory, %d MB disk free\n",
+		sys.OS, sys.Arch, sys.Hostname, sys.CPUCores, sys.MemoryMB, sys.DiskFreeMB)
+	fmt.Fprintf(w, "Load:\tcpu %.1f%%, memory %.1f%%, %d active, %d queued\n",
+		n.Load.CPUPercent, n.Load.MemoryPercent, n.Load.ActiveTasks, n.Load.QueuedTasks)
+	fmt.Fprintf(w, "Last heartbeat:\t%s\n", heartbeat(n.LastHeartbeat))
+	fmt.Fprintf(w, "Registered:\t%s (%s ago)\n", n.RegisteredAt.Local().Format(time.RFC3339), age(n.RegisteredAt))
+	fmt.Fprintf(w, "Tags:\t%s\n", formatTags(n.Tags))
+	fmt.Fprintf(w, "Features:\t%s\n", formatList(n.SupportedFeatures))
+	fmt.Fprintf(w, "Capabilities:\t%s\n", formatList(capabilities(n)))
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\nInstalled skills:\n")
+	if len(n.InstalledSkills) == 0 {
+		fmt.Fprintf(out, "  <none>\n")
+	} else {
+		table := printers.NewTable(
+			printers.Column{Name: "  NAME"},
+			printers.Column{Name: "VERSION"},
+			printers.Column{Name: "CAPABILITIES"},
+			printers.Column{Name: "ID", Wide: true},
+		)
+		for _, sk := range n.InstalledSkills {
+			table.AddRow("  "+sk.Name, sk.Version, strings.Join(sk.Capabilities, ","), sk.ID)
+		}
+		if err := table.Print(out, wide); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(out, "\nRecent tasks:\n")
+	if len(d.RecentTasks) == 0 {
+		_, err := fmt.Fprintf(out, "  <none>\n")
+		return err
+	}
+	table := printers.NewTable(
+		printers.Column{Name: "  ID"},
+		printers.Column{Name: "NAME"},
+		printers.Column{Name: "TYPE"},
+		printers.Column{Name: "STATUS"},
+		printers.Column{Name: "AGE"},
+	)
+	for _, t := range d.RecentTasks {
+		table.AddRow("  "+t.ID, t.Name, t.Type, string(t.Status), age(t.CreatedAt))
+	}
+
+	return table.Print(out, wide)
+}
+
+// capabilities returns the capabilities the node advertises and those its
+// skills provide, sorted and without duplicates.
+func capabilities(n *v1.Node) []string {
+	seen := make(map[string]struct{})
+	for _, c := range n.Capabilities {
+		name := c.Name
+		if c.Version != "" {
+			name += "@" + c.Version
+		}
+		seen[name] = struct{}{}
+	}
+	for _, sk := range n.InstalledSkills {
+		for _, c := range sk.Capabilities {
+			seen[c] = struct{}{}
+		}
+	}
+
+	out := make([]string, 0, len(seen))
+	for c := range seen {
+		out = append(out, c)
+	}
+	sort.Strings(out)
+
+	return out
+}
+
+func formatList(items []string) string {
+	if len(items) == 0 {
+		return "<none>"
+	}
+
+	return strings.Join(items, ", ")
+}
@@ -0,0 +1,113 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/spf13/cobra"
+)
+
+var drainExample = templates.Examples(`
+		# Drain a node once its running tasks have finished
+		eidoctl node drain golem-3f2a9c0d1e4b5a67
+
+		# Give the running tasks 10 minutes, then cancel them
+		eidoctl node drain golem-3f2a9c0d1e4b5a67 --timeout=10m --force
+
+		# Wait until the node is deregistered
+		eidoctl node drain golem-3f2a9c0d1e4b5a67 --wait`)
+
+// Drain is an options struct to support 'node drain' sub command.
+type Drain struct {
+	// Timeout bounds the wait for running tasks; 0 waits until they finish.
+	Timeout time.Duration
+
+	// Force cancels the tasks still running when Timeout expires.
+	Force bool
+
+	// Wait blocks until the hivemind deregistered the nodes.
+	Wait bool
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewCmdDrain returns new initialized instance of 'node drain' sub command.
+func NewCmdDrain(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &Drain{Factory: f, IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:                   "drain NODE_ID [NODE_ID...]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Drain golem nodes before removing them",
+		Long: templates.LongDesc(`
+		Drain golem nodes: cordon them and deregister them once the tasks assigned to
+		them have finished.
+
+		Without --force a node whose tasks outlive --timeout stays cordoned and
+		registered. Uncordoning a draining node aborts the drain.`),
+		Example: drainExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 0 {
+				cmdutil.CheckErr(cmdutil.UsageErrorf(cmd, "at least one node ID is required"))
+			}
+			cmdutil.CheckErr(o.Validate())
+			cmdutil.CheckErr(o.Run(cmd.Context(), args))
+		},
+	}
+
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", o.Timeout,
+		"How long the hivemind waits for running tasks, 0 waits until they finish")
+	cmd.Flags().BoolVar(&o.Force, "force", o.Force, "Cancel the tasks still running when --timeout expires")
+	cmd.Flags().BoolVar(&o.Wait, "wait", o.Wait, "Wait until the nodes are deregistered")
+
+	return cmd
+}
+
+// Validate makes sure there is no discrepancy in command options.
+func (o *Drain) Validate() error {
+	if o.Timeout < 0 {
+		return fmt.Errorf("--timeout must not be negative")
+	}
+	if o.Force && o.Timeout == 0 {
+		return fmt.Errorf("--force requires --timeout")
+	}
+
+	return nil
+}
+
+// Run executes a node drain sub command using the specified options.
+func (o *Drain) Run(ctx context.Context, args []string) error {
+	client, err := o.Factory.HivemindClient()
+	if err != nil {
+		return err
+	}
+
+	req := &v1.DrainNodeRequest{Force: o.Force}
+	if o.Timeout > 0 {
+		req.Timeout = o.Timeout.String()
+	}
+	for _, id := range args {
+		if _, err := client.Nodes().Drain(ctx, id, req); err != nil {
+			return err
+		}
+		fmt.Fprintf(o.Out, "node/%s draining\n", id)
+	}
+
+	if !o.Wait {
+		return nil
+	}
+	for _, id := range args {
+		if err := cmdutil.WaitNodeDeregistered(ctx, client, id); err != nil {
+			return err
+		}
+		fmt.Fprintf(o.Out, "node/%s drained\n", id)
+	}
+
+	return nil
+}
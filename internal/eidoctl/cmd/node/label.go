@@ -0,0 +1,129 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/spf13/cobra"
+)
+
+var labelExample = templates.Examples(`
+		# Tag a node with region=lab and gpu=a100
+		eidoctl node label golem-3f2a9c0d1e4b5a67 region=lab gpu=a100
+
+		# Change the value of an existing tag
+		eidoctl node label golem-3f2a9c0d1e4b5a67 region=prod --overwrite
+
+		# Remove the gpu tag
+		eidoctl node label golem-3f2a9c0d1e4b5a67 gpu-`)
+
+// Label is an options struct to support 'node label' sub command.
+type Label struct {
+	Overwrite bool
+
+	set    map[string]string
+	remove []string
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewCmdLabel returns new initialized instance of 'node label' sub command.
+func NewCmdLabel(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &Label{Factory: f, IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:                   "label NODE_ID KEY=VALUE... [KEY-...]",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"tag"},
+		Short:                 "Update the tags of a golem node",
+		Long: templates.LongDesc(`
+		Update the tags of a golem node. KEY=VALUE sets a tag and KEY- removes it.
+
+		Tags are matched against the preferred tags of a task by the scheduler. Changing
+		the value of an existing tag requires --overwrite.`),
+		Example: labelExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) < 2 {
+				cmdutil.CheckErr(cmdutil.UsageErrorf(cmd, "a node ID and at least one tag are required"))
+			}
+			cmdutil.CheckErr(o.Complete(args[1:]))
+			cmdutil.CheckErr(o.Run(cmd.Context(), args))
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.Overwrite, "overwrite", o.Overwrite, "Allow changing the value of existing tags")
+
+	return cmd
+}
+
+// Complete parses the tag arguments.
+func (o *Label) Complete(args []string) error {
+	o.set = make(map[string]string)
+	for _, arg := range args {
+		if key, ok := strings.CutSuffix(arg, "-"); ok && !strings.Contains(arg, "=") {
+			if key == "" {
+				return fmt.Errorf("invalid tag removal %q", arg)
+			}
+			o.remove = append(o.remove, key)
+
+			continue
+		}
+
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || key == "" {
+			return fmt.Errorf("invalid tag %q, expected KEY=VALUE or KEY-", arg)
+		}
+		if _, dup := o.set[key]; dup {
+			return fmt.Errorf("tag %q is set more than once", key)
+		}
+		o.set[key] = value
+	}
+
+	for _, key := range o.remove {
+		if _, ok := o.set[key]; ok {
+			return fmt.Errorf("tag %q is both set and removed", key)
+		}
+	}
+
+	return nil
+}
+
+// Run executes a node label sub command using the specified options.
+func (o *Label) Run(ctx context.Context, args []string) error {
+	client, err := o.Factory.HivemindClient()
+	if err != nil {
+		return err
+	}
+
+	id := args[0]
+	node, err := client.Nodes().Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	tags := make(map[string]string, len(node.Tags)+len(o.set))
+	for k, v := range node.Tags {
+		tags[k] = v
+	}
+	for k, v := range o.set {
+		if old, ok := tags[k]; ok && old != v && !o.Overwrite {
+			return fmt.Errorf("node %s already has tag %s=%s, use --overwrite to change it", id, k, old)
+		}
+		tags[k] = v
+	}
+	for _, k := range o.remove {
+		delete(tags, k)
+	}
+
+	if _, err := client.Nodes().UpdateTags(ctx, id, tags); err != nil {
+		return err
+	}
+	fmt.Fprintf(o.Out, "node/%s labeled\n", id)
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/internal/pkg/client/hivemind"
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/kiosk404/eidolon/pkg/cli/printers"
+	"github.com/spf13/cobra"
+)
+
+var listExample = templates.Examples(`
+		# List every registered node
+		eidoctl node list
+
+		# List the online nodes with their address, system and tags
+		eidoctl node list --status=online -o wide
+
+		# List the IDs of the cordoned nodes
+		eidoctl node list --cordoned -o jsonpath='{range .items[*]}{.id}{"\n"}{end}'`)
+
+// List is an options struct to support 'node list' sub command.
+type List struct {
+	Status   string
+	Cordoned bool
+	Limit    int
+
+	cordoned *bool
+	printer  printers.ResourcePrinter
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewCmdList returns new initialized instance of 'node list' sub command.
+func NewCmdList(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &List{Limit: v1.MaxListLimit, Factory: f, IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:                   "list",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"ls"},
+		Short:                 "List golem nodes",
+		Long:                  "List the golem nodes registered in the hivemind, including the offline ones, sorted by ID.",
+		Example:               listExample,
+		Args:                  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(cmd))
+			cmdutil.CheckErr(o.Validate())
+			cmdutil.CheckErr(o.Run(cmd.Context(), args))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.Status, "status", o.Status, "Only list nodes in this state: online or offline")
+	cmd.Flags().BoolVar(&o.Cordoned, "cordoned", o.Cordoned, "Only list cordoned nodes, --cordoned=false lists the schedulable ones")
+	cmd.Flags().IntVar(&o.Limit, "limit", o.Limit, "The maximum number of nodes to list")
+
+	return cmd
+}
+
+// Complete completes all the required options.
+func (o *List) Complete(cmd *cobra.Command) error {
+	if cmd.Flags().Changed("cordoned") {
+		o.cordoned = &o.Cordoned
+	}
+
+	var err error
+	o.printer, err = cmdutil.PrinterForCommand(printNodes)
+
+	return err
+}
+
+// Validate makes sure there is no discrepancy in command options.
+func (o *List) Validate() error {
+	switch protocol.NodeStatus(o.Status) {
+	case "", protocol.NodeStatusOnline, protocol.NodeStatusOffline:
+	default:
+		return fmt.Errorf("unknown status %q, expected %s or %s", o.Status, protocol.NodeStatusOnline, protocol.NodeStatusOffline)
+	}
+	if o.Limit <= 0 || o.Limit > v1.MaxListLimit {
+		return fmt.Errorf("--limit must be between 1 and %d", v1.MaxListLimit)
+	}
+
+	return nil
+}
+
+// Run executes a node list sub command using the specified options.
+func (o *List) Run(ctx context.Context, args []string) error {
+	client, err := o.Factory.HivemindClient()
+	if err != nil {
+		return err
+	}
+
+	list, err := client.Nodes().List(ctx, hivemind.NodeListOptions{
+		ListOptions: v1.ListOptions{Limit: o.Limit},
+		Status:      o.Status,
+		Cordoned:    o.cordoned,
+	})
+	if err != nil {
+		return err
+	}
+
+	return o.printer.PrintObj(list, o.Out)
+}
+
+func printNodes(obj any, w io.Writer, wide bool) error {
+	list, ok := obj.(*v1.NodeList)
+	if !ok {
+		return fmt.Errorf("unexpected object %T", obj)
+	}
+
+	table := printers.NewTable(
+		printers.Column{Name: "ID"},
+		printers.Column{Name: "NAME"},
+		printers.Column{Name: "STATUS"},
+		printers.Column{Name: "SCHEDULING"},
+		printers.Column{Name: "HEALTH"},
+		printers.Column{Name: "TASKS"},
+		printers.Column{Name: "SKILLS"},
+		printers.Column{Name: "AGE"},
+		printers.Column{Name: "ADDRESS", Wide: true},
+		printers.Column{Name: "VERSION", Wide: true},
+		printers.Column{Name: "SYSTEM", Wide: true},
+		printers.Column{Name: "LAST HEARTBEAT", Wide: true},
+		printers.Column{Name: "TAGS", Wide: true},
+	)
+	for _, n := range list.Items {
+		sys := n.SystemInfo
+		table.AddRow(n.ID, n.Name, string(n.Status), scheduling(n), fmt.Sprintf("%.2f", n.HealthScore),
+			fmt.Sprintf("%d/%d", n.Load.ActiveTasks, n.Load.QueuedTasks), fmt.Sprint(len(n.InstalledSkills)),
+			age(n.RegisteredAt), n.Address, n.Version,
+			fmt.Sprintf("%s/%s %dc %dMB", sys.OS, sys.Arch, sys.CPUCores, sys.MemoryMB),
+			heartbeat(n.LastHeartbeat), formatTags(n.Tags))
+	}
+
+	return table.Print(w, wide)
+}
+
+func heartbeat(t time.Time) string {
+	if t.IsZero() {
+		return "<never>"
+	}
+
+	return age(t) + " ago"
+}
+
+// age formats the time elapsed since t in its two most significant units, like: 3h12m.
+func age(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case t.IsZero():
+		return "<unknown>"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%ds", int(d.Minutes()), int(d.Seconds())%60)
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh%dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dd%dh", int(d.Hours())/24, int(d.Hours())%24)
+	}
+}
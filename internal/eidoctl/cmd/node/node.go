@@ -0,0 +1,67 @@
+package node
+
+import (
+	"sort"
+	"strings"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/spf13/cobra"
+)
+
+var nodeLong = templates.LongDesc(`
+		Manage the golem nodes registered in the hivemind.
+
+		A cordoned node keeps running its tasks but is not picked for new ones. Draining
+		a node cordons it and deregisters it once its tasks have finished, deleting it
+		deregisters it right away.`)
+
+// NewCmdNode returns new initialized instance of 'node' sub command.
+func NewCmdNode(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "node SUBCOMMAND",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"nodes"},
+		Short:                 "Manage golem nodes",
+		Long:                  nodeLong,
+		Run:                   cmdutil.DefaultSubCommandRun(ioStreams.ErrOut),
+	}
+
+	cmd.AddCommand(NewCmdList(f, ioStreams))
+	cmd.AddCommand(NewCmdDescribe(f, ioStreams))
+	cmd.AddCommand(NewCmdLabel(f, ioStreams))
+	cmd.AddCommand(NewCmdCordon(f, ioStreams))
+	cmd.AddCommand(NewCmdUncordon(f, ioStreams))
+	cmd.AddCommand(NewCmdDrain(f, ioStreams))
+	cmd.AddCommand(NewCmdDelete(f, ioStreams))
+
+	return cmd
+}
+
+// scheduling describes whether new tasks may be placed on the node.
+func scheduling(n *v1.Node) string {
+	switch {
+	case n.Draining:
+		return "draining"
+	case n.Cordoned:
+		return "cordoned"
+	default:
+		return "schedulable"
+	}
+}
+
+func formatTags(tags map[string]string) string {
+	if len(tags) == 0 {
+		return "<none>"
+	}
+
+	pairs := make([]string, 0, len(tags))
+	for k, v := range tags {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
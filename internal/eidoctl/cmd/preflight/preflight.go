@@ -0,0 +1,147 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/preflight"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	"github.com/kiosk404/eidolon/internal/pkg/clientcmd"
+	"github.com/kiosk404/eidolon/internal/pkg/workspace"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/kiosk404/eidolon/pkg/cli/printers"
+	"github.com/spf13/cobra"
+)
+
+var preflightExample = templates.Examples(`
+		# Run every pre-flight check
+		eidoctl preflight
+
+		# Run a single check
+		eidoctl preflight disk
+
+		# Print the status of every check
+		eidoctl preflight -o jsonpath='{range [*]}{.name}: {.status}{"\n"}{end}'
+
+		# Make sure the ports a golem listens on are free, ignoring the memory check
+		eidoctl preflight --ports=11800,11801 --ignore-checks=memory`)
+
+// Preflight is an options struct to support 'preflight' sub command.
+type Preflight struct {
+	Workspace    string
+	DataDir      string
+	Ports        []int
+	IgnoreChecks []string
+
+	clientConfig *clientcmd.ClientConfig
+	printer      printers.ResourcePrinter
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewPreflightOptions returns an initialized Preflight instance.
+func NewPreflightOptions(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *Preflight {
+	return &Preflight{
+		Workspace: workspace.DefaultDir(),
+		Factory:   f,
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdPreflight returns new initialized instance of 'preflight' sub command.
+func NewCmdPreflight(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewPreflightOptions(f, ioStreams)
+
+	cmd := &cobra.Command{
+		Use:                   "preflight [CHECK]",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"check"},
+		Short:                 "Run the pre-flight checks of this node",
+		Long: templates.LongDesc(fmt.Sprintf(`
+		Run the checks 'eidoctl init' and 'eidoctl join' run before they touch the node.
+
+		Every check either passes, warns or fails. The command fails when a check fails
+		unless it is listed in --ignore-checks.
+
+		Available checks: %s.`, strings.Join(preflight.Names(), ", "))),
+		Example: preflightExample,
+		Args:    cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete())
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(cmd.Context(), args))
+		},
+		ValidArgs: preflight.Names(),
+	}
+
+	cmd.Flags().StringVar(&o.Workspace, "workspace", o.Workspace, "The workspace directory to check")
+	cmd.Flags().StringVar(&o.DataDir, "data", o.DataDir, "The data directory to check, defaults to <workspace>/data")
+	cmd.Flags().IntSliceVar(&o.Ports, "ports", o.Ports, "Ports that must be free to listen on")
+	cmd.Flags().StringSliceVar(&o.IgnoreChecks, "ignore-checks", o.IgnoreChecks,
+		"Checks whose failures are reported as warnings, 'all' ignores every check")
+
+	return cmd
+}
+
+// Complete completes all the required options.
+func (o *Preflight) Complete() error {
+	var err error
+	if o.clientConfig, err = o.Factory.ToClientConfig(); err != nil {
+		return err
+	}
+	o.printer, err = cmdutil.PrinterForCommand(func(obj any, w io.Writer, _ bool) error {
+		preflight.Print(w, obj.([]*cmdutil.NodeCheckResult))
+		return nil
+	})
+
+	return err
+}
+
+// Validate makes sure there is no discrepancy in command options.
+func (o *Preflight) Validate(args []string) error {
+	return preflight.ValidateIgnore(o.IgnoreChecks)
+}
+
+// Run executes a preflight sub command using the specified options.
+func (o *Preflight) Run(ctx context.Context, args []string) error {
+	opts := preflight.NewOptions()
+	opts.Workspace = o.Workspace
+	opts.DataDir = o.DataDir
+	if opts.DataDir == "" {
+		opts.DataDir = filepath.Join(o.Workspace, "data")
+	}
+	opts.HivemindAddr = o.clientConfig.Hivemind.GRPCServer
+	opts.HivemindAPI = o.clientConfig.Hivemind.Server
+	opts.Ports = o.Ports
+	opts.Ignore = o.IgnoreChecks
+
+	checker := o.Factory.NodeChecker(opts)
+
+	var results []*cmdutil.NodeCheckResult
+	if len(args) == 1 {
+		r, err := checker.RunChecker(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		results = append(results, r)
+	} else {
+		var err error
+		if results, err = checker.RunAll(ctx); err != nil {
+			return fmt.Errorf("node check failed!error:%w", err)
+		}
+	}
+
+	if err := o.printer.PrintObj(results, o.Out); err != nil {
+		return err
+	}
+	if failed := preflight.Failed(results); failed > 0 {
+		return fmt.Errorf("%d pre-flight checks failed", failed)
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package reset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	"github.com/kiosk404/eidolon/internal/pkg/workspace"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/kiosk404/eidolon/pkg/utils/homedir"
+	"github.com/spf13/cobra"
+)
+
+var resetExample = templates.Examples(`
+		# Remove the identity, credentials and caches of this node
+		eidoctl reset
+
+		# Remove the whole workspace and the installed skills without asking
+		eidoctl reset --purge --yes`)
+
+// Reset is an options struct to support 'reset' sub command.
+type Reset struct {
+	Workspace string
+
+	// Purge removes the workspace and the skills directory as a whole.
+	Purge bool
+
+	Yes bool
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewResetOptions returns an initialized Reset instance.
+func NewResetOptions(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *Reset {
+	return &Reset{
+		Workspace: workspace.DefaultDir(),
+		Factory:   f,
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdReset returns new initialized instance of 'reset' sub command.
+func NewCmdReset(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewResetOptions(f, ioStreams)
+
+	cmd := &cobra.Command{
+		Use:                   "reset",
+		DisableFlagsInUseLine: true,
+		Short:                 "Revert the changes made to this node by 'eidoctl init' and 'eidoctl join'",
+		Long: templates.LongDesc(`
+		Remove the node identity, the golem configuration, the membership, the credentials
+		issued by the hivemind and the caches from the workspace. Installed skills, data and
+		logs are kept unless --purge is given, which removes the workspace and the skills
+		directory altogether. The eidoctl configuration file is kept in any case.
+
+		Reset does not talk to the hivemind. Run 'eidoctl leave' first, or the node stays
+		registered until an operator removes it.`),
+		Example: resetExample,
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Run(cmd.Context(), args))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.Workspace, "workspace", o.Workspace, "The workspace directory to reset")
+	cmd.Flags().BoolVar(&o.Purge, "purge", o.Purge, "Remove the whole workspace and skills directory")
+	cmd.Flags().BoolVarP(&o.Yes, "yes", "y", o.Yes, "Do not ask for confirmation")
+
+	return cmd
+}
+
+// Run executes a reset sub command using the specified options.
+func (o *Reset) Run(ctx context.Context, args []string) error {
+	ws := workspace.New(o.Workspace)
+
+	if m, err := ws.LoadMembership(); err == nil {
+		fmt.Fprintf(o.ErrOut, "warning: node %s is still a member of the hivemind %s, "+
+			"run 'eidoctl leave' first to deregister it\n", m.NodeID, m.HivemindAddr)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		fmt.Fprintf(o.ErrOut, "warning: %s\n", err.Error())
+	}
+
+	paths, err := o.paths(ws)
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		fmt.Fprintf(o.Out, "Nothing to reset in %s.\n", ws.Dir)
+		return nil
+	}
+
+	fmt.Fprintf(o.Out, "The following will be removed:\n")
+	for _, path := range paths {
+		fmt.Fprintf(o.Out, "  %s\n", path)
+	}
+	if !o.Yes && !cmdutil.Confirm(o.In, o.Out, "Continue?") {
+		return fmt.Errorf("aborted")
+	}
+
+	for _, path := range paths {
+		if err := os.RemoveAll(path); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(o.Out, "Node reset, run 'eidoctl init' to initialize it again.\n")
+
+	return nil
+}
+
+// paths returns the existing paths to remove.
+func (o *Reset) paths(ws *workspace.Workspace) ([]string, error) {
+	cfg := ws.DefaultConfig()
+	if c, err := ws.LoadConfig(); err == nil {
+		cfg = c
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		fmt.Fprintf(o.ErrOut, "warning: %s, assuming the default layout\n", err.Error())
+	}
+
+	var candidates []string
+	if o.Purge {
+		dir, err := filepath.Abs(ws.Dir)
+		if err != nil {
+			return nil, err
+		}
+		if dir == filepath.Dir(dir) || dir == filepath.Clean(homedir.HomeDir()) {
+			return nil, fmt.Errorf("refusing to purge %s", dir)
+		}
+		if candidates, err = o.purgeCandidates(dir); err != nil {
+			return nil, err
+		}
+		if rel, err := filepath.Rel(dir, cfg.SkillsDir); err != nil || strings.HasPrefix(rel, "..") {
+			candidates = append(candidates, cfg.SkillsDir)
+		}
+	} else {
+		candidates = []string{
+			ws.IdentityPath(),
+			ws.ConfigPath(),
+			ws.MembershipPath(),
+			ws.Path(workspace.PKIDir),
+			cfg.CacheDir,
+		}
+	}
+
+	var paths []string
+	for _, path := range candidates {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Lstat(path); err == nil {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths, nil
+}
+
+// purgeCandidates returns dir, or its entries when it holds the eidoctl
+// configuration file, which is not part of the node and is kept.
+func (o *Reset) purgeCandidates(dir string) ([]string, error) {
+	configPath, err := filepath.Abs(o.Factory.ConfigPath())
+	if err != nil {
+		return nil, err
+	}
+	if filepath.Dir(configPath) != dir {
+		return []string{dir}, nil
+	}
+	if _, err := os.Lstat(configPath); err != nil {
+		return []string{dir}, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if path := filepath.Join(dir, e.Name()); path != configPath {
+			candidates = append(candidates, path)
+		}
+	}
+
+	return candidates, nil
+}
@@ -0,0 +1,67 @@
+package skill
+
+import (
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/spf13/cobra"
+)
+
+var activateExample = templates.Examples(`
+		# Roll web-search back to an installed version
+		eidoctl skill activate web-search@1.1.0`)
+
+// Activate is an options struct to support 'skill activate' sub command.
+type Activate struct {
+	*StoreOptions
+
+	id      string
+	version string
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewCmdActivate returns new initialized instance of 'skill activate' sub command.
+func NewCmdActivate(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &Activate{StoreOptions: NewStoreOptions(), Factory: f, IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:                   "activate ID@VERSION",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"rollback"},
+		Short:                 "Switch a skill to another installed version",
+		Long:                  "Make an installed version of a skill the active one and notify the running golem.",
+		Example:               activateExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(cmd, args))
+			cmdutil.CheckErr(o.Run())
+		},
+	}
+
+	o.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// Complete completes all the required options.
+func (o *Activate) Complete(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return cmdutil.UsageErrorf(cmd, "exactly one ID@VERSION is required")
+	}
+	if o.id, o.version = splitRef(args[0]); o.id == "" || o.version == "" {
+		return cmdutil.UsageErrorf(cmd, "%q must be ID@VERSION", args[0])
+	}
+
+	return nil
+}
+
+// Run executes a skill activate sub command using the specified options.
+func (o *Activate) Run() error {
+	ws, store, err := o.Store()
+	if err != nil {
+		return err
+	}
+
+	return activate(o.IOStreams, ws, store, o.id, o.version)
+}
@@ -0,0 +1,157 @@
+package skill
+
+import (
+	"context"
+	"fmt"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	"github.com/kiosk404/eidolon/internal/pkg/skill"
+	"github.com/kiosk404/eidolon/internal/pkg/workspace"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/spf13/cobra"
+)
+
+var installExample = templates.Examples(`
+		# Install the package in a local directory
+		eidoctl skill install ./web-search
+
+		# Install a package archive after checking its checksum
+		eidoctl skill install https://example.com/web-search-1.2.0.tar.gz --checksum=sha256:9f86d0...
+
+		# Install a tag of a git repository and make it the active version
+		eidoctl skill install git+https://github.com/example/web-search@v1.2.0 --activate`)
+
+// Install is an options struct to support 'skill install' sub command.
+type Install struct {
+	*StoreOptions
+
+	// Checksum is the expected digest of the archive, or of the package when
+	// it is not installed from an archive.
+	Checksum string
+
+	// Force replaces a version that is already installed.
+	Force bool
+
+	// Activate makes the new version active even if the skill already has an
+	// active version.
+	Activate bool
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewInstallOptions returns an initialized Install instance.
+func NewInstallOptions(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *Install {
+	return &Install{
+		StoreOptions: NewStoreOptions(),
+		Factory:      f,
+		IOStreams:    ioStreams,
+	}
+}
+
+// NewCmdInstall returns new initialized instance of 'skill install' sub command.
+func NewCmdInstall(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewInstallOptions(f, ioStreams)
+
+	cmd := &cobra.Command{
+		Use:                   "install (PATH | ARCHIVE | URL | GIT-URL[@REF]) [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Install a skill package",
+		Long: templates.LongDesc(`
+		Install a skill package from a local directory, a tar archive, gzip compressed or not,
+		on disk or served over http(s), or a git repository. Git sources are prefixed with
+		'git+' or end with '.git', and may name the branch or tag to install after an '@'.
+
+		The version is installed next to the other versions of the skill. It becomes the
+		active version when the skill has none yet or when --activate is given, see
+		'eidoctl skill upgrade' to move an installed skill to a new version.`),
+		Example: installExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(cmd.Context(), args))
+		},
+	}
+
+	o.AddFlags(cmd.Flags())
+	cmd.Flags().StringVar(&o.Checksum, "checksum", o.Checksum,
+		"The expected sha256 digest of the archive, or of the package for directories and git repositories")
+	cmd.Flags().BoolVar(&o.Force, "force", o.Force, "Replace the version if it is already installed")
+	cmd.Flags().BoolVar(&o.Activate, "activate", o.Activate, "Make the installed version the active one")
+
+	return cmd
+}
+
+// Validate makes sure there is no discrepancy in command options.
+func (o *Install) Validate(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return cmdutil.UsageErrorf(cmd, "exactly one package source is required")
+	}
+
+	return nil
+}
+
+// Run executes a skill install sub command using the specified options.
+func (o *Install) Run(ctx context.Context, args []string) error {
+	ws, store, err := o.Store()
+	if err != nil {
+		return err
+	}
+
+	pkg, err := fetch(ctx, o.IOStreams, args[0])
+	if err != nil {
+		return err
+	}
+	defer pkg.Close()
+
+	v, err := install(o.IOStreams, store, pkg, o.Checksum, o.Force)
+	if err != nil {
+		return err
+	}
+
+	active, err := store.Active(v.ID)
+	if err != nil {
+		return err
+	}
+	if active != "" && !o.Activate {
+		fmt.Fprintf(o.Out, "Skill %s@%s stays active, run 'eidoctl skill activate %s@%s' to switch to this version.\n",
+			v.ID, active, v.ID, v.Version)
+		return nil
+	}
+
+	return activate(o.IOStreams, ws, store, v.ID, v.Version)
+}
+
+// install checks the fetched package against checksum when set and installs
+// it in store.
+func install(streams genericclioptions.IOStreams, store *skill.Store, pkg *fetched,
+	checksum string, force bool,
+) (*skill.Version, error) {
+	if checksum != "" {
+		if err := skill.VerifyDigest(pkg.Digest, checksum); err != nil {
+			return nil, fmt.Errorf("%s: %w", pkg.Source, err)
+		}
+	} else {
+		fmt.Fprintf(streams.ErrOut, "warning: no checksum given, %s is installed unverified (%s)\n", pkg.Source, pkg.Digest)
+	}
+
+	v, err := store.Install(pkg.Dir, pkg.Source, force)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(streams.Out, "Skill %s@%s installed in %s.\n", v.ID, v.Version, v.Dir)
+
+	return v, nil
+}
+
+// activate makes version the active version of skill id and notifies the
+// golem.
+func activate(streams genericclioptions.IOStreams, ws *workspace.Workspace, store *skill.Store, id, version string) error {
+	if err := store.Activate(id, version); err != nil {
+		return err
+	}
+	fmt.Fprintf(streams.Out, "Skill %s@%s is active.\n", id, version)
+	notifyGolem(ws, streams)
+
+	return nil
+}
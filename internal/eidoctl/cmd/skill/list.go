@@ -0,0 +1,161 @@
+package skill
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	"github.com/kiosk404/eidolon/internal/pkg/skill"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/kiosk404/eidolon/pkg/cli/printers"
+	"github.com/spf13/cobra"
+)
+
+var listExample = templates.Examples(`
+		# List the installed versions of every skill
+		eidoctl skill list
+
+		# Also show where each version comes from
+		eidoctl skill list -o wide
+
+		# List the versions of one skill
+		eidoctl skill list web-search`)
+
+// VersionInfo is an installed version as listed by 'skill list'.
+type VersionInfo struct {
+	ID           string     `json:"id"                     yaml:"id"`
+	Name         string     `json:"name"                   yaml:"name"`
+	Version      string     `json:"version"                yaml:"version"`
+	Active       bool       `json:"active"                 yaml:"active"`
+	Capabilities []string   `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
+	Digest       string     `json:"digest,omitempty"       yaml:"digest,omitempty"`
+	Source       string     `json:"source,omitempty"       yaml:"source,omitempty"`
+	InstalledAt  *time.Time `json:"installed_at,omitempty" yaml:"installedAt,omitempty"`
+	Dir          string     `json:"dir"                    yaml:"dir"`
+}
+
+// VersionInfoList is what 'skill list' reports.
+type VersionInfoList struct {
+	Items []*VersionInfo `json:"items" yaml:"items"`
+}
+
+// List is an options struct to support 'skill list' sub command.
+type List struct {
+	*StoreOptions
+
+	printer printers.ResourcePrinter
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewCmdList returns new initialized instance of 'skill list' sub command.
+func NewCmdList(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &List{StoreOptions: NewStoreOptions(), Factory: f, IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:                   "list [ID...]",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"ls"},
+		Short:                 "List the installed skills",
+		Long:                  "List the installed versions of every skill, or of the given ones. The active versions are marked with a '*'.",
+		Example:               listExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete())
+			cmdutil.CheckErr(o.Run(args))
+		},
+	}
+
+	o.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// Complete completes all the required options.
+func (o *List) Complete() error {
+	var err error
+	o.printer, err = cmdutil.PrinterForCommand(printVersions)
+
+	return err
+}
+
+// Run executes a skill list sub command using the specified options.
+func (o *List) Run(args []string) error {
+	_, store, err := o.Store()
+	if err != nil {
+		return err
+	}
+
+	var versions []*skill.Version
+	if len(args) == 0 {
+		if versions, err = store.List(); err != nil {
+			return err
+		}
+	}
+	for _, id := range args {
+		vs, err := store.Versions(id)
+		if err != nil {
+			return err
+		}
+		if len(vs) == 0 {
+			return fmt.Errorf("skill %s is not installed in %s", id, store.Dir())
+		}
+		versions = append(versions, vs...)
+	}
+
+	list := &VersionInfoList{Items: make([]*VersionInfo, 0, len(versions))}
+	for _, v := range versions {
+		info := &VersionInfo{
+			ID:           v.ID,
+			Name:         v.Name,
+			Version:      v.Version,
+			Active:       v.Active,
+			Capabilities: v.Capabilities,
+			Dir:          v.Dir,
+		}
+		if v.Record != nil {
+			info.Digest = v.Record.Digest
+			info.Source = v.Record.Source
+			info.InstalledAt = &v.Record.InstalledAt
+		}
+		list.Items = append(list.Items, info)
+	}
+
+	return o.printer.PrintObj(list, o.Out)
+}
+
+func printVersions(obj any, w io.Writer, wide bool) error {
+	list, ok := obj.(*VersionInfoList)
+	if !ok {
+		return fmt.Errorf("unexpected object %T", obj)
+	}
+
+	table := printers.NewTable(
+		printers.Column{Name: "ACTIVE"},
+		printers.Column{Name: "ID"},
+		printers.Column{Name: "VERSION"},
+		printers.Column{Name: "NAME"},
+		printers.Column{Name: "CAPABILITIES"},
+		printers.Column{Name: "DIGEST", Wide: true},
+		printers.Column{Name: "SOURCE", Wide: true},
+		printers.Column{Name: "INSTALLED", Wide: true},
+	)
+	for _, v := range list.Items {
+		// A space keeps the cell blank, empty cells print as <none>.
+		active := " "
+		if v.Active {
+			active = "*"
+		}
+		installed := "<unknown>"
+		if v.InstalledAt != nil {
+			installed = v.InstalledAt.Local().Format(time.RFC3339)
+		}
+		table.AddRow(active, v.ID, v.Version, v.Name, strings.Join(v.Capabilities, ","),
+			v.Digest, v.Source, installed)
+	}
+
+	return table.Print(w, wide)
+}
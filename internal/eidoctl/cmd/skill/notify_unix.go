@@ -0,0 +1,10 @@
+//go:build unix
+
+package skill
+
+import "syscall"
+
+// signalReload asks the golem running as pid to reload its skills.
+func signalReload(pid int) error {
+	return syscall.Kill(pid, syscall.SIGHUP)
+}
@@ -0,0 +1,11 @@
+//go:build windows
+
+package skill
+
+import "errors"
+
+// signalReload asks the golem running as pid to reload its skills. Windows
+// has no SIGHUP to send.
+func signalReload(int) error {
+	return errors.New("signals are not supported on windows")
+}
@@ -0,0 +1,106 @@
+package skill
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	"github.com/kiosk404/eidolon/internal/pkg/skill"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/spf13/cobra"
+)
+
+var pushExample = templates.Examples(`
+		# Upload the package in a local directory to the skill registry of the hivemind
+		eidoctl skill push ./web-search
+
+		# Upload a tag of a git repository
+		eidoctl skill push git+https://github.com/example/web-search@v1.2.0`)
+
+// Push is an options struct to support 'skill push' sub command.
+type Push struct {
+	// Checksum is the expected digest of the archive, or of the package when
+	// it is not pushed from an archive.
+	Checksum string
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewCmdPush returns new initialized instance of 'skill push' sub command.
+func NewCmdPush(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &Push{Factory: f, IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:                   "push (PATH | ARCHIVE | URL | GIT-URL[@REF]) [flags]",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"upload"},
+		Short:                 "Upload a skill package to the skill registry of the hivemind",
+		Long: templates.LongDesc(`
+		Upload a skill package to the skill registry of the hivemind, from the same sources
+		as 'eidoctl skill install'.
+
+		Tasks submitted with 'eidoctl task run --install-skills' have the skills they require
+		installed from the registry on a node that lacks them. A version can be pushed again
+		with the same content, but not with another one.`),
+		Example: pushExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(cmd.Context(), args))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.Checksum, "checksum", o.Checksum,
+		"The expected sha256 digest of the archive, or of the package for directories and git repositories")
+
+	return cmd
+}
+
+// Validate makes sure there is no discrepancy in command options.
+func (o *Push) Validate(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return cmdutil.UsageErrorf(cmd, "exactly one package source is required")
+	}
+
+	return nil
+}
+
+// Run executes a skill push sub command using the specified options.
+func (o *Push) Run(ctx context.Context, args []string) error {
+	client, err := o.Factory.HivemindClient()
+	if err != nil {
+		return err
+	}
+
+	pkg, err := fetch(ctx, o.IOStreams, args[0])
+	if err != nil {
+		return err
+	}
+	defer pkg.Close()
+
+	if o.Checksum != "" {
+		if err := skill.VerifyDigest(pkg.Digest, o.Checksum); err != nil {
+			return fmt.Errorf("%s: %w", pkg.Source, err)
+		}
+	}
+	// Load the package first, the hivemind would refuse it anyway.
+	if _, err := skill.Load(pkg.Dir); err != nil {
+		return err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(skill.Archive(pkg.Dir, pw))
+	}()
+	uploaded, err := client.Skills().Upload(ctx, pr)
+	pr.Close()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.Out, "Skill %s@%s pushed (%s).\n", uploaded.ID, uploaded.Version, uploaded.Digest)
+
+	return nil
+}
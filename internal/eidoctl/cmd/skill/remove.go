@@ -0,0 +1,88 @@
+package skill
+
+import (
+	"fmt"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/spf13/cobra"
+)
+
+var removeExample = templates.Examples(`
+		# Remove an old version of a skill
+		eidoctl skill remove web-search@1.1.0
+
+		# Remove a skill and all its versions
+		eidoctl skill remove web-search`)
+
+// Remove is an options struct to support 'skill remove' sub command.
+type Remove struct {
+	*StoreOptions
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewCmdRemove returns new initialized instance of 'skill remove' sub command.
+func NewCmdRemove(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &Remove{StoreOptions: NewStoreOptions(), Factory: f, IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:                   "remove ID[@VERSION]...",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"rm", "uninstall"},
+		Short:                 "Remove installed skills or versions",
+		Long: templates.LongDesc(`
+		Remove a version of a skill, or the skill and all its versions when no version is
+		given. The active version cannot be removed on its own, activate another version
+		first.`),
+		Example: removeExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+	}
+
+	o.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// Validate makes sure there is no discrepancy in command options.
+func (o *Remove) Validate(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return cmdutil.UsageErrorf(cmd, "at least one skill is required")
+	}
+
+	return nil
+}
+
+// Run executes a skill remove sub command using the specified options.
+func (o *Remove) Run(args []string) error {
+	ws, store, err := o.Store()
+	if err != nil {
+		return err
+	}
+
+	// The golem only needs to reload when a whole skill goes away, the other
+	// versions are not loaded.
+	notify := false
+	for _, arg := range args {
+		id, version := splitRef(arg)
+		if err := store.Remove(id, version); err != nil {
+			return err
+		}
+		if version == "" {
+			notify = true
+			fmt.Fprintf(o.Out, "Skill %s removed.\n", id)
+		} else {
+			fmt.Fprintf(o.Out, "Skill %s@%s removed.\n", id, version)
+		}
+	}
+	if notify {
+		notifyGolem(ws, o.IOStreams)
+	}
+
+	return nil
+}
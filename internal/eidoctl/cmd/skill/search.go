@@ -0,0 +1,109 @@
+package skill
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/kiosk404/eidolon/pkg/cli/printers"
+	"github.com/spf13/cobra"
+)
+
+var searchExample = templates.Examples(`
+		# List the skills of the registry of the hivemind
+		eidoctl skill search
+
+		# List the versions of a skill with their digests
+		eidoctl skill search web-search -o wide`)
+
+// Search is an options struct to support 'skill search' sub command.
+type Search struct {
+	printer printers.ResourcePrinter
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewCmdSearch returns new initialized instance of 'skill search' sub command.
+func NewCmdSearch(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &Search{Factory: f, IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:                   "search [ID]",
+		DisableFlagsInUseLine: true,
+		Short:                 "List the skills of the skill registry of the hivemind",
+		Long: templates.LongDesc(`
+		List every version of every skill in the skill registry of the hivemind, or the
+		versions of a single skill, newest first.`),
+		Example: searchExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(cmd, args))
+			cmdutil.CheckErr(o.Run(cmd.Context(), args))
+		},
+	}
+
+	return cmd
+}
+
+// Complete completes all the required options.
+func (o *Search) Complete(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return cmdutil.UsageErrorf(cmd, "at most one skill ID is allowed")
+	}
+
+	var err error
+	o.printer, err = cmdutil.PrinterForCommand(printSkillPackages)
+
+	return err
+}
+
+// Run executes a skill search sub command using the specified options.
+func (o *Search) Run(ctx context.Context, args []string) error {
+	client, err := o.Factory.HivemindClient()
+	if err != nil {
+		return err
+	}
+
+	opts := v1.ListOptions{Limit: v1.MaxListLimit}
+	var list *v1.SkillPackageList
+	if len(args) == 1 {
+		list, err = client.Skills().Versions(ctx, args[0], opts)
+	} else {
+		list, err = client.Skills().List(ctx, opts)
+	}
+	if err != nil {
+		return err
+	}
+
+	return o.printer.PrintObj(list, o.Out)
+}
+
+func printSkillPackages(obj any, w io.Writer, wide bool) error {
+	list, ok := obj.(*v1.SkillPackageList)
+	if !ok {
+		return fmt.Errorf("unexpected object %T", obj)
+	}
+
+	table := printers.NewTable(
+		printers.Column{Name: "ID"},
+		printers.Column{Name: "VERSION"},
+		printers.Column{Name: "NAME"},
+		printers.Column{Name: "CAPABILITIES"},
+		printers.Column{Name: "SIZE"},
+		printers.Column{Name: "UPLOADED"},
+		printers.Column{Name: "DIGEST", Wide: true},
+		printers.Column{Name: "DESCRIPTION", Wide: true},
+	)
+	for _, pkg := range list.Items {
+		table.AddRow(pkg.ID, pkg.Version, pkg.Name, strings.Join(pkg.Capabilities, ","), fmt.Sprint(pkg.Size),
+			pkg.UploadedAt.Local().Format(time.RFC3339), pkg.Digest, pkg.Description)
+	}
+
+	return table.Print(w, wide)
+}
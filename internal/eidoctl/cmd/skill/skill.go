@@ -0,0 +1,114 @@
+package skill
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	"github.com/kiosk404/eidolon/internal/pkg/skill"
+	"github.com/kiosk404/eidolon/internal/pkg/workspace"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var skillLong = templates.LongDesc(`
+		Manage the skills installed on this node.
+
+		Every version of a skill is unpacked in its own directory of the skills directory,
+		next to the others, and a 'current' link points to the active one. Installing,
+		upgrading or activating a version switches the link at once, and the running golem
+		is told to reload its skills so that the hivemind learns about them with its next
+		heartbeat.
+
+		Packages pushed to the skill registry of the hivemind can also be installed by the
+		hivemind itself, on the nodes it picks for tasks that require them.`)
+
+// NewCmdSkill returns new initialized instance of 'skill' sub command.
+func NewCmdSkill(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "skill SUBCOMMAND",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"skills"},
+		Short:                 "Manage the skills installed on this node",
+		Long:                  skillLong,
+		Run:                   cmdutil.DefaultSubCommandRun(ioStreams.ErrOut),
+	}
+
+	cmd.AddCommand(NewCmdInstall(f, ioStreams))
+	cmd.AddCommand(NewCmdUpgrade(f, ioStreams))
+	cmd.AddCommand(NewCmdList(f, ioStreams))
+	cmd.AddCommand(NewCmdActivate(f, ioStreams))
+	cmd.AddCommand(NewCmdRemove(f, ioStreams))
+	cmd.AddCommand(NewCmdPush(f, ioStreams))
+	cmd.AddCommand(NewCmdSearch(f, ioStreams))
+
+	return cmd
+}
+
+// StoreOptions locates the skills directory of the node.
+type StoreOptions struct {
+	Workspace string
+
+	// SkillsDir overrides the skills directory of the golem configuration.
+	SkillsDir string
+}
+
+// NewStoreOptions returns an initialized StoreOptions instance.
+func NewStoreOptions() *StoreOptions {
+	return &StoreOptions{Workspace: workspace.DefaultDir()}
+}
+
+// AddFlags adds the flags locating the skills directory to fs.
+func (o *StoreOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Workspace, "workspace", o.Workspace, "The workspace directory of the node")
+	fs.StringVar(&o.SkillsDir, "skills-dir", o.SkillsDir,
+		"The skills directory, defaults to the one of the golem configuration in the workspace")
+}
+
+// Store returns the workspace and the store of the skills directory.
+func (o *StoreOptions) Store() (*workspace.Workspace, *skill.Store, error) {
+	ws := workspace.New(o.Workspace)
+	if o.SkillsDir != "" {
+		return ws, skill.NewStore(o.SkillsDir), nil
+	}
+
+	cfg, err := ws.LoadConfig()
+	if errors.Is(err, fs.ErrNotExist) {
+		cfg = ws.DefaultConfig()
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	return ws, skill.NewStore(cfg.SkillsDir), nil
+}
+
+// notifyGolem tells the running golem of ws to reload its skills.
+func notifyGolem(ws *workspace.Workspace, streams genericclioptions.IOStreams) {
+	pid, err := ws.GolemPID()
+	if err != nil {
+		fmt.Fprintf(streams.ErrOut, "warning: %s\n", err.Error())
+		return
+	}
+	if pid == 0 {
+		fmt.Fprintf(streams.Out, "No golem is running, the skills are loaded when it starts.\n")
+		return
+	}
+
+	if err := signalReload(pid); err != nil {
+		fmt.Fprintf(streams.ErrOut, "warning: failed to notify the golem (pid %d): %s, restart it to load the skills\n",
+			pid, err.Error())
+		return
+	}
+	fmt.Fprintf(streams.Out, "Golem (pid %d) notified to reload its skills.\n", pid)
+}
+
+// splitRef splits ID[@VERSION].
+func splitRef(ref string) (id, version string) {
+	id, version, _ = strings.Cut(ref, "@")
+
+	return id, version
+}
@@ -0,0 +1,215 @@
+package skill
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kiosk404/eidolon/internal/pkg/skill"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+)
+
+// downloadTimeout bounds the download of a package archive.
+const downloadTimeout = 5 * time.Minute
+
+// fetched is a package ready to be installed.
+type fetched struct {
+	// Dir is the root of the package.
+	Dir string
+
+	// Source is where the package comes from, local paths are made absolute.
+	Source string
+
+	// Digest is the digest the --checksum flag is compared with: the digest
+	// of the archive for archives, of the package otherwise.
+	Digest string
+
+	tmp string
+}
+
+// Close removes the files fetched.
+func (f *fetched) Close() error {
+	if f.tmp == "" {
+		return nil
+	}
+
+	return os.RemoveAll(f.tmp)
+}
+
+// fetch gets the package at source, which is one of:
+//
+//	a local package directory
+//	a local or http(s) tar archive, gzip compressed or not
+//	a git repository with an optional reference: git+https://host/repo@v1.2.0 or host:repo.git@main
+func fetch(ctx context.Context, streams genericclioptions.IOStreams, source string) (*fetched, error) {
+	fmt.Fprintf(streams.Out, "Fetching %s...\n", source)
+	var (
+		f   *fetched
+		err error
+	)
+	if url, ref, ok := gitSource(source); ok {
+		f, err = fetchGit(ctx, url, ref)
+	} else if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		f, err = fetchURL(ctx, source)
+	} else {
+		if source, err = filepath.Abs(source); err != nil {
+			return nil, err
+		}
+		f, err = fetchLocal(source)
+	}
+	if err != nil {
+		return nil, err
+	}
+	f.Source = source
+
+	return f, nil
+}
+
+func fetchLocal(path string) (*fetched, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return fetchArchive(path)
+	}
+
+	root, err := skill.FindRoot(path)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := skill.Digest(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fetched{Dir: root, Digest: digest}, nil
+}
+
+// gitSource splits a git source into the URL of the repository and the
+// reference to check out.
+func gitSource(source string) (url, ref string, ok bool) {
+	url, explicit := strings.CutPrefix(source, "git+")
+
+	// An '@' after the path is a reference, not the user of URLs like
+	// git@host:repo.git.
+	rest := url
+	if _, after, ok := strings.Cut(url, "://"); ok {
+		rest = after
+	}
+	if i := strings.LastIndex(rest, "@"); i >= 0 && strings.ContainsAny(rest[:i], "/:") {
+		url, ref = url[:len(url)-len(rest)+i], rest[i+1:]
+	}
+	if !explicit && !strings.HasSuffix(url, ".git") {
+		return "", "", false
+	}
+
+	return url, ref, true
+}
+
+func fetchGit(ctx context.Context, url, ref string) (*fetched, error) {
+	tmp, err := os.MkdirTemp("", "eidoctl-skill-")
+	if err != nil {
+		return nil, err
+	}
+	f := &fetched{tmp: tmp}
+
+	args := []string{"clone", "--quiet", "--depth", "1"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	repo := filepath.Join(tmp, "repo")
+	cmd := exec.CommandContext(ctx, "git", append(args, "--", url, repo)...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("git clone %s failed: %w: %s", url, err, strings.TrimSpace(string(out)))
+	}
+
+	if f.Dir, err = skill.FindRoot(repo); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	if f.Digest, err = skill.Digest(f.Dir); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func fetchURL(ctx context.Context, url string) (*fetched, error) {
+	tmp, err := os.MkdirTemp("", "eidoctl-skill-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	ctx, cancel := context.WithTimeout(ctx, downloadTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download %s failed: %s", url, resp.Status)
+	}
+
+	archive := filepath.Join(tmp, "package.tar")
+	out, err := os.Create(archive)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		out.Close()
+		return nil, fmt.Errorf("download %s failed: %w", url, err)
+	}
+	if err := out.Close(); err != nil {
+		return nil, err
+	}
+
+	return fetchArchive(archive)
+}
+
+// fetchArchive unpacks the archive at path.
+func fetchArchive(path string) (*fetched, error) {
+	digest, err := skill.FileDigest(path)
+	if err != nil {
+		return nil, err
+	}
+
+	tmp, err := os.MkdirTemp("", "eidoctl-skill-")
+	if err != nil {
+		return nil, err
+	}
+	f := &fetched{Digest: digest, tmp: tmp}
+
+	in, err := os.Open(path)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	defer in.Close()
+
+	dir := filepath.Join(tmp, "package")
+	if err := skill.Extract(in, dir); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if f.Dir, err = skill.FindRoot(dir); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return f, nil
+}
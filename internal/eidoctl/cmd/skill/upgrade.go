@@ -0,0 +1,146 @@
+package skill
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	"github.com/kiosk404/eidolon/internal/pkg/skill"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	versionutil "github.com/kiosk404/eidolon/pkg/version/util"
+	"github.com/spf13/cobra"
+)
+
+var upgradeExample = templates.Examples(`
+		# Upgrade a skill to the version in a package archive
+		eidoctl skill upgrade ./web-search-1.3.0.tar.gz --checksum=sha256:9f86d0...
+
+		# Go back to an older version from a git tag
+		eidoctl skill upgrade git+https://github.com/example/web-search@v1.1.0 --allow-downgrade`)
+
+// Upgrade is an options struct to support 'skill upgrade' sub command.
+type Upgrade struct {
+	*StoreOptions
+
+	Checksum string
+
+	Force bool
+
+	// AllowDowngrade accepts a package older than the active version.
+	AllowDowngrade bool
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewUpgradeOptions returns an initialized Upgrade instance.
+func NewUpgradeOptions(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *Upgrade {
+	return &Upgrade{
+		StoreOptions: NewStoreOptions(),
+		Factory:      f,
+		IOStreams:    ioStreams,
+	}
+}
+
+// NewCmdUpgrade returns new initialized instance of 'skill upgrade' sub command.
+func NewCmdUpgrade(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewUpgradeOptions(f, ioStreams)
+
+	cmd := &cobra.Command{
+		Use:                   "upgrade (PATH | ARCHIVE | URL | GIT-URL[@REF]) [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Install a new version of an installed skill and activate it",
+		Long: templates.LongDesc(`
+		Install a new version of a skill that is already installed and make it the active
+		version. The package is fetched like with 'eidoctl skill install', and must be newer
+		than the active version unless --allow-downgrade is given. The previous version is
+		kept, so 'eidoctl skill activate' can roll back to it.`),
+		Example: upgradeExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(cmd, args))
+			cmdutil.CheckErr(o.Run(cmd.Context(), args))
+		},
+	}
+
+	o.AddFlags(cmd.Flags())
+	cmd.Flags().StringVar(&o.Checksum, "checksum", o.Checksum,
+		"The expected sha256 digest of the archive, or of the package for directories and git repositories")
+	cmd.Flags().BoolVar(&o.Force, "force", o.Force, "Replace the version if it is already installed")
+	cmd.Flags().BoolVar(&o.AllowDowngrade, "allow-downgrade", o.AllowDowngrade,
+		"Accept a version older than the active one")
+
+	return cmd
+}
+
+// Validate makes sure there is no discrepancy in command options.
+func (o *Upgrade) Validate(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return cmdutil.UsageErrorf(cmd, "exactly one package source is required")
+	}
+
+	return nil
+}
+
+// Run executes a skill upgrade sub command using the specified options.
+func (o *Upgrade) Run(ctx context.Context, args []string) error {
+	ws, store, err := o.Store()
+	if err != nil {
+		return err
+	}
+
+	pkg, err := fetch(ctx, o.IOStreams, args[0])
+	if err != nil {
+		return err
+	}
+	defer pkg.Close()
+
+	m, err := skill.LoadManifest(filepath.Join(pkg.Dir, skill.ManifestFile))
+	if err != nil {
+		return err
+	}
+
+	active, err := store.Active(m.ID)
+	if err != nil {
+		return err
+	}
+	if active == "" {
+		return fmt.Errorf("skill %s is not installed, use 'eidoctl skill install'", m.ID)
+	}
+	if err := o.checkVersion(m, active); err != nil {
+		return err
+	}
+
+	v, err := install(o.IOStreams, store, pkg, o.Checksum, o.Force)
+	if err != nil {
+		return err
+	}
+
+	return activate(o.IOStreams, ws, store, v.ID, v.Version)
+}
+
+// checkVersion makes sure the package is newer than the active version.
+func (o *Upgrade) checkVersion(m *skill.Manifest, active string) error {
+	if o.AllowDowngrade {
+		return nil
+	}
+
+	cur, err := versionutil.ParseSemantic(active)
+	if err != nil {
+		return fmt.Errorf("active version of skill %s: %w", m.ID, err)
+	}
+	cmp, err := cur.Compare(m.Version)
+	if err != nil {
+		return err
+	}
+	switch {
+	case cmp == 0:
+		return fmt.Errorf("skill %s@%s is already active", m.ID, m.Version)
+	case cmp > 0:
+		return fmt.Errorf("skill %s@%s is older than the active version %s, pass --allow-downgrade to install it",
+			m.ID, m.Version, active)
+	}
+
+	return nil
+}
@@ -0,0 +1,312 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/internal/pkg/client/hivemind"
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+	"github.com/kiosk404/eidolon/internal/pkg/workspace"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/kiosk404/eidolon/pkg/cli/printers"
+	"github.com/spf13/cobra"
+)
+
+var statusExample = templates.Examples(`
+		# Show the state of this node
+		eidoctl status
+
+		# Show the state of this node as JSON
+		eidoctl status -o json
+
+		# Print whether this node is connected
+		eidoctl status -o jsonpath='{.connection}'`)
+
+// Connection states of a node.
+const (
+	ConnectionNotJoined     = "not joined"
+	ConnectionUnreachable   = "unreachable"
+	ConnectionNotRegistered = "not registered"
+	ConnectionConnected     = "connected"
+	ConnectionDisconnected  = "disconnected"
+)
+
+// dialTimeout bounds the reachability check of the hivemind.
+const dialTimeout = 3 * time.Second
+
+// NodeStatus is what 'eidoctl status' reports.
+type NodeStatus struct {
+	Workspace   string `json:"workspace"             yaml:"workspace"`
+	Initialized bool   `json:"initialized"           yaml:"initialized"`
+	NodeID      string `json:"node_id,omitempty"     yaml:"nodeID,omitempty"`
+	NodeName    string `json:"node_name,omitempty"   yaml:"nodeName,omitempty"`
+
+	GolemRunning bool `json:"golem_running"         yaml:"golemRunning"`
+	GolemPID     int  `json:"golem_pid,omitempty"   yaml:"golemPID,omitempty"`
+
+	HivemindAddr string `json:"hivemind_addr,omitempty" yaml:"hivemindAddr,omitempty"`
+	Connection   string `json:"connection"              yaml:"connection"`
+	Error        string `json:"error,omitempty"         yaml:"error,omitempty"`
+
+	// Node is the registration of the node in the hivemind.
+	Node *v1.Node `json:"node,omitempty" yaml:"node,omitempty"`
+
+	// Tasks are the tasks assigned to or running on the node.
+	Tasks []*v1.Task `json:"tasks,omitempty" yaml:"tasks,omitempty"`
+}
+
+// Status is an options struct to support 'status' sub command.
+type Status struct {
+	Workspace string
+
+	printer printers.ResourcePrinter
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewStatusOptions returns an initialized Status instance.
+func NewStatusOptions(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *Status {
+	return &Status{
+		Workspace: workspace.DefaultDir(),
+		Factory:   f,
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdStatus returns new initialized instance of 'status' sub command.
+func NewCmdStatus(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewStatusOptions(f, ioStreams)
+
+	cmd := &cobra.Command{
+		Use:                   "status",
+		DisableFlagsInUseLine: true,
+		Short:                 "Show the health and connection state of this node",
+		Long: templates.LongDesc(`
+		Show the identity of this node, whether the golem is running and what the hivemind
+		knows about the node: its registration, health score, last heartbeat, tags, installed
+		skills and the tasks assigned to it.`),
+		Example: statusExample,
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete())
+			cmdutil.CheckErr(o.Run(cmd.Context(), args))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.Workspace, "workspace", o.Workspace, "The workspace directory of the node")
+
+	return cmd
+}
+
+// Complete completes all the required options.
+func (o *Status) Complete() error {
+	var err error
+	o.printer, err = cmdutil.PrinterForCommand(printStatus)
+
+	return err
+}
+
+// Run executes a status sub command using the specified options.
+func (o *Status) Run(ctx context.Context, args []string) error {
+	st, err := o.collect(ctx)
+	if err != nil {
+		return err
+	}
+
+	return o.printer.PrintObj(st, o.Out)
+}
+
+// collect gathers the local state and, for a joined node, asks the hivemind about it.
+// Failing to reach the hivemind is part of the status, not an error.
+func (o *Status) collect(ctx context.Context) (*NodeStatus, error) {
+	ws := workspace.New(o.Workspace)
+	st := &NodeStatus{Workspace: ws.Dir, Connection: ConnectionNotJoined}
+
+	if id, err := ws.LoadIdentity(); err == nil {
+		st.Initialized = true
+		st.NodeID = id.NodeID
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	pid, err := ws.GolemPID()
+	if err != nil {
+		return nil, err
+	}
+	st.GolemPID, st.GolemRunning = pid, pid > 0
+
+	m, err := ws.LoadMembership()
+	if errors.Is(err, fs.ErrNotExist) {
+		return st, nil
+	} else if err != nil {
+		return nil, err
+	}
+	st.NodeID, st.NodeName, st.HivemindAddr = m.NodeID, m.NodeName, m.HivemindAddr
+
+	dialer := net.Dialer{Timeout: dialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", m.HivemindAddr)
+	if err != nil {
+		st.Connection, st.Error = ConnectionUnreachable, err.Error()
+		return st, nil
+	}
+	conn.Close()
+
+	client, err := o.Factory.HivemindClient()
+	if err != nil {
+		return nil, err
+	}
+	node, err := client.Nodes().Get(ctx, m.NodeID)
+	switch {
+	case hivemind.IsNotFound(err):
+		st.Connection = ConnectionNotRegistered
+		return st, nil
+	case err != nil:
+		st.Connection, st.Error = ConnectionUnreachable, err.Error()
+		return st, nil
+	}
+	st.Node = node
+	st.Connection = ConnectionDisconnected
+	if node.Status == protocol.NodeStatusOnline {
+		st.Connection = ConnectionConnected
+	}
+
+	tasks, err := client.Tasks().List(ctx, hivemind.TaskListOptions{
+		ListOptions: v1.ListOptions{Limit: v1.MaxListLimit},
+		NodeID:      m.NodeID,
+	})
+	if err != nil {
+		st.Error = fmt.Sprintf("list tasks failed: %s", err.Error())
+		return st, nil
+	}
+	for _, t := range tasks.Items {
+		if !t.Status.IsTerminal() {
+			st.Tasks = append(st.Tasks, t)
+		}
+	}
+
+	return st, nil
+}
+
+func printStatus(obj any, out io.Writer, wide bool) error {
+	st, ok := obj.(*NodeStatus)
+	if !ok {
+		return fmt.Errorf("unexpected object %T", obj)
+	}
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+
+	nodeID := st.NodeID
+	switch {
+	case nodeID == "":
+		nodeID = "<not initialized>"
+	case st.NodeName != "":
+		nodeID += " (" + st.NodeName + ")"
+	}
+	fmt.Fprintf(w, "Node:\t%s\n", nodeID)
+	fmt.Fprintf(w, "Workspace:\t%s\n", st.Workspace)
+	if st.GolemRunning {
+		fmt.Fprintf(w, "Golem:\trunning (pid %d)\n", st.GolemPID)
+	} else {
+		fmt.Fprintf(w, "Golem:\tnot running\n")
+	}
+
+	hivemindAddr := st.HivemindAddr
+	if hivemindAddr == "" {
+		hivemindAddr = "<none>"
+	}
+	fmt.Fprintf(w, "Hivemind:\t%s\n", hivemindAddr)
+	connection := st.Connection
+	if st.Error != "" {
+		connection += ": " + st.Error
+	}
+	fmt.Fprintf(w, "Connection:\t%s\n", connection)
+
+	if n := st.Node; n != nil {
+		state := []string{string(n.Status)}
+		if n.Cordoned {
+			state = append(state, "cordoned")
+		}
+		if n.Draining {
+			state = append(state, "draining")
+		}
+		fmt.Fprintf(w, "State:\t%s\n", strings.Join(state, ", "))
+		fmt.Fprintf(w, "Health:\t%.2f\n", n.HealthScore)
+		fmt.Fprintf(w, "Last heartbeat:\t%s\n", since(n.LastHeartbeat))
+		fmt.Fprintf(w, "Load:\tcpu %.1f%%, memory %.1f%%, %d active, %d queued\n",
+			n.Load.CPUPercent, n.Load.MemoryPercent, n.Load.ActiveTasks, n.Load.QueuedTasks)
+		fmt.Fprintf(w, "Tags:\t%s\n", formatTags(n.Tags))
+		if wide {
+			sys := n.SystemInfo
+			fmt.Fprintf(w, "Address:\t%s\n", n.Address)
+			fmt.Fprintf(w, "Version:\t%s\n", n.Version)
+			fmt.Fprintf(w, "System:\t%s/%s, %d cores, %d MB memory, %d MB disk free\n",
+				sys.OS, sys.Arch, sys.CPUCores, sys.MemoryMB, sys.DiskFreeMB)
+			fmt.Fprintf(w, "Registered:\t%s\n", since(n.RegisteredAt))
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if st.Node == nil {
+		return nil
+	}
+
+	fmt.Fprintf(out, "\nInstalled skills:\n")
+	if len(st.Node.InstalledSkills) == 0 {
+		fmt.Fprintf(out, "  <none>\n")
+	} else {
+		fmt.Fprintf(w, "  NAME\tVERSION\tCAPABILITIES\n")
+		for _, sk := range st.Node.InstalledSkills {
+			fmt.Fprintf(w, "  %s\t%s\t%s\n", sk.Name, sk.Version, strings.Join(sk.Capabilities, ","))
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(out, "\nTasks:\n")
+	if len(st.Tasks) == 0 {
+		fmt.Fprintf(out, "  <none>\n")
+		return nil
+	}
+	fmt.Fprintf(w, "  ID\tNAME\tSTATUS\tPRIORITY\n")
+	for _, t := range st.Tasks {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%d\n", t.ID, t.Name, t.Status, t.Priority)
+	}
+
+	return w.Flush()
+}
+
+func formatTags(tags map[string]string) string {
+	if len(tags) == 0 {
+		return "<none>"
+	}
+
+	pairs := make([]string, 0, len(tags))
+	for k, v := range tags {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
+
+func since(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+
+	return fmt.Sprintf("%s (%s ago)", t.Format(time.RFC3339), time.Since(t).Truncate(time.Second))
+}
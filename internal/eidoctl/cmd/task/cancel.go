@@ -0,0 +1,63 @@
+package task
+
+import (
+	"context"
+	"fmt"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/spf13/cobra"
+)
+
+var cancelExample = templates.Examples(`
+		# Cancel a task
+		eidoctl task cancel task-6f1c2a
+
+		# Cancel several tasks
+		eidoctl task cancel task-6f1c2a task-9b03e7`)
+
+// Cancel is an options struct to support 'task cancel' sub command.
+type Cancel struct {
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewCmdCancel returns new initialized instance of 'task cancel' sub command.
+func NewCmdCancel(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &Cancel{Factory: f, IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:                   "cancel TASK_ID [TASK_ID...]",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"delete"},
+		Short:                 "Cancel tasks",
+		Long:                  "Cancel tasks that are queued or running. Finished tasks cannot be cancelled.",
+		Example:               cancelExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 0 {
+				cmdutil.CheckErr(cmdutil.UsageErrorf(cmd, "at least one task ID is required"))
+			}
+			cmdutil.CheckErr(o.Run(cmd.Context(), args))
+		},
+	}
+
+	return cmd
+}
+
+// Run executes a task cancel sub command using the specified options.
+func (o *Cancel) Run(ctx context.Context, args []string) error {
+	client, err := o.Factory.HivemindClient()
+	if err != nil {
+		return err
+	}
+
+	for _, id := range args {
+		if _, err := client.Tasks().Cancel(ctx, id); err != nil {
+			return err
+		}
+		fmt.Fprintf(o.Out, "task/%s cancelled\n", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/kiosk404/eidolon/pkg/cli/printers"
+	"github.com/spf13/cobra"
+)
+
+var getExample = templates.Examples(`
+		# Show a task and why it was placed on its node
+		eidoctl task get task-6f1c2a
+
+		# Also show the payload and the timestamps of the task
+		eidoctl task get task-6f1c2a -o wide
+
+		# Print the score of every candidate node
+		eidoctl task get task-6f1c2a -o jsonpath='{range .decision.scores[*]}{.node_id}{"\t"}{.total_score}{"\n"}{end}'`)
+
+// Get is an options struct to support 'task get' sub command.
+type Get struct {
+	printer printers.ResourcePrinter
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewCmdGet returns new initialized instance of 'task get' sub command.
+func NewCmdGet(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &Get{Factory: f, IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:                   "get TASK_ID",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"describe"},
+		Short:                 "Show a task and its scheduling decision",
+		Long: templates.LongDesc(`
+		Show a task and the latest scheduling decision made for it: the node that was
+		selected and why, and the score breakdown of every candidate node together with
+		the reason the rejected ones were rejected.`),
+		Example: getExample,
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete())
+			cmdutil.CheckErr(o.Run(cmd.Context(), args))
+		},
+	}
+
+	return cmd
+}
+
+// Complete completes all the required options.
+func (o *Get) Complete() error {
+	var err error
+	o.printer, err = cmdutil.PrinterForCommand(printTask)
+
+	return err
+}
+
+// Run executes a task get sub command using the specified options.
+func (o *Get) Run(ctx context.Context, args []string) error {
+	client, err := o.Factory.HivemindClient()
+	if err != nil {
+		return err
+	}
+
+	task, err := client.Tasks().Get(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	return o.printer.PrintObj(task, o.Out)
+}
+
+func printTask(obj any, out io.Writer, wide bool) error {
+	t, ok := obj.(*v1.Task)
+	if !ok {
+		return fmt.Errorf("unexpected object %T", obj)
+	}
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+
+	fmt.Fprintf(w, "ID:\t%s\n", t.ID)
+	fmt.Fprintf(w, "Name:\t%s\n", t.Name)
+	fmt.Fprintf(w, "Type:\t%s\n", t.Type)
+	fmt.Fprintf(w, "Status:\t%s\n", t.Status)
+	fmt.Fprintf(w, "Priority:\t%d\n", t.Priority)
+	fmt.Fprintf(w, "Mode:\t%s\n", t.Mode)
+	fmt.Fprintf(w, "Node:\t%s\n", orNone(t.AssignedNodeID))
+	fmt.Fprintf(w, "Retries:\t%d\n", t.Retries)
+	fmt.Fprintf(w, "Age:\t%s\n", age(t.CreatedAt))
+	if wide {
+		timeout := "<default>"
+		if t.Timeout > 0 {
+			timeout = t.Timeout.String()
+		}
+		fmt.Fprintf(w, "Timeout:\t%s\n", timeout)
+		fmt.Fprintf(w, "Created:\t%s\n", t.CreatedAt.Local().Format(time.RFC3339))
+		fmt.Fprintf(w, "Started:\t%s\n", formatTime(t.StartedAt))
+		fmt.Fprintf(w, "Completed:\t%s\n", formatTime(t.CompletedAt))
+		fmt.Fprintf(w, "Metadata:\t%s\n", formatLabels(t.Metadata))
+		payload := "<none>"
+		if len(t.Payload) > 0 {
+			data, err := json.Marshal(t.Payload)
+			if err != nil {
+				return err
+			}
+			payload = string(data)
+		}
+		fmt.Fprintf(w, "Payload:\t%s\n", payload)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	d := t.Decision
+	if d == nil {
+		_, err := fmt.Fprintf(out, "\nDecision:\n  <not scheduled yet>\n")
+		return err
+	}
+	fmt.Fprintf(out, "\nDecision:\n")
+	fmt.Fprintf(w, "  Selected:\t%s\n", orNone(d.SelectedNodeID))
+	fmt.Fprintf(w, "  Reason:\t%s\n", d.Reason)
+	fmt.Fprintf(w, "  Candidates:\t%d, %d eligible\n", d.CandidateCount, d.EligibleCount)
+	fmt.Fprintf(w, "  Decided:\t%s in %s\n", d.DecidedAt.Local().Format(time.RFC3339), d.Latency)
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if len(d.Scores) == 0 {
+		return nil
+	}
+
+	scores := append([]v1.NodeScore(nil), d.Scores...)
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].Eligible != scores[j].Eligible {
+			return scores[i].Eligible
+		}
+
+		return scores[i].TotalScore > scores[j].TotalScore
+	})
+
+	fmt.Fprintf(out, "\nScores:\n")
+	table := printers.NewTable(
+		printers.Column{Name: "  NODE"},
+		printers.Column{Name: "TOTAL"},
+		printers.Column{Name: "CAPABILITY"},
+		printers.Column{Name: "SKILL"},
+		printers.Column{Name: "RESOURCE"},
+		printers.Column{Name: "LOAD"},
+		printers.Column{Name: "TAG"},
+		printers.Column{Name: "AFFINITY"},
+		printers.Column{Name: "ELIGIBLE"},
+		printers.Column{Name: "REASON"},
+	)
+	for _, s := range scores {
+		table.AddRow("  "+s.NodeID, score(s.TotalScore), score(s.CapabilityScore), score(s.SkillScore),
+			score(s.ResourceScore), score(s.LoadScore), score(s.TagScore), score(s.AffinityScore),
+			fmt.Sprint(s.Eligible), s.RejectReason)
+	}
+
+	return table.Print(out, wide)
+}
+
+func score(f float64) string {
+	return fmt.Sprintf("%.2f", f)
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+
+	return s
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "<none>"
+	}
+
+	return t.Local().Format(time.RFC3339)
+}
+
+func formatLabels(m map[string]string) string {
+	if len(m) == 0 {
+		return "<none>"
+	}
+
+	pairs := make([]string, 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
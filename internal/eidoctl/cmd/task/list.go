@@ -0,0 +1,152 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/internal/pkg/client/hivemind"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/kiosk404/eidolon/pkg/cli/printers"
+	"github.com/spf13/cobra"
+)
+
+var listExample = templates.Examples(`
+		# List the most recent tasks
+		eidoctl task list
+
+		# List the running tasks of a node
+		eidoctl task list --status=running --node=golem-3f2a9c0d1e4b5a67
+
+		# List the IDs of the failed tasks
+		eidoctl task list --status=failed -o jsonpath='{range .items[*]}{.id}{"\n"}{end}'`)
+
+// List is an options struct to support 'task list' sub command.
+type List struct {
+	Status string
+	NodeID string
+	Mode   string
+	Limit  int
+	Offset int
+
+	printer printers.ResourcePrinter
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewCmdList returns new initialized instance of 'task list' sub command.
+func NewCmdList(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &List{Limit: 100, Factory: f, IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:                   "list",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"ls"},
+		Short:                 "List tasks",
+		Long:                  "List the tasks known to the hivemind, newest first.",
+		Example:               listExample,
+		Args:                  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete())
+			cmdutil.CheckErr(o.Validate())
+			cmdutil.CheckErr(o.Run(cmd.Context(), args))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.Status, "status", o.Status,
+		"Only list tasks in this state: pending, assigned, running, completed, failed, cancelled or timed_out")
+	cmd.Flags().StringVar(&o.NodeID, "node", o.NodeID, "Only list tasks assigned to this node")
+	cmd.Flags().StringVar(&o.Mode, "mode", o.Mode, "Only list tasks submitted in this mode: ai or direct")
+	cmd.Flags().IntVar(&o.Limit, "limit", o.Limit, "The maximum number of tasks to list")
+	cmd.Flags().IntVar(&o.Offset, "offset", o.Offset, "The number of tasks to skip")
+
+	return cmd
+}
+
+// Complete completes all the required options.
+func (o *List) Complete() error {
+	var err error
+	o.printer, err = cmdutil.PrinterForCommand(printTasks)
+
+	return err
+}
+
+// Validate makes sure there is no discrepancy in command options.
+func (o *List) Validate() error {
+	if o.Limit <= 0 || o.Limit > v1.MaxListLimit {
+		return fmt.Errorf("--limit must be between 1 and %d", v1.MaxListLimit)
+	}
+	if o.Offset < 0 {
+		return fmt.Errorf("--offset must not be negative")
+	}
+
+	return nil
+}
+
+// Run executes a task list sub command using the specified options.
+func (o *List) Run(ctx context.Context, args []string) error {
+	client, err := o.Factory.HivemindClient()
+	if err != nil {
+		return err
+	}
+
+	list, err := client.Tasks().List(ctx, hivemind.TaskListOptions{
+		ListOptions: v1.ListOptions{Offset: o.Offset, Limit: o.Limit},
+		Status:      o.Status,
+		NodeID:      o.NodeID,
+		Mode:        o.Mode,
+	})
+	if err != nil {
+		return err
+	}
+
+	return o.printer.PrintObj(list, o.Out)
+}
+
+func printTasks(obj any, w io.Writer, wide bool) error {
+	list, ok := obj.(*v1.TaskList)
+	if !ok {
+		return fmt.Errorf("unexpected object %T", obj)
+	}
+
+	table := printers.NewTable(
+		printers.Column{Name: "ID"},
+		printers.Column{Name: "NAME"},
+		printers.Column{Name: "TYPE"},
+		printers.Column{Name: "STATUS"},
+		printers.Column{Name: "PRIORITY"},
+		printers.Column{Name: "NODE"},
+		printers.Column{Name: "AGE"},
+		printers.Column{Name: "MODE", Wide: true},
+		printers.Column{Name: "RETRIES", Wide: true},
+		printers.Column{Name: "CREATED", Wide: true},
+	)
+	for _, t := range list.Items {
+		table.AddRow(t.ID, t.Name, t.Type, string(t.Status), fmt.Sprint(t.Priority), t.AssignedNodeID,
+			age(t.CreatedAt), t.Mode, fmt.Sprint(t.Retries), t.CreatedAt.Local().Format(time.RFC3339))
+	}
+
+	return table.Print(w, wide)
+}
+
+// age formats the time elapsed since t in its two most significant units, like: 3h12m.
+func age(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case t.IsZero():
+		return "<unknown>"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%ds", int(d.Minutes()), int(d.Seconds())%60)
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh%dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dd%dh", int(d.Hours())/24, int(d.Hours())%24)
+	}
+}
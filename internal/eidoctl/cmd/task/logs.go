@@ -0,0 +1,115 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/spf13/cobra"
+)
+
+var logsExample = templates.Examples(`
+		# Print the progress messages and the result of a task
+		eidoctl task logs task-6f1c2a
+
+		# Keep printing the progress of a task until it finishes
+		eidoctl task logs -f task-6f1c2a
+
+		# Prefix every line with its timestamp
+		eidoctl task logs --timestamps task-6f1c2a`)
+
+// Logs is an options struct to support 'task logs' sub command.
+type Logs struct {
+	Follow     bool
+	Timestamps bool
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewCmdLogs returns new initialized instance of 'task logs' sub command.
+func NewCmdLogs(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &Logs{Factory: f, IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:                   "logs [-f] TASK_ID",
+		DisableFlagsInUseLine: true,
+		Short:                 "Print the progress and the result of a task",
+		Long: templates.LongDesc(`
+		Print the progress messages a golem reported while running a task, followed by
+		the output of the task once it completes or its error once it fails.
+
+		The hivemind keeps a bounded number of events per task, the oldest progress
+		messages of a long running task may be gone.`),
+		Example: logsExample,
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Run(cmd.Context(), args))
+		},
+	}
+
+	cmd.Flags().BoolVarP(&o.Follow, "follow", "f", o.Follow, "Keep printing new progress until the task finishes")
+	cmd.Flags().BoolVar(&o.Timestamps, "timestamps", o.Timestamps, "Prefix every line with its timestamp")
+
+	return cmd
+}
+
+// Run executes a task logs sub command using the specified options.
+func (o *Logs) Run(ctx context.Context, args []string) error {
+	client, err := o.Factory.HivemindClient()
+	if err != nil {
+		return err
+	}
+
+	if o.Follow {
+		return client.Tasks().Watch(ctx, args[0], 0, func(e *v1.TaskEvent) error {
+			return o.printLog(o.Out, e)
+		})
+	}
+
+	list, err := client.Tasks().Events(ctx, args[0], 0)
+	if err != nil {
+		return err
+	}
+	for _, e := range list.Items {
+		if err := o.printLog(o.Out, e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// printLog prints the progress message or the outcome carried by e, if any.
+func (o *Logs) printLog(w io.Writer, e *v1.TaskEvent) error {
+	var line string
+	switch {
+	case e.Progress != nil:
+		line = fmt.Sprintf("[%3.0f%%] %s", e.Progress.Percent, e.Progress.Message)
+	case e.Result != nil && e.Result.Success:
+		output, err := json.MarshalIndent(e.Result.Output, "", "  ")
+		if err != nil {
+			return err
+		}
+		line = string(output)
+	case e.Error != "":
+		line = fmt.Sprintf("%s: %s", e.Type, e.Error)
+	case e.Type == v1.TaskEventCancelled || e.Type == v1.TaskEventTimedOut:
+		line = e.Type
+	default:
+		return nil
+	}
+
+	if o.Timestamps {
+		line = e.Timestamp.Local().Format(time.RFC3339Nano) + " " + line
+	}
+	_, err := fmt.Fprintln(w, line)
+
+	return err
+}
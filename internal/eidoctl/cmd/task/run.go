@@ -0,0 +1,293 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/kiosk404/eidolon/pkg/cli/printers"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+var runExample = templates.Examples(`
+		# Run a shell task on the node the scheduler picks
+		eidoctl task run --type=shell --payload='{"command":"uname -a"}'
+
+		# Run a task on a given node
+		eidoctl task run --type=shell --node=golem-3f2a9c0d1e4b5a67 --payload='{"command":"uptime"}'
+
+		# Require a skill and 4 CPU cores, prefer nodes in the lab region
+		eidoctl task run --type=crawl --require-skill=browser --min-cpu=4 --prefer-tag=region=lab
+
+		# Require a version range of a skill
+		eidoctl task run --type=search --require-skill='web-search>=1.2,<2'
+
+		# Have the skill installed from the skill registry if no node has it
+		eidoctl task run --type=search --require-skill=web-search --install-skills
+
+		# Submit the task described in a manifest and follow it until it finishes
+		eidoctl task run -f task.yaml --watch
+
+		# Override the priority of a manifest read from stdin
+		cat task.json | eidoctl task run -f - --priority=3`)
+
+// Run is an options struct to support 'task run' sub command.
+type Run struct {
+	Filename string
+
+	Name     string
+	Type     string
+	Payload  string
+	Priority int
+	Timeout  time.Duration
+	Metadata map[string]string
+
+	Node                 string
+	RequiredSkills       []string
+	InstallSkills        bool
+	RequiredFeatures     []string
+	RequiredCapabilities []string
+	MinCPUCores          int
+	MinMemoryMB          int64
+	PreferredTags        map[string]string
+
+	Watch bool
+
+	request *v1.SubmitTaskRequest
+	printer printers.ResourcePrinter
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewRunOptions returns an initialized Run instance.
+func NewRunOptions(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *Run {
+	return &Run{
+		Priority:  int(protocol.TaskPriorityNormal),
+		Factory:   f,
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdRun returns new initialized instance of 'task run' sub command.
+func NewCmdRun(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewRunOptions(f, ioStreams)
+
+	cmd := &cobra.Command{
+		Use:                   "run (--type=TYPE | -f FILENAME) [flags]",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"submit"},
+		Short:                 "Submit a task to the hivemind",
+		Long: templates.LongDesc(`
+		Submit a task to the hivemind.
+
+		The task is described by flags, by a YAML or JSON manifest holding a task
+		request, or both, in which case the flags override the manifest.
+
+		With --node the task is placed on that node, provided the node meets the
+		requirements. Otherwise the scheduler picks the node scoring best for the required
+		skills, features, capabilities and resources, favouring the preferred tags.
+
+		With --install-skills a node lacking the required skills may still be picked: the
+		hivemind has it install them from its skill registry, see 'eidoctl skill push', and
+		dispatches the task once the node reports them.`),
+		Example: runExample,
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(cmd))
+			cmdutil.CheckErr(o.Validate())
+			cmdutil.CheckErr(o.Run(cmd.Context(), args))
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.Filename, "filename", "f", o.Filename, "A YAML or JSON manifest of the task, '-' reads it from stdin")
+	cmd.Flags().StringVar(&o.Name, "name", o.Name, "A short name of the task, defaults to its ID")
+	cmd.Flags().StringVar(&o.Type, "type", o.Type, "The executor that runs the task on the node")
+	cmd.Flags().StringVar(&o.Payload, "payload", o.Payload, "The input of the executor as a JSON object")
+	cmd.Flags().IntVar(&o.Priority, "priority", o.Priority, "The priority of the task, from 0 (low) to 3 (critical)")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", o.Timeout, "The time the task may run, 0 uses the scheduler default")
+	cmd.Flags().StringToStringVar(&o.Metadata, "metadata", o.Metadata, "Labels attached to the task, like: team=search")
+	cmd.Flags().StringVar(&o.Node, "node", o.Node, "Run the task on this node instead of letting the scheduler pick one")
+	cmd.Flags().StringArrayVar(&o.RequiredSkills, "require-skill", o.RequiredSkills,
+		"A skill the node must have installed, with optional version constraints like: web-search>=1.2,<2. Repeat for several skills")
+	cmd.Flags().BoolVar(&o.InstallSkills, "install-skills", o.InstallSkills,
+		"Install the required skills from the skill registry of the hivemind on a node that lacks them")
+	cmd.Flags().StringSliceVar(&o.RequiredFeatures, "require-feature", o.RequiredFeatures, "Features the node must support")
+	cmd.Flags().StringSliceVar(&o.RequiredCapabilities, "require-capability", o.RequiredCapabilities,
+		"Capabilities the skills of the node must provide")
+	cmd.Flags().IntVar(&o.MinCPUCores, "min-cpu", o.MinCPUCores, "The CPU cores the node must have")
+	cmd.Flags().Int64Var(&o.MinMemoryMB, "min-memory", o.MinMemoryMB, "The memory in MB the node must have")
+	cmd.Flags().StringToStringVar(&o.PreferredTags, "prefer-tag", o.PreferredTags, "Tags of the nodes to favour, like: region=lab")
+	cmd.Flags().BoolVarP(&o.Watch, "watch", "w", o.Watch, "Follow the events of the task until it finishes")
+
+	return cmd
+}
+
+// Complete builds the task request from the manifest and the flags set on the command line.
+func (o *Run) Complete(cmd *cobra.Command) error {
+	o.request = &v1.SubmitTaskRequest{Priority: protocol.TaskPriority(o.Priority)}
+	if o.Filename != "" {
+		req, err := readManifest(o.Filename, o.In)
+		if err != nil {
+			return err
+		}
+		o.request = req
+	}
+
+	req, flags := o.request, cmd.Flags()
+	if flags.Changed("name") {
+		req.Name = o.Name
+	}
+	if flags.Changed("type") {
+		req.Type = o.Type
+	}
+	if flags.Changed("payload") {
+		req.Payload = nil
+		if err := json.Unmarshal([]byte(o.Payload), &req.Payload); err != nil {
+			return fmt.Errorf("--payload must be a JSON object: %w", err)
+		}
+	}
+	if flags.Changed("priority") {
+		req.Priority = protocol.TaskPriority(o.Priority)
+	}
+	if flags.Changed("timeout") {
+		req.Timeout = o.Timeout.String()
+	}
+	if flags.Changed("metadata") {
+		req.Metadata = o.Metadata
+	}
+	if flags.Changed("node") {
+		req.Mode, req.TargetNodeID = v1.ScheduleModeDirect, o.Node
+	}
+	if flags.Changed("require-skill") {
+		req.RequiredSkills = o.RequiredSkills
+	}
+	if flags.Changed("install-skills") {
+		req.InstallMissingSkills = o.InstallSkills
+	}
+	if flags.Changed("require-feature") {
+		req.RequiredFeatures = o.RequiredFeatures
+	}
+	if flags.Changed("require-capability") {
+		req.RequiredCapabilities = o.RequiredCapabilities
+	}
+	if flags.Changed("prefer-tag") {
+		req.PreferredTags = o.PreferredTags
+	}
+	if flags.Changed("min-cpu") || flags.Changed("min-memory") {
+		if req.Resources == nil {
+			req.Resources = &v1.ResourceRequirements{}
+		}
+		if flags.Changed("min-cpu") {
+			req.Resources.MinCPUCores = o.MinCPUCores
+		}
+		if flags.Changed("min-memory") {
+			req.Resources.MinMemoryMB = o.MinMemoryMB
+		}
+	}
+	if req.Mode == "" && req.TargetNodeID != "" {
+		req.Mode = v1.ScheduleModeDirect
+	}
+
+	var err error
+	o.printer, err = cmdutil.PrinterForCommand(printSubmitted)
+
+	return err
+}
+
+// Validate makes sure there is no discrepancy in command options.
+func (o *Run) Validate() error {
+	req := o.request
+	if req.Type == "" {
+		return fmt.Errorf("the task type is required, set --type or the type of the manifest")
+	}
+	if req.Priority < protocol.TaskPriorityLow || req.Priority > protocol.TaskPriorityCritical {
+		return fmt.Errorf("priority must be between %d and %d", protocol.TaskPriorityLow, protocol.TaskPriorityCritical)
+	}
+	if o.Timeout < 0 {
+		return fmt.Errorf("--timeout must not be negative")
+	}
+	if req.Mode == v1.ScheduleModeDirect && req.TargetNodeID == "" {
+		return fmt.Errorf("a task in %s mode needs a target node, set --node", v1.ScheduleModeDirect)
+	}
+
+	return nil
+}
+
+// Run executes a task run sub command using the specified options.
+func (o *Run) Run(ctx context.Context, args []string) error {
+	client, err := o.Factory.HivemindClient()
+	if err != nil {
+		return err
+	}
+
+	task, err := client.Tasks().Create(ctx, o.request)
+	if err != nil {
+		return err
+	}
+	if err := o.printer.PrintObj(task, o.Out); err != nil || !o.Watch {
+		return err
+	}
+
+	last, err := followEvents(ctx, client, task.ID, 0, o.printer, o.Out)
+	if err != nil {
+		return err
+	}
+	if last == nil || last.Status != protocol.TaskStatusCompleted {
+		return fmt.Errorf("task %s did not complete", task.ID)
+	}
+
+	return nil
+}
+
+// readManifest decodes a task request from a YAML or JSON file. JSON manifests
+// use the field names of the API, YAML ones the camel case names.
+func readManifest(filename string, stdin io.Reader) (*v1.SubmitTaskRequest, error) {
+	var data []byte
+	var err error
+	if filename == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(filename)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read manifest failed: %w", err)
+	}
+
+	req := &v1.SubmitTaskRequest{}
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		err = json.Unmarshal(data, req)
+	} else {
+		err = yaml.Unmarshal(data, req)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decode manifest %s failed: %w", filename, err)
+	}
+
+	return req, nil
+}
+
+func printSubmitted(obj any, w io.Writer, wide bool) error {
+	switch obj := obj.(type) {
+	case *v1.Task:
+		if obj.AssignedNodeID != "" {
+			_, err := fmt.Fprintf(w, "task/%s submitted, %s to %s\n", obj.ID, obj.Status, obj.AssignedNodeID)
+			return err
+		}
+		_, err := fmt.Fprintf(w, "task/%s submitted, %s\n", obj.ID, obj.Status)
+
+		return err
+	default:
+		return printEvent(obj, w, wide)
+	}
+}
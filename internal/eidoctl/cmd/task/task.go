@@ -0,0 +1,34 @@
+package task
+
+import (
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/spf13/cobra"
+)
+
+var taskLong = templates.LongDesc(`
+		Submit, inspect and follow the tasks scheduled by the hivemind.
+
+		A task is either placed on a given node (direct mode) or on the node the
+		scheduler scores best for its requirements (ai mode).`)
+
+// NewCmdTask returns new initialized instance of 'task' sub command.
+func NewCmdTask(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "task SUBCOMMAND",
+		DisableFlagsInUseLine: true,
+		Short:                 "Manage tasks",
+		Long:                  taskLong,
+		Run:                   cmdutil.DefaultSubCommandRun(ioStreams.ErrOut),
+	}
+
+	cmd.AddCommand(NewCmdRun(f, ioStreams))
+	cmd.AddCommand(NewCmdGet(f, ioStreams))
+	cmd.AddCommand(NewCmdList(f, ioStreams))
+	cmd.AddCommand(NewCmdCancel(f, ioStreams))
+	cmd.AddCommand(NewCmdLogs(f, ioStreams))
+	cmd.AddCommand(NewCmdWatch(f, ioStreams))
+
+	return cmd
+}
@@ -0,0 +1,137 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/internal/pkg/client/hivemind"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/kiosk404/eidolon/pkg/cli/printers"
+	"github.com/spf13/cobra"
+)
+
+var watchExample = templates.Examples(`
+		# Follow a task until it finishes
+		eidoctl task watch task-6f1c2a
+
+		# Print the events as JSON, one object per event
+		eidoctl task watch task-6f1c2a -o json
+
+		# Only print the events after the 5th one
+		eidoctl task watch task-6f1c2a --since=5`)
+
+// Watch is an options struct to support 'task watch' sub command.
+type Watch struct {
+	Since int64
+
+	printer printers.ResourcePrinter
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewCmdWatch returns new initialized instance of 'task watch' sub command.
+func NewCmdWatch(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &Watch{Factory: f, IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:                   "watch TASK_ID",
+		DisableFlagsInUseLine: true,
+		Short:                 "Follow the lifecycle events of a task",
+		Long: templates.LongDesc(`
+		Print the lifecycle events of a task as they happen: submission, assignment,
+		progress, rescheduling and the final outcome. The events recorded so far are
+		printed first. The command returns when the task finishes.`),
+		Example: watchExample,
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete())
+			cmdutil.CheckErr(o.Run(cmd.Context(), args))
+		},
+	}
+
+	cmd.Flags().Int64Var(&o.Since, "since", o.Since, "Skip the events up to and including this sequence number")
+
+	return cmd
+}
+
+// Complete completes all the required options.
+func (o *Watch) Complete() error {
+	var err error
+	o.printer, err = cmdutil.PrinterForCommand(printEvent)
+
+	return err
+}
+
+// Run executes a task watch sub command using the specified options.
+func (o *Watch) Run(ctx context.Context, args []string) error {
+	client, err := o.Factory.HivemindClient()
+	if err != nil {
+		return err
+	}
+
+	_, err = followEvents(ctx, client, args[0], o.Since, o.printer, o.Out)
+
+	return err
+}
+
+// followEvents prints the events of a task until it finishes and returns the
+// last one.
+func followEvents(ctx context.Context, client *hivemind.Client, id string, since int64,
+	printer printers.ResourcePrinter, out io.Writer,
+) (*v1.TaskEvent, error) {
+	var last *v1.TaskEvent
+	err := client.Tasks().Watch(ctx, id, since, func(e *v1.TaskEvent) error {
+		last = e
+		return printer.PrintObj(e, out)
+	})
+
+	return last, err
+}
+
+func printEvent(obj any, w io.Writer, wide bool) error {
+	e, ok := obj.(*v1.TaskEvent)
+	if !ok {
+		return fmt.Errorf("unexpected object %T", obj)
+	}
+
+	var detail []string
+	if e.NodeID != "" {
+		detail = append(detail, "node "+e.NodeID)
+	}
+	if p := e.Progress; p != nil {
+		detail = append(detail, fmt.Sprintf("%.0f%%", p.Percent))
+		if p.Message != "" {
+			detail = append(detail, p.Message)
+		}
+	}
+	if e.Reason != "" {
+		detail = append(detail, e.Reason)
+	}
+	if e.Error != "" {
+		detail = append(detail, "error: "+e.Error)
+	}
+	if r := e.Result; r != nil && len(r.Output) > 0 {
+		output, err := json.Marshal(r.Output)
+		if err != nil {
+			return err
+		}
+		detail = append(detail, "output: "+string(output))
+	}
+
+	ts := e.Timestamp.Local().Format(time.TimeOnly)
+	if wide {
+		ts = e.Timestamp.Local().Format(time.RFC3339)
+	}
+	line := fmt.Sprintf("%s  %-11s %-9s %s", ts, e.Type, e.Status, strings.Join(detail, ", "))
+	_, err := fmt.Fprintln(w, strings.TrimRight(line, " "))
+
+	return err
+}
@@ -0,0 +1,116 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/kiosk404/eidolon/pkg/cli/printers"
+	"github.com/spf13/cobra"
+)
+
+var createExample = templates.Examples(`
+		# Create a token that lets one node join within 24 hours
+		eidoctl token create
+
+		# Create a token for up to 10 GPU nodes, valid for a week
+		eidoctl token create --usages=10 --ttl=168h --tags=gpu=true,zone=eu-1
+
+		# Create a token that never expires and can be used any number of times
+		eidoctl token create --ttl=0 --usages=0`)
+
+// Create is an options struct to support 'token create' sub command.
+type Create struct {
+	Description string
+	TTL         time.Duration
+	Usages      int
+	Tags        map[string]string
+
+	printer printers.ResourcePrinter
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewCreateOptions returns an initialized Create instance.
+func NewCreateOptions(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *Create {
+	return &Create{
+		TTL:       24 * time.Hour,
+		Usages:    1,
+		Factory:   f,
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdCreate returns new initialized instance of 'token create' sub command.
+func NewCmdCreate(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewCreateOptions(f, ioStreams)
+
+	cmd := &cobra.Command{
+		Use:                   "create",
+		DisableFlagsInUseLine: true,
+		Short:                 "Create a bootstrap token and print it",
+		Long:                  "Create a bootstrap token on the hivemind and print it. The token cannot be shown again.",
+		Example:               createExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete())
+			cmdutil.CheckErr(o.Validate())
+			cmdutil.CheckErr(o.Run(cmd.Context(), args))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.Description, "description", o.Description, "A human friendly description of the token")
+	cmd.Flags().DurationVar(&o.TTL, "ttl", o.TTL, "The duration before the token expires, 0 means never")
+	cmd.Flags().IntVar(&o.Usages, "usages", o.Usages, "The number of nodes that may join with the token, 0 means unlimited")
+	cmd.Flags().StringToStringVar(&o.Tags, "tags", o.Tags, "Tags attached to every node joining with the token")
+
+	return cmd
+}
+
+// Complete completes all the required options.
+func (o *Create) Complete() error {
+	var err error
+	o.printer, err = cmdutil.PrinterForCommand(func(obj any, w io.Writer, _ bool) error {
+		_, err := fmt.Fprintln(w, obj.(*v1.Token).Token)
+		return err
+	})
+
+	return err
+}
+
+// Validate makes sure there is no discrepancy in command options.
+func (o *Create) Validate() error {
+	if o.TTL < 0 {
+		return fmt.Errorf("--ttl must not be negative")
+	}
+	if o.Usages < 0 {
+		return fmt.Errorf("--usages must not be negative")
+	}
+
+	return nil
+}
+
+// Run executes a token create sub command using the specified options.
+func (o *Create) Run(ctx context.Context, args []string) error {
+	client, err := o.Factory.HivemindClient()
+	if err != nil {
+		return err
+	}
+
+	token, err := client.Tokens().Create(ctx, &v1.CreateTokenRequest{
+		Description: o.Description,
+		TTL:         o.TTL.String(),
+		MaxUses:     &o.Usages,
+		Tags:        o.Tags,
+	})
+	if err != nil {
+		return err
+	}
+
+	return o.printer.PrintObj(token, o.Out)
+}
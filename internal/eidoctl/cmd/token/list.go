@@ -0,0 +1,139 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/kiosk404/eidolon/pkg/cli/printers"
+	"github.com/spf13/cobra"
+)
+
+// List is an options struct to support 'token list' sub command.
+type List struct {
+	printer printers.ResourcePrinter
+
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewCmdList returns new initialized instance of 'token list' sub command.
+func NewCmdList(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &List{Factory: f, IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:                   "list",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"ls"},
+		Short:                 "List bootstrap tokens",
+		Long:                  "List the bootstrap tokens on the hivemind, without their secrets.",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete())
+			cmdutil.CheckErr(o.Run(cmd.Context(), args))
+		},
+	}
+
+	return cmd
+}
+
+// Complete completes all the required options.
+func (o *List) Complete() error {
+	var err error
+	o.printer, err = cmdutil.PrinterForCommand(printTokens)
+
+	return err
+}
+
+// Run executes a token list sub command using the specified options.
+func (o *List) Run(ctx context.Context, args []string) error {
+	client, err := o.Factory.HivemindClient()
+	if err != nil {
+		return err
+	}
+
+	list, err := client.Tokens().List(ctx, v1.ListOptions{Limit: 500})
+	if err != nil {
+		return err
+	}
+
+	return o.printer.PrintObj(list, o.Out)
+}
+
+func printTokens(obj any, w io.Writer, wide bool) error {
+	list, ok := obj.(*v1.TokenList)
+	if !ok {
+		return fmt.Errorf("unexpected object %T", obj)
+	}
+
+	table := printers.NewTable(
+		printers.Column{Name: "ID"},
+		printers.Column{Name: "USAGES"},
+		printers.Column{Name: "TTL"},
+		printers.Column{Name: "EXPIRES"},
+		printers.Column{Name: "TAGS"},
+		printers.Column{Name: "DESCRIPTION"},
+		printers.Column{Name: "CREATED", Wide: true},
+		printers.Column{Name: "LAST USED", Wide: true},
+	)
+	for _, t := range list.Items {
+		table.AddRow(t.ID, usages(t), ttl(t), expires(t), tags(t.Tags), t.Description,
+			t.CreatedAt.Local().Format(time.RFC3339), lastUsed(t))
+	}
+
+	return table.Print(w, wide)
+}
+
+func usages(t *v1.Token) string {
+	if t.MaxUses == 0 {
+		return fmt.Sprintf("%d/unlimited", t.Uses)
+	}
+
+	return fmt.Sprintf("%d/%d", t.Uses, t.MaxUses)
+}
+
+func ttl(t *v1.Token) string {
+	switch {
+	case t.ExpiresAt == nil:
+		return "<forever>"
+	case t.Expired:
+		return "<expired>"
+	default:
+		return time.Until(*t.ExpiresAt).Round(time.Second).String()
+	}
+}
+
+func expires(t *v1.Token) string {
+	if t.ExpiresAt == nil {
+		return "<never>"
+	}
+
+	return t.ExpiresAt.Local().Format(time.RFC3339)
+}
+
+func lastUsed(t *v1.Token) string {
+	if t.LastUsedAt == nil {
+		return "<never>"
+	}
+
+	return t.LastUsedAt.Local().Format(time.RFC3339)
+}
+
+func tags(m map[string]string) string {
+	if len(m) == 0 {
+		return "<none>"
+	}
+
+	pairs := make([]string, 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
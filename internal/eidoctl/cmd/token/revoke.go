@@ -0,0 +1,65 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/spf13/cobra"
+)
+
+var revokeExample = templates.Examples(`
+		# Revoke a token by its ID
+		eidoctl token revoke 07401b
+
+		# The full token is accepted as well
+		eidoctl token revoke 07401b.f395accd246ae52d`)
+
+// Revoke is an options struct to support 'token revoke' sub command.
+type Revoke struct {
+	Factory cmdutil.Factory
+	genericclioptions.IOStreams
+}
+
+// NewCmdRevoke returns new initialized instance of 'token revoke' sub command.
+func NewCmdRevoke(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := &Revoke{Factory: f, IOStreams: ioStreams}
+
+	cmd := &cobra.Command{
+		Use:                   "revoke TOKEN_ID [TOKEN_ID...]",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"delete"},
+		Short:                 "Revoke bootstrap tokens",
+		Long:                  "Revoke bootstrap tokens so that no more nodes can join with them. Joined nodes are not affected.",
+		Example:               revokeExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 0 {
+				cmdutil.CheckErr(cmdutil.UsageErrorf(cmd, "at least one token ID is required"))
+			}
+			cmdutil.CheckErr(o.Run(cmd.Context(), args))
+		},
+	}
+
+	return cmd
+}
+
+// Run executes a token revoke sub command using the specified options.
+func (o *Revoke) Run(ctx context.Context, args []string) error {
+	client, err := o.Factory.HivemindClient()
+	if err != nil {
+		return err
+	}
+
+	for _, arg := range args {
+		id, _, _ := strings.Cut(arg, ".")
+		if err := client.Tokens().Revoke(ctx, id); err != nil {
+			return err
+		}
+		fmt.Fprintf(o.Out, "bootstrap token %q revoked\n", id)
+	}
+
+	return nil
+}
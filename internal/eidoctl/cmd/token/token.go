@@ -0,0 +1,32 @@
+package token
+
+import (
+	cmdutil "github.com/kiosk404/eidolon/internal/eidoctl/cmd/util"
+	"github.com/kiosk404/eidolon/internal/eidoctl/utils/templates"
+	"github.com/kiosk404/eidolon/pkg/cli/genericclioptions"
+	"github.com/spf13/cobra"
+)
+
+var tokenLong = templates.LongDesc(`
+		Manage the bootstrap tokens golems use to join the hivemind.
+
+		A bootstrap token has the form "[a-z0-9]{6}.[a-z0-9]{16}". The first part is
+		the public token ID, the second part is the secret, which is only shown when
+		the token is created.`)
+
+// NewCmdToken returns new initialized instance of 'token' sub command.
+func NewCmdToken(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "token SUBCOMMAND",
+		DisableFlagsInUseLine: true,
+		Short:                 "Manage bootstrap tokens",
+		Long:                  tokenLong,
+		Run:                   cmdutil.DefaultSubCommandRun(ioStreams.ErrOut),
+	}
+
+	cmd.AddCommand(NewCmdCreate(f, ioStreams))
+	cmd.AddCommand(NewCmdList(f, ioStreams))
+	cmd.AddCommand(NewCmdRevoke(f, ioStreams))
+
+	return cmd
+}
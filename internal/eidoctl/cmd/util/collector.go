@@ -0,0 +1,51 @@
+package util
+
+import (
+	"context"
+	"os"
+	"runtime"
+
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+	"github.com/kiosk404/eidolon/pkg/utils/iputil"
+	"github.com/kiosk404/eidolon/pkg/version"
+	hoststat "github.com/likexian/host-stat-go"
+)
+
+type defaultNodeInfoCollector struct {
+}
+
+// Collect reports the static hardware description of the local machine. The
+// values host-stat-go cannot read are left empty rather than failing the join.
+func (d defaultNodeInfoCollector) Collect(ctx context.Context) (*protocol.NodeInfo, error) {
+	info := &protocol.NodeInfo{
+		Address: iputil.GetLocalIP(),
+		Version: version.Get().GitVersion,
+		SystemInfo: protocol.SystemInfo{
+			OS:       runtime.GOOS,
+			Arch:     runtime.GOARCH,
+			CPUCores: runtime.NumCPU(),
+		},
+	}
+
+	if hostInfo, err := hoststat.GetHostInfo(); err == nil {
+		info.SystemInfo.Hostname = hostInfo.HostName
+	} else if hostname, err := os.Hostname(); err == nil {
+		info.SystemInfo.Hostname = hostname
+	}
+	info.Name = info.SystemInfo.Hostname
+
+	if memStat, err := hoststat.GetMemStat(); err == nil {
+		info.SystemInfo.MemoryMB = memStat.MemTotal
+	}
+
+	if disks, err := hoststat.GetDiskStat(); err == nil {
+		for _, disk := range disks {
+			if disk.Mount == "/" {
+				info.SystemInfo.DiskFreeMB = disk.Free
+				break
+			}
+		}
+	}
+
+	return info, nil
+}
@@ -0,0 +1,58 @@
+package util
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type defaultHivemindConnector struct {
+	conn *grpc.ClientConn
+}
+
+func (d *defaultHivemindConnector) Connect(ctx context.Context, addr string, tlsConfig *tls.Config) error {
+	creds := insecure.NewCredentials()
+	if tlsConfig != nil {
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return fmt.Errorf("dial hivemind %s: %w", addr, err)
+	}
+
+	// grpc.NewClient connects lazily, wait for the connection so that an
+	// unreachable hivemind is reported as such instead of as a failed call.
+	conn.Connect()
+	for state := conn.GetState(); state != connectivity.Ready; state = conn.GetState() {
+		if !conn.WaitForStateChange(ctx, state) {
+			conn.Close()
+			return fmt.Errorf("connect to hivemind %s: %w (last state %s)", addr, ctx.Err(), state)
+		}
+	}
+	d.conn = conn
+
+	return nil
+}
+
+func (d *defaultHivemindConnector) Join(ctx context.Context, req *protocol.JoinRequest) (*protocol.JoinResponse, error) {
+	if d.conn == nil {
+		return nil, fmt.Errorf("not connected to the hivemind")
+	}
+
+	return protocol.NewBootstrapClient(d.conn).Join(ctx, req)
+}
+
+func (d *defaultHivemindConnector) Close() error {
+	if d.conn == nil {
+		return nil
+	}
+
+	return d.conn.Close()
+}
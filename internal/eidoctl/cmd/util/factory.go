@@ -2,6 +2,13 @@ package util
 
 import (
 	"context"
+	"crypto/tls"
+	"time"
+
+	"github.com/kiosk404/eidolon/internal/eidoctl/preflight"
+	"github.com/kiosk404/eidolon/internal/pkg/client/hivemind"
+	"github.com/kiosk404/eidolon/internal/pkg/options"
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
 )
 
 // Factory provides abstractions that allow the Eidoctl command to be extended across multiple types
@@ -14,40 +21,63 @@ import (
 // upon peer methods in its own ring.
 // commands are decoupled from the factory).
 type Factory interface {
+	options.RESTClientGetter
+
+	// HivemindClient returns a client of the hivemind REST API.
+	HivemindClient() (*hivemind.Client, error)
 	HivemindConnector() HivemindConnector
-	NodeChecker() NodeChecker
+	// NodeChecker returns the pre-flight checks of the local node.
+	NodeChecker(opts *preflight.Options) NodeChecker
 	NodeInfoCollector() NodeInfoCollector
 }
 
+// HivemindConnector talks to the gRPC endpoint of a hivemind on behalf of a node.
 type HivemindConnector interface {
-	Connect(ctx context.Context, addr string) error
-	Join(ctx context.Context, token string)
-	NodeInfoCollector() NodeInfoCollector
+	// Connect dials the hivemind at addr. A nil tlsConfig connects without TLS.
+	Connect(ctx context.Context, addr string, tlsConfig *tls.Config) error
+
+	// Join exchanges a bootstrap token for the node's identity.
+	Join(ctx context.Context, req *protocol.JoinRequest) (*protocol.JoinResponse, error)
+
+	// Close releases the connection.
+	Close() error
 }
 
+// NodeInfoCollector gathers the registration data of the local node.
 type NodeInfoCollector interface {
-	Collect(ctx context.Context) error
+	Collect(ctx context.Context) (*protocol.NodeInfo, error)
 }
 
+// NodeChecker runs pre-flight checks on the local node.
 type NodeChecker interface {
 	RunAll(ctx context.Context) ([]*NodeCheckResult, error)
 
 	RunChecker(ctx context.Context, name string) (*NodeCheckResult, error)
 }
 
-type NodeCheckResult struct {
-	Name    string
-	Status  string
-	Message string
-	Passed  bool
-	Errors  []error
-}
+// NodeCheckResult is the outcome of a single pre-flight check.
+type NodeCheckResult = preflight.NodeCheckResult
 
 type defaultFactory struct {
+	options.RESTClientGetter
+}
+
+func NewDefaultFactory(clientGetter options.RESTClientGetter) Factory {
+	return &defaultFactory{RESTClientGetter: clientGetter}
 }
 
-func NewDefaultFactory() Factory {
-	return &defaultFactory{}
+func (d *defaultFactory) HivemindClient() (*hivemind.Client, error) {
+	clientConfig, err := d.ToClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := clientConfig.HivemindConfig()
+	if err != nil {
+		return nil, err
+	}
+	cfg.Timeout = 30 * time.Second
+
+	return hivemind.NewForConfig(cfg)
 }
 
 func (d *defaultFactory) HivemindConnector() HivemindConnector {
@@ -58,45 +88,6 @@ func (d *defaultFactory) NodeInfoCollector() NodeInfoCollector {
 	return &defaultNodeInfoCollector{}
 }
 
-func (d *defaultFactory) NodeChecker() NodeChecker {
-	return &defaultNodeChecker{}
-}
-
-type defaultHivemindConnector struct {
-}
-
-func (d defaultHivemindConnector) Connect(ctx context.Context, addr string) error {
-	//TODO implement me
-	panic("implement me")
-}
-
-func (d defaultHivemindConnector) Join(ctx context.Context, token string) {
-	//TODO implement me
-	panic("implement me")
-}
-
-func (d defaultHivemindConnector) NodeInfoCollector() NodeInfoCollector {
-	//TODO implement me
-	panic("implement me")
-}
-
-type defaultNodeInfoCollector struct {
-}
-
-func (d defaultNodeInfoCollector) Collect(ctx context.Context) error {
-	//TODO implement me
-	panic("implement me")
-}
-
-type defaultNodeChecker struct {
-}
-
-func (d defaultNodeChecker) RunAll(ctx context.Context) ([]*NodeCheckResult, error) {
-	//TODO implement me
-	panic("implement me")
-}
-
-func (d defaultNodeChecker) RunChecker(ctx context.Context, name string) (*NodeCheckResult, error) {
-	//TODO implement me
-	panic("implement me")
+func (d *defaultFactory) NodeChecker(opts *preflight.Options) NodeChecker {
+	return preflight.NewChecker(opts)
 }
@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"net/url"
 	"os"
 	"strings"
 
 	"github.com/bytedance/gopkg/util/logger"
 	"github.com/kiosk404/eidolon/pkg/errorx"
+	"github.com/spf13/cobra"
 )
 
 const (
@@ -78,10 +80,9 @@ func checkErr(err error, handleErr func(string, int)) {
 	case errors.Is(err, ErrExit):
 		handleErr("", DefaultErrorExitCode)
 	default:
-		var err errorx.Aggregate
 		switch {
-		case errors.As(err, &err):
-			handleErr(MultipleErrors(``, err.Errors()), DefaultErrorExitCode)
+		case errors.As(err, &agg):
+			handleErr(MultipleErrors(``, agg.Errors()), DefaultErrorExitCode)
 		default: // for any other error type
 			msg, ok := StandardErrorMessage(err)
 			if !ok {
@@ -141,3 +142,39 @@ func StandardErrorMessage(err error) (string, bool) {
 	}
 	return "", false
 }
+
+// UsageErrorf returns an error that tells the user how to get help for cmd.
+func UsageErrorf(cmd *cobra.Command, format string, args ...interface{}) error {
+	msg := fmt.Sprintf(format, args...)
+	return fmt.Errorf("%s\nSee '%s -h' for help and examples", msg, cmd.CommandPath())
+}
+
+// DefaultSubCommandRun prints a command's help string to the specified output if no
+// arguments (sub-commands) are provided, or a usage error otherwise.
+func DefaultSubCommandRun(out io.Writer) func(c *cobra.Command, args []string) {
+	return func(c *cobra.Command, args []string) {
+		c.SetOut(out)
+		c.SetErr(out)
+		if len(args) > 0 {
+			CheckErr(UsageErrorf(c, "unknown command %q", args[0]))
+		}
+		_ = c.Help()
+		CheckErr(ErrExit)
+	}
+}
+
+// Confirm asks a yes/no question on out and reads the answer from in. Anything
+// but "y" or "yes", including the end of input, is a no.
+func Confirm(in io.Reader, out io.Writer, question string) bool {
+	fmt.Fprintf(out, "%s [y/N]: ", question)
+
+	var answer string
+	if _, err := fmt.Fscanln(in, &answer); err != nil {
+		fmt.Fprintln(out)
+		return false
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,39 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kiosk404/eidolon/internal/pkg/client/hivemind"
+)
+
+// NodePollInterval is how often WaitNodeDeregistered checks the node.
+const NodePollInterval = 2 * time.Second
+
+// WaitNodeDeregistered polls the hivemind until a draining node is gone. It
+// fails once the node stops draining while still registered, which happens when
+// the drain timed out without force or was aborted.
+func WaitNodeDeregistered(ctx context.Context, client *hivemind.Client, nodeID string) error {
+	ticker := time.NewTicker(NodePollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		node, err := client.Nodes().Get(ctx, nodeID)
+		if hivemind.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("get node failed: %w", err)
+		}
+		if !node.Draining {
+			return fmt.Errorf("node %s still runs tasks and stays cordoned, the drain timed out or was aborted; "+
+				"retry with --timeout and --force to cancel them", nodeID)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package util
+
+import (
+	"github.com/kiosk404/eidolon/internal/eidoctl/types"
+	"github.com/kiosk404/eidolon/pkg/cli/printers"
+	"github.com/spf13/viper"
+)
+
+// PrinterForCommand returns the printer of the output format selected with the
+// global --output flag. human prints the default and wide formats.
+func PrinterForCommand(human printers.HumanReadable) (printers.ResourcePrinter, error) {
+	return printers.NewPrinter(viper.GetString(types.FlagOutput), human)
+}
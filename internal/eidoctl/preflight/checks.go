@@ -0,0 +1,252 @@
+package preflight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	hoststat "github.com/likexian/host-stat-go"
+)
+
+// supportedPlatforms are the GOOS/GOARCH pairs golems are released for.
+var supportedPlatforms = map[string]bool{
+	"linux/amd64":  true,
+	"linux/arm64":  true,
+	"darwin/amd64": true,
+	"darwin/arm64": true,
+}
+
+func init() {
+	Register(CheckFunc{"os-arch", checkPlatform})
+	Register(CheckFunc{"cpu", checkCPU})
+	Register(CheckFunc{"memory", checkMemory})
+	Register(CheckFunc{"disk", checkDisk})
+	Register(CheckFunc{"workspace", checkWorkspace})
+	Register(CheckFunc{"hivemind", checkHivemind})
+	Register(CheckFunc{"clock-skew", checkClockSkew})
+	Register(CheckFunc{"binaries", checkBinaries})
+	Register(CheckFunc{"ports", checkPorts})
+}
+
+func checkPlatform(_ context.Context, _ *Options) *NodeCheckResult {
+	platform := runtime.GOOS + "/" + runtime.GOARCH
+	if !supportedPlatforms[platform] {
+		return Fail("os-arch", "platform %s is not supported", platform)
+	}
+
+	return Pass("os-arch", "platform %s", platform)
+}
+
+func checkCPU(_ context.Context, opts *Options) *NodeCheckResult {
+	cores := runtime.NumCPU()
+	if info, err := hoststat.GetCPUInfo(); err == nil && info.CoreCount > 0 {
+		cores = int(info.CoreCount)
+	}
+
+	if cores < opts.MinCPUCores {
+		return Fail("cpu", "%d CPU cores available, at least %d required", cores, opts.MinCPUCores)
+	}
+
+	return Pass("cpu", "%d CPU cores", cores)
+}
+
+func checkMemory(_ context.Context, opts *Options) *NodeCheckResult {
+	mem, err := hoststat.GetMemStat()
+	if err != nil {
+		return Warn("memory", "cannot read memory size: %s", err.Error())
+	}
+
+	if mem.MemTotal < opts.MinMemoryMB {
+		return Fail("memory", "%d MB of memory, at least %d MB required", mem.MemTotal, opts.MinMemoryMB)
+	}
+
+	return Pass("memory", "%d MB of memory", mem.MemTotal)
+}
+
+func checkDisk(_ context.Context, opts *Options) *NodeCheckResult {
+	dir := opts.DataDir
+	if dir == "" {
+		dir = opts.Workspace
+	}
+	if dir == "" {
+		return Skip("disk", "no data directory configured")
+	}
+
+	existing := nearestExisting(dir)
+	var fs syscall.Statfs_t
+	if err := syscall.Statfs(existing, &fs); err != nil {
+		return Warn("disk", "cannot stat %s: %s", existing, err.Error())
+	}
+	freeMB := fs.Bavail * uint64(fs.Bsize) / (1024 * 1024)
+
+	if freeMB < opts.MinDiskFreeMB {
+		return Fail("disk", "%d MB free in %s, at least %d MB required", freeMB, existing, opts.MinDiskFreeMB)
+	}
+
+	return Pass("disk", "%d MB free in %s", freeMB, existing)
+}
+
+func checkWorkspace(_ context.Context, opts *Options) *NodeCheckResult {
+	if opts.Workspace == "" {
+		return Skip("workspace", "no workspace configured")
+	}
+
+	// The workspace may not exist yet, it is then created in its nearest existing parent.
+	dir := nearestExisting(opts.Workspace)
+	if info, err := os.Stat(dir); err != nil {
+		return Fail("workspace", "cannot stat %s: %s", dir, err.Error())
+	} else if !info.IsDir() {
+		return Fail("workspace", "%s is not a directory", dir)
+	}
+
+	f, err := os.CreateTemp(dir, ".eidoctl-preflight-*")
+	if err != nil {
+		return Fail("workspace", "%s is not writable: %s", dir, err.Error())
+	}
+	f.Close()
+	os.Remove(f.Name())
+
+	return Pass("workspace", "%s is writable", dir)
+}
+
+func checkHivemind(ctx context.Context, opts *Options) *NodeCheckResult {
+	if opts.HivemindAddr == "" {
+		return Skip("hivemind", "no hivemind address configured")
+	}
+
+	dialer := net.Dialer{Timeout: opts.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", opts.HivemindAddr)
+	if err != nil {
+		if opts.HivemindRequired {
+			return Fail("hivemind", "%s is not reachable: %s", opts.HivemindAddr, err.Error())
+		}
+
+		return Warn("hivemind", "%s is not reachable: %s", opts.HivemindAddr, err.Error())
+	}
+	conn.Close()
+
+	return Pass("hivemind", "%s is reachable", opts.HivemindAddr)
+}
+
+// checkClockSkew compares the local clock with the Date header of the hivemind.
+// Client certificates are only valid within their validity window, so a large
+// skew breaks mutual TLS.
+func checkClockSkew(ctx context.Context, opts *Options) *NodeCheckResult {
+	if opts.HivemindAPI == "" {
+		return Skip("clock-skew", "no hivemind API configured")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	url := strings.TrimSuffix(opts.HivemindAPI, "/") + "/healthz"
+	if !strings.Contains(url, "://") {
+		url = "http://" + url
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return Warn("clock-skew", "cannot query %s: %s", url, err.Error())
+	}
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return Warn("clock-skew", "cannot query %s: %s", url, err.Error())
+	}
+	resp.Body.Close()
+	rtt := time.Since(start)
+
+	remote, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return Warn("clock-skew", "hivemind sent no usable Date header")
+	}
+	// The Date header has a one second resolution and was produced half way through the request.
+	skew := start.Add(rtt / 2).Sub(remote)
+	if skew < 0 {
+		skew = -skew
+	}
+	skew = skew.Truncate(time.Second)
+
+	switch {
+	case skew > opts.MaxClockSkew:
+		return Fail("clock-skew", "clock is %s off the hivemind, at most %s tolerated", skew, opts.MaxClockSkew)
+	case skew > opts.MaxClockSkew/2:
+		return Warn("clock-skew", "clock is %s off the hivemind", skew)
+	default:
+		return Pass("clock-skew", "clock is %s off the hivemind", skew)
+	}
+}
+
+func checkBinaries(_ context.Context, opts *Options) *NodeCheckResult {
+	var missing, missingOptional []string
+	for _, bin := range opts.RequiredBinaries {
+		if _, err := exec.LookPath(bin); err != nil {
+			missing = append(missing, bin)
+		}
+	}
+	for _, bin := range opts.OptionalBinaries {
+		if _, err := exec.LookPath(bin); err != nil {
+			missingOptional = append(missingOptional, bin)
+		}
+	}
+
+	switch {
+	case len(missing) > 0:
+		return Fail("binaries", "required binaries not found in PATH: %s", strings.Join(missing, ", "))
+	case len(missingOptional) > 0:
+		return Warn("binaries", "optional binaries not found in PATH: %s", strings.Join(missingOptional, ", "))
+	case len(opts.RequiredBinaries)+len(opts.OptionalBinaries) == 0:
+		return Skip("binaries", "no binaries required")
+	default:
+		return Pass("binaries", "found %s", strings.Join(append(opts.RequiredBinaries, opts.OptionalBinaries...), ", "))
+	}
+}
+
+func checkPorts(_ context.Context, opts *Options) *NodeCheckResult {
+	if len(opts.Ports) == 0 {
+		return Skip("ports", "no ports required")
+	}
+
+	var busy []string
+	for _, port := range opts.Ports {
+		l, err := net.Listen("tcp", net.JoinHostPort("", strconv.Itoa(port)))
+		if err != nil {
+			if errors.Is(err, syscall.EADDRINUSE) {
+				busy = append(busy, strconv.Itoa(port))
+				continue
+			}
+
+			return Fail("ports", "cannot listen on port %d: %s", port, err.Error())
+		}
+		l.Close()
+	}
+	if len(busy) > 0 {
+		return Fail("ports", "ports already in use: %s", strings.Join(busy, ", "))
+	}
+
+	return Pass("ports", "ports %s are free", fmt.Sprint(opts.Ports))
+}
+
+// nearestExisting returns path or its closest ancestor that exists.
+func nearestExisting(path string) string {
+	path = filepath.Clean(path)
+	for {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return path
+		}
+		path = parent
+	}
+}
@@ -0,0 +1,251 @@
+// Package preflight verifies that the local machine can run a golem before
+// eidoctl initializes it or joins it to a hivemind.
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Status values of a NodeCheckResult.
+const (
+	StatusPass    = "pass"
+	StatusWarning = "warning"
+	StatusFail    = "fail"
+	StatusSkipped = "skipped"
+)
+
+// IgnoreAll ignores the failures of every check when passed in Options.Ignore.
+const IgnoreAll = "all"
+
+// NodeCheckResult is the outcome of a single check.
+type NodeCheckResult struct {
+	Name    string  `json:"name"    yaml:"name"`
+	Status  string  `json:"status"  yaml:"status"`
+	Message string  `json:"message" yaml:"message"`
+	Passed  bool    `json:"passed"  yaml:"passed"`
+	Errors  []error `json:"-"       yaml:"-"`
+}
+
+// Options parameterizes the checks.
+type Options struct {
+	// Workspace and DataDir are the directories the golem writes to.
+	Workspace string
+	DataDir   string
+
+	// HivemindAddr is the gRPC address of the hivemind; empty skips the check.
+	HivemindAddr string
+	// HivemindAPI is the REST address of the hivemind, used to measure the clock skew.
+	HivemindAPI string
+	// HivemindRequired turns an unreachable hivemind from a warning into a failure.
+	HivemindRequired bool
+
+	MinCPUCores   int
+	MinMemoryMB   uint64
+	MinDiskFreeMB uint64
+
+	// MaxClockSkew is the skew tolerated before the check fails; half of it warns.
+	MaxClockSkew time.Duration
+
+	// RequiredBinaries must be in PATH, OptionalBinaries only warn when missing.
+	RequiredBinaries []string
+	OptionalBinaries []string
+
+	// Ports must be free to listen on.
+	Ports []int
+
+	// Ignore lists checks whose failures are reported as warnings, or IgnoreAll.
+	Ignore []string
+
+	// Timeout bounds each check that talks to the network.
+	Timeout time.Duration
+}
+
+// NewOptions returns Options with the minimum requirements of a golem.
+func NewOptions() *Options {
+	return &Options{
+		MinCPUCores:      2,
+		MinMemoryMB:      1024,
+		MinDiskFreeMB:    1024,
+		MaxClockSkew:     5 * time.Minute,
+		RequiredBinaries: []string{"tar"},
+		OptionalBinaries: []string{"git"},
+		Timeout:          5 * time.Second,
+	}
+}
+
+// Check is a single named pre-flight check.
+type Check interface {
+	Name() string
+	Run(ctx context.Context, opts *Options) *NodeCheckResult
+}
+
+// CheckFunc adapts a function to a Check.
+type CheckFunc struct {
+	CheckName string
+	Func      func(ctx context.Context, opts *Options) *NodeCheckResult
+}
+
+func (c CheckFunc) Name() string { return c.CheckName }
+
+func (c CheckFunc) Run(ctx context.Context, opts *Options) *NodeCheckResult { return c.Func(ctx, opts) }
+
+var registry []Check
+
+// Register adds a check; checks run in registration order.
+func Register(c Check) {
+	for _, existing := range registry {
+		if existing.Name() == c.Name() {
+			panic(fmt.Sprintf("preflight check %q registered twice", c.Name()))
+		}
+	}
+	registry = append(registry, c)
+}
+
+// Names returns the names of the registered checks, sorted.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for _, c := range registry {
+		names = append(names, c.Name())
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Checker runs the registered checks.
+type Checker struct {
+	opts   *Options
+	ignore map[string]bool
+}
+
+// NewChecker creates a Checker.
+func NewChecker(opts *Options) *Checker {
+	ignore := make(map[string]bool, len(opts.Ignore))
+	for _, name := range opts.Ignore {
+		ignore[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+
+	return &Checker{opts: opts, ignore: ignore}
+}
+
+// ValidateIgnore returns an error for ignored names that are not registered checks.
+func ValidateIgnore(names []string) error {
+	known := make(map[string]bool, len(registry))
+	for _, c := range registry {
+		known[c.Name()] = true
+	}
+
+	var unknown []string
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != IgnoreAll && !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown pre-flight checks %s, known checks are: %s",
+			strings.Join(unknown, ", "), strings.Join(Names(), ", "))
+	}
+
+	return nil
+}
+
+// RunAll runs every registered check.
+func (c *Checker) RunAll(ctx context.Context) ([]*NodeCheckResult, error) {
+	results := make([]*NodeCheckResult, 0, len(registry))
+	for _, check := range registry {
+		results = append(results, c.run(ctx, check))
+	}
+
+	return results, nil
+}
+
+// RunChecker runs the check with the given name.
+func (c *Checker) RunChecker(ctx context.Context, name string) (*NodeCheckResult, error) {
+	for _, check := range registry {
+		if check.Name() == name {
+			return c.run(ctx, check), nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown pre-flight check %q, known checks are: %s", name, strings.Join(Names(), ", "))
+}
+
+func (c *Checker) run(ctx context.Context, check Check) (r *NodeCheckResult) {
+	defer func() {
+		if p := recover(); p != nil {
+			r = Fail(check.Name(), "check panicked: %v", p)
+		}
+		if r == nil {
+			r = Fail(check.Name(), "check returned no result")
+		}
+		r.Name = check.Name()
+		r.Passed = r.Status == StatusPass || r.Status == StatusSkipped
+		if r.Status == StatusFail && (c.ignore[IgnoreAll] || c.ignore[check.Name()]) {
+			r.Status = StatusWarning
+			r.Message += " (ignored)"
+		}
+	}()
+
+	return check.Run(ctx, c.opts)
+}
+
+// Pass returns a passing result.
+func Pass(name, format string, args ...interface{}) *NodeCheckResult {
+	return &NodeCheckResult{Name: name, Status: StatusPass, Message: fmt.Sprintf(format, args...), Passed: true}
+}
+
+// Warn returns a warning result.
+func Warn(name, format string, args ...interface{}) *NodeCheckResult {
+	return &NodeCheckResult{Name: name, Status: StatusWarning, Message: fmt.Sprintf(format, args...)}
+}
+
+// Fail returns a failed result.
+func Fail(name, format string, args ...interface{}) *NodeCheckResult {
+	return &NodeCheckResult{Name: name, Status: StatusFail, Message: fmt.Sprintf(format, args...)}
+}
+
+// Skip returns a result for a check that does not apply.
+func Skip(name, format string, args ...interface{}) *NodeCheckResult {
+	return &NodeCheckResult{Name: name, Status: StatusSkipped, Message: fmt.Sprintf(format, args...), Passed: true}
+}
+
+// Mark returns the symbol printed in front of a result.
+func Mark(r *NodeCheckResult) string {
+	switch r.Status {
+	case StatusPass:
+		return "✔"
+	case StatusWarning:
+		return "⚠"
+	case StatusSkipped:
+		return "-"
+	default:
+		return "✖"
+	}
+}
+
+// Failed counts the results that failed.
+func Failed(results []*NodeCheckResult) int {
+	n := 0
+	for _, r := range results {
+		if r.Status == StatusFail {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Print writes one line per result to w and returns the number of failures.
+func Print(w io.Writer, results []*NodeCheckResult) int {
+	for _, r := range results {
+		fmt.Fprintf(w, "%s %-10s %s\n", Mark(r), r.Name, r.Message)
+	}
+
+	return Failed(results)
+}
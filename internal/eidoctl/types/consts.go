@@ -4,4 +4,5 @@ package types
 const (
 	FlagEidolonConfig   = "eidolon.config"
 	FlagAAAAAAAAAConfig = "AAAA-config"
+	FlagOutput          = "output"
 )
@@ -1,9 +1,12 @@
 package golem
 
 import (
-	"fmt"
+	"errors"
+	"io/fs"
+	"path/filepath"
 
-	"github.com/kiosk404/eidolon/internal/hivemind/options"
+	"github.com/kiosk404/eidolon/internal/golem/options"
+	"github.com/kiosk404/eidolon/internal/pkg/workspace"
 	"github.com/kiosk404/eidolon/pkg/app"
 	"github.com/kiosk404/eidolon/pkg/logger"
 )
@@ -18,6 +21,8 @@ func NewApp(basename string) *app.App {
 		basename,
 		app.WithOptions(opts),
 		app.WithDescription(`The golem is a worker node in the eidolon realm.`),
+		// The golem reads its configuration from the workspace.
+		app.WithNoConfig(),
 		app.WithDefaultValidArgs(),
 		app.WithRunFunc(run(opts)),
 	)
@@ -26,14 +31,19 @@ func NewApp(basename string) *app.App {
 
 func run(opts *options.Options) app.RunFunc {
 	return func(basename string) error {
-		logBaseName := basename
-		logPath := fmt.Sprintf("%s/%s.log", logBaseName, logBaseName)
+		ws := workspace.New(opts.Workspace)
+		cfg := ws.DefaultConfig()
+		if c, err := ws.LoadConfig(); err == nil {
+			cfg = c
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
 
-		if err := logger.InitLog(logPath); err != nil {
+		if err := logger.InitLog(filepath.Join(cfg.LogDir, AppName+".log")); err != nil {
 			return err
 		}
 		defer logger.FlushLog()
 
-		return nil
+		return Run(opts, ws, cfg)
 	}
 }
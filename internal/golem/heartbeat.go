@@ -0,0 +1,105 @@
+package golem
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kiosk404/eidolon/internal/golem/skill"
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+const logModule = "golem"
+
+// reportTimeout bounds the report of a task result.
+const reportTimeout = 30 * time.Second
+
+// heartbeater periodically reports the load and skills of the golem to the
+// hivemind and runs the tasks the hivemind hands back.
+type heartbeater struct {
+	nodeID   string
+	client   protocol.NodeClient
+	skills   *skill.Manager
+	fetch    skill.Fetcher
+	interval time.Duration
+
+	active atomic.Int32
+	wg     sync.WaitGroup
+}
+
+// Run sends a heartbeat right away and then every interval until ctx is done,
+// then waits for the running tasks.
+func (h *heartbeater) Run(ctx context.Context) {
+	defer h.wg.Wait()
+
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	for {
+		h.beat(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *heartbeater) beat(ctx context.Context) {
+	reqCtx, cancel := context.WithTimeout(ctx, h.interval)
+	defer cancel()
+
+	hb := h.skills.Heartbeat(h.nodeID, protocol.NodeLoadInfo{
+		ActiveTasks: int(h.active.Load()),
+		ReportedAt:  time.Now(),
+	})
+	resp, err := h.client.Heartbeat(reqCtx, hb)
+	if err != nil {
+		logger.WarnX(logModule, "heartbeat failed: %s", err.Error())
+		if hb.InstalledSkills != nil {
+			// Report the skills again with the next heartbeat.
+			h.skills.MarkChanged()
+		}
+		return
+	}
+
+	for _, task := range resp.Tasks {
+		h.active.Add(1)
+		h.wg.Add(1)
+		go func(task *protocol.Task) {
+			defer h.wg.Done()
+			defer h.active.Add(-1)
+			h.run(ctx, task)
+		}(task)
+	}
+}
+
+// run runs a task and reports its result. The golem only runs the tasks
+// installing skills so far, the others fail right away.
+func (h *heartbeater) run(ctx context.Context, task *protocol.Task) {
+	logger.InfoX(logModule, "running task %s of type %s", task.ID, task.Type)
+
+	var result *protocol.TaskResult
+	switch task.Type {
+	case protocol.TaskTypeInstallSkills:
+		result = h.skills.InstallTask(ctx, h.nodeID, task, h.fetch)
+	default:
+		result = &protocol.TaskResult{
+			TaskID:      task.ID,
+			NodeID:      h.nodeID,
+			Error:       fmt.Sprintf("golem does not run tasks of type %q", task.Type),
+			CompletedAt: time.Now(),
+		}
+	}
+
+	// Report even when the golem is stopping, the task is done either way.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), reportTimeout)
+	defer cancel()
+	if _, err := h.client.ReportResult(ctx, result); err != nil {
+		logger.WarnX(logModule, "failed to report the result of task %s: %s", task.ID, err.Error())
+	}
+}
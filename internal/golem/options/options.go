@@ -0,0 +1,54 @@
+package options
+
+import (
+	"time"
+
+	"github.com/kiosk404/eidolon/internal/pkg/workspace"
+	"github.com/kiosk404/eidolon/pkg/utils/cliflag"
+	"github.com/kiosk404/eidolon/pkg/utils/json"
+)
+
+// Options contains the configuration of a golem. The node identity, the
+// hivemind it joined and its credentials are read from the workspace that
+// 'eidoctl init' and 'eidoctl join' prepared.
+type Options struct {
+	Workspace string `json:"workspace" mapstructure:"workspace"`
+
+	// HeartbeatInterval is the period of the heartbeats that report the load
+	// and skills of the golem and pick up the tasks queued for it.
+	HeartbeatInterval time.Duration `json:"heartbeat-interval" mapstructure:"heartbeat-interval"`
+
+	// HivemindAPI is the REST API skill packages are downloaded from.
+	HivemindAPI string `json:"hivemind-api" mapstructure:"hivemind-api"`
+}
+
+func (o *Options) Flags() (fss cliflag.NamedFlagSets) {
+	fs := fss.FlagSet("golem")
+	fs.StringVar(&o.Workspace, "workspace", o.Workspace, ""+
+		"The workspace directory prepared by 'eidoctl init' and 'eidoctl join'.")
+	fs.DurationVar(&o.HeartbeatInterval, "heartbeat-interval", o.HeartbeatInterval, ""+
+		"The period of the heartbeats sent to the hivemind.")
+	fs.StringVar(&o.HivemindAPI, "hivemind-api", o.HivemindAPI, ""+
+		"The base URL of the hivemind REST API skill packages are downloaded from, like: https://hivemind:11790. "+
+		"Installing skills from the hivemind fails when it is not set.")
+
+	return fss
+}
+
+func NewOptions() *Options {
+	return &Options{
+		Workspace:         workspace.DefaultDir(),
+		HeartbeatInterval: 10 * time.Second,
+	}
+}
+
+func (o *Options) String() string {
+	data, _ := json.Marshal(o)
+
+	return string(data)
+}
+
+// Complete set default Options.
+func (o *Options) Complete() error {
+	return nil
+}
@@ -0,0 +1,14 @@
+package options
+
+import "fmt"
+
+func (o *Options) Validate() []error {
+	var errs []error
+	if o.Workspace == "" {
+		errs = append(errs, fmt.Errorf("--workspace must not be empty"))
+	}
+	if o.HeartbeatInterval <= 0 {
+		errs = append(errs, fmt.Errorf("--heartbeat-interval must be positive"))
+	}
+	return errs
+}
@@ -0,0 +1,148 @@
+package golem
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/kiosk404/eidolon/internal/golem/options"
+	"github.com/kiosk404/eidolon/internal/golem/skill"
+	"github.com/kiosk404/eidolon/internal/pkg/client/hivemind"
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+	"github.com/kiosk404/eidolon/internal/pkg/workspace"
+	"github.com/kiosk404/eidolon/pkg/logger"
+	"github.com/kiosk404/eidolon/pkg/utils/certutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Run starts the golem of the workspace and blocks until it receives SIGINT
+// or SIGTERM. The PID file tells eidoctl which process to notify while it runs.
+func Run(opts *options.Options, ws *workspace.Workspace, cfg *workspace.Config) error {
+	m, err := ws.LoadMembership()
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("workspace %s has not joined a hivemind, run 'eidoctl join' first", ws.Dir)
+	} else if err != nil {
+		return err
+	}
+
+	if pid, err := ws.GolemPID(); err != nil {
+		logger.Warn("Ignoring the PID file: %s", err.Error())
+	} else if pid != 0 && pid != os.Getpid() {
+		return fmt.Errorf("a golem already runs on workspace %s with pid %d", ws.Dir, pid)
+	}
+	// eidoctl sends SIGHUP as soon as the PID file exists, which would kill
+	// the golem until the skill manager handles it. The skills are scanned
+	// once the manager is up anyway.
+	signal.Ignore(syscall.SIGHUP)
+	if err := ws.WritePID(os.Getpid()); err != nil {
+		return fmt.Errorf("write PID file: %w", err)
+	}
+	defer func() {
+		if err := ws.RemovePID(); err != nil {
+			logger.Warn("Remove PID file failed: %s", err.Error())
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	tlsConfig, err := clientTLSConfig(ctx, m)
+	if err != nil {
+		return err
+	}
+	conn, err := dial(m.HivemindAddr, tlsConfig)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	fetch, err := fetcher(opts.HivemindAPI, tlsConfig)
+	if err != nil {
+		return err
+	}
+
+	skills := skill.NewManager(cfg.SkillsDir)
+	if err := skills.Refresh(); err != nil {
+		logger.Warn("Scan skills in %s failed: %s", cfg.SkillsDir, err.Error())
+	}
+	go skills.Run(ctx)
+
+	h := &heartbeater{
+		nodeID:   m.NodeID,
+		client:   protocol.NewNodeClient(conn),
+		skills:   skills,
+		fetch:    fetch,
+		interval: opts.HeartbeatInterval,
+	}
+
+	logger.Info("Golem %s started on workspace %s with pid %d, reporting to %s",
+		m.NodeID, ws.Dir, os.Getpid(), m.HivemindAddr)
+	h.Run(ctx)
+	logger.Info("Golem %s stopped", m.NodeID)
+
+	return nil
+}
+
+// clientTLSConfig returns the TLS configuration of the credentials written by
+// 'eidoctl join', nil when the node joined without TLS. Rotated credentials
+// are picked up until ctx is done.
+func clientTLSConfig(ctx context.Context, m *workspace.Membership) (*tls.Config, error) {
+	if m.CAFile == "" && m.CertFile == "" {
+		return nil, nil
+	}
+
+	reloader, err := certutil.NewReloader(m.CertFile, m.KeyFile, m.CAFile)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		if err := reloader.Run(ctx); err != nil {
+			logger.Error("Watch the node credentials failed, they will not be reloaded: %s", err.Error())
+		}
+	}()
+	host, _, _ := net.SplitHostPort(m.HivemindAddr)
+
+	return certutil.ClientConfig(reloader, host), nil
+}
+
+// dial connects lazily to the gRPC endpoint of the hivemind, so that the
+// golem keeps heartbeating until the hivemind is reachable.
+func dial(addr string, tlsConfig *tls.Config) (*grpc.ClientConn, error) {
+	creds := insecure.NewCredentials()
+	if tlsConfig != nil {
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("dial hivemind %s: %w", addr, err)
+	}
+
+	return conn, nil
+}
+
+// fetcher returns the Fetcher downloading skill packages from the REST API at
+// addr, or one failing every download when addr is empty.
+func fetcher(addr string, tlsConfig *tls.Config) (skill.Fetcher, error) {
+	if addr == "" {
+		return func(context.Context, protocol.SkillPackage) (io.ReadCloser, error) {
+			return nil, fmt.Errorf("the hivemind API is not configured, set --hivemind-api")
+		}, nil
+	}
+
+	client, err := hivemind.NewForConfig(&hivemind.Config{Address: addr, TLSConfig: tlsConfig})
+	if err != nil {
+		return nil, err
+	}
+
+	return skill.HivemindFetcher(client), nil
+}
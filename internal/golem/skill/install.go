@@ -0,0 +1,164 @@
+package skill
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kiosk404/eidolon/internal/pkg/client/hivemind"
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+	"github.com/kiosk404/eidolon/internal/pkg/skill"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// Fetcher downloads the archive of a package of the skill registry. The
+// caller closes the returned reader.
+type Fetcher func(ctx context.Context, pkg protocol.SkillPackage) (io.ReadCloser, error)
+
+// HivemindFetcher returns a Fetcher that downloads packages from the hivemind
+// c talks to.
+func HivemindFetcher(c *hivemind.Client) Fetcher {
+	return func(ctx context.Context, pkg protocol.SkillPackage) (io.ReadCloser, error) {
+		return c.Skills().Download(ctx, pkg.ID, pkg.Version)
+	}
+}
+
+// InstallTask runs a protocol.TaskTypeInstallSkills task: every package is
+// downloaded with fetch, verified against its digest and installed, then the
+// skills are refreshed so that the next heartbeat reports them. A package is
+// only activated when the skill has no active version or the active one does
+// not satisfy the constraints of the package, so that the version the
+// operator chose is kept when it will do. The returned result is to be reported to the hivemind.
+func (m *Manager) InstallTask(ctx context.Context, nodeID string, task *protocol.Task, fetch Fetcher) *protocol.TaskResult {
+	result := &protocol.TaskResult{TaskID: task.ID, NodeID: nodeID}
+
+	payload, err := protocol.ParseInstallSkillsPayload(task.Payload)
+	if err != nil {
+		result.Error = err.Error()
+		result.CompletedAt = time.Now()
+		return result
+	}
+
+	store := skill.NewStore(m.dir)
+	var (
+		installed []string
+		errs      []string
+	)
+	for _, pkg := range payload.Packages {
+		if err := installPackage(ctx, store, pkg, fetch); err != nil {
+			logger.WarnX(logModule, "failed to install skill %s@%s: %s", pkg.ID, pkg.Version, err.Error())
+			errs = append(errs, fmt.Sprintf("%s@%s: %s", pkg.ID, pkg.Version, err.Error()))
+			continue
+		}
+		logger.InfoX(logModule, "skill %s@%s installed from the hivemind", pkg.ID, pkg.Version)
+		installed = append(installed, pkg.ID+"@"+pkg.Version)
+	}
+	if err := m.Refresh(); err != nil {
+		errs = append(errs, err.Error())
+	}
+
+	result.Success = len(errs) == 0
+	result.Error = strings.Join(errs, "; ")
+	result.Output = map[string]interface{}{"installed": installed}
+	result.CompletedAt = time.Now()
+
+	return result
+}
+
+// installPackage installs pkg in store, and activates it unless the active
+// version of its skill satisfies the constraints of pkg.
+func installPackage(ctx context.Context, store *skill.Store, pkg protocol.SkillPackage, fetch Fetcher) error {
+	constraints, err := skill.ParseVersionConstraints(pkg.Constraints)
+	if err != nil {
+		return err
+	}
+	active, err := store.Active(pkg.ID)
+	if err != nil {
+		return err
+	}
+	if active == pkg.Version {
+		return nil
+	}
+	activate := func() error {
+		if active != "" && constraints.Allows(active) {
+			logger.InfoX(logModule, "skill %s keeps its active version %s, which satisfies %q",
+				pkg.ID, active, pkg.Constraints)
+			return nil
+		}
+		return store.Activate(pkg.ID, pkg.Version)
+	}
+	versions, err := store.Versions(pkg.ID)
+	if err != nil {
+		return err
+	}
+	for _, v := range versions {
+		if v.Version == pkg.Version {
+			return activate()
+		}
+	}
+
+	tmp, err := os.MkdirTemp("", "eidolon-skill-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	archive := filepath.Join(tmp, "package")
+	if err := download(ctx, pkg, fetch, archive); err != nil {
+		return err
+	}
+	got, err := skill.FileDigest(archive)
+	if err != nil {
+		return err
+	}
+	if err := skill.VerifyDigest(got, pkg.Digest); err != nil {
+		return err
+	}
+
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := skill.Extract(f, filepath.Join(tmp, "pkg")); err != nil {
+		return err
+	}
+	root, err := skill.FindRoot(filepath.Join(tmp, "pkg"))
+	if err != nil {
+		return err
+	}
+
+	v, err := store.Install(root, "hivemind:"+pkg.Path, false)
+	if err != nil {
+		return err
+	}
+	if v.ID != pkg.ID || v.Version != pkg.Version {
+		return fmt.Errorf("package holds skill %s@%s", v.ID, v.Version)
+	}
+
+	return activate()
+}
+
+// download writes the archive of pkg to path.
+func download(ctx context.Context, pkg protocol.SkillPackage, fetch Fetcher, path string) error {
+	rc, err := fetch(ctx, pkg)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, rc); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
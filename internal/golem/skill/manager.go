@@ -0,0 +1,161 @@
+// Package skill keeps track of the skills installed on a golem.
+package skill
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+	"github.com/kiosk404/eidolon/internal/pkg/skill"
+	"github.com/kiosk404/eidolon/pkg/errorx"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+const logModule = "skill"
+
+// Manager holds the skills found in the skills directory of a golem. It only
+// sees new or removed packages after Refresh.
+type Manager struct {
+	dir string
+
+	mu      sync.RWMutex
+	skills  map[string]*skill.Skill
+	changed bool
+}
+
+// NewManager creates a Manager of the skills in dir. Call Refresh to load them.
+func NewManager(dir string) *Manager {
+	return &Manager{dir: dir, skills: make(map[string]*skill.Skill)}
+}
+
+// Dir returns the skills directory.
+func (m *Manager) Dir() string {
+	return m.dir
+}
+
+// Refresh scans the skills directory again. Packages that fail to load are
+// logged and left out; the error is only returned when the directory itself
+// cannot be read, in which case the known skills are kept.
+func (m *Manager) Refresh() error {
+	skills, err := skill.Scan(m.dir)
+	var agg errorx.Aggregate
+	if errors.As(err, &agg) {
+		for _, e := range agg.Errors() {
+			logger.WarnX(logModule, "skipping invalid skill package: %s", e.Error())
+		}
+	} else if err != nil {
+		return err
+	}
+
+	found := make(map[string]*skill.Skill, len(skills))
+	for _, s := range skills {
+		found[s.ID] = s
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !sameSkills(m.skills, found) {
+		m.changed = true
+		logger.InfoX(logModule, "%d skills installed in %s", len(found), m.dir)
+	}
+	m.skills = found
+
+	return nil
+}
+
+// Run refreshes the skills each time the process receives SIGHUP, which
+// eidoctl sends after installing or removing skills, until ctx is done.
+func (m *Manager) Run(ctx context.Context) {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGHUP)
+	defer signal.Stop(sig)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sig:
+			if err := m.Refresh(); err != nil {
+				logger.WarnX(logModule, "failed to refresh skills: %s", err.Error())
+			}
+		}
+	}
+}
+
+// Get returns the skill with the given ID, nil if it is not installed.
+func (m *Manager) Get(id string) *skill.Skill {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.skills[id]
+}
+
+// List returns the installed skills sorted by ID.
+func (m *Manager) List() []*skill.Skill {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	list := make([]*skill.Skill, 0, len(m.skills))
+	for _, s := range m.skills {
+		list = append(list, s)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+
+	return list
+}
+
+// InstalledSkills returns the skills to report in the registration of the golem.
+func (m *Manager) InstalledSkills() []protocol.SkillInfo {
+	list := m.List()
+	infos := make([]protocol.SkillInfo, len(list))
+	for i, s := range list {
+		infos[i] = s.Info()
+	}
+
+	return infos
+}
+
+// Heartbeat returns the heartbeat reporting load. It carries the installed
+// skills when they changed since the previous heartbeat.
+func (m *Manager) Heartbeat(nodeID string, load protocol.NodeLoadInfo) *protocol.Heartbeat {
+	hb := &protocol.Heartbeat{NodeID: nodeID, Load: load}
+
+	m.mu.Lock()
+	changed := m.changed
+	m.changed = false
+	m.mu.Unlock()
+
+	if changed {
+		hb.InstalledSkills = m.InstalledSkills()
+	}
+
+	return hb
+}
+
+// MarkChanged makes the next heartbeat carry the installed skills, like after
+// the heartbeat that carried them failed.
+func (m *Manager) MarkChanged() {
+	m.mu.Lock()
+	m.changed = true
+	m.mu.Unlock()
+}
+
+// sameSkills reports whether a and b hold the same versions of the same skills.
+func sameSkills(a, b map[string]*skill.Skill) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for id, s := range a {
+		if o, ok := b[id]; !ok || o.Version != s.Version || o.Dir != s.Dir {
+			return false
+		}
+	}
+
+	return true
+}
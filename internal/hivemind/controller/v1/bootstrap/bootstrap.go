@@ -0,0 +1,51 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+
+	srvbootstrap "github.com/kiosk404/eidolon/internal/hivemind/service/bootstrap"
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+	"github.com/kiosk404/eidolon/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BootstrapController serves the Bootstrap gRPC service.
+type BootstrapController struct {
+	bootstrap *srvbootstrap.Service
+}
+
+var _ protocol.BootstrapServer = &BootstrapController{}
+
+// NewBootstrapController creates a Bootstrap gRPC handler.
+func NewBootstrapController(svc *srvbootstrap.Service) *BootstrapController {
+	return &BootstrapController{bootstrap: svc}
+}
+
+// Join exchanges a bootstrap token for a node identity.
+func (b *BootstrapController) Join(ctx context.Context, req *protocol.JoinRequest) (*protocol.JoinResponse, error) {
+	logger.CtxInfo(ctx, "bootstrap join function called.")
+
+	resp, err := b.bootstrap.Join(ctx, req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return resp, nil
+}
+
+// toStatus maps bootstrap sentinel errors onto gRPC status codes. The reason a
+// token was rejected is only logged, never returned to the caller.
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, srvbootstrap.ErrTokenInvalid):
+		return status.Error(codes.Unauthenticated, srvbootstrap.ErrTokenInvalid.Error())
+	case errors.Is(err, srvbootstrap.ErrInvalidJoinRequest):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, srvbootstrap.ErrJoinDisabled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,65 @@
+package golem
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kiosk404/eidolon/internal/hivemind/service/registry"
+	"github.com/kiosk404/eidolon/internal/hivemind/service/scheduler"
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+	genericapiserver "github.com/kiosk404/eidolon/internal/pkg/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GolemController serves the Node gRPC service joined golems report to.
+type GolemController struct {
+	registry  registry.Registry
+	outbox    *registry.Outbox
+	scheduler scheduler.Scheduler
+}
+
+var _ protocol.NodeServer = &GolemController{}
+
+// NewGolemController creates a Node gRPC handler.
+func NewGolemController(reg registry.Registry, outbox *registry.Outbox, sched scheduler.Scheduler) *GolemController {
+	return &GolemController{registry: reg, outbox: outbox, scheduler: sched}
+}
+
+// Heartbeat records the load of a golem and hands it the tasks queued for it.
+func (g *GolemController) Heartbeat(ctx context.Context, req *protocol.Heartbeat) (*protocol.HeartbeatResponse, error) {
+	if err := authorize(ctx, req.NodeID); err != nil {
+		return nil, err
+	}
+	if err := g.registry.Heartbeat(ctx, req); err != nil {
+		if errors.Is(err, registry.ErrNodeNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &protocol.HeartbeatResponse{Tasks: g.outbox.Drain(req.NodeID)}, nil
+}
+
+// ReportResult records the outcome of a task the golem ran.
+func (g *GolemController) ReportResult(ctx context.Context, req *protocol.TaskResult) (*protocol.ReportResultResponse, error) {
+	if err := authorize(ctx, req.NodeID); err != nil {
+		return nil, err
+	}
+	g.scheduler.ReportResult(ctx, req)
+
+	return &protocol.ReportResultResponse{}, nil
+}
+
+// authorize makes sure a golem presenting a client certificate only speaks for
+// the node the certificate was issued to.
+func authorize(ctx context.Context, nodeID string) error {
+	if nodeID == "" {
+		return status.Error(codes.InvalidArgument, "node ID is required")
+	}
+	if cn, ok := genericapiserver.PeerCommonName(ctx); ok && cn != nodeID {
+		return status.Errorf(codes.PermissionDenied, "the client certificate of %s cannot report for %s", cn, nodeID)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package node
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// Cordon marks a node as unschedulable.
+func (n *NodeController) Cordon(c *gin.Context) {
+	logger.CtxInfo(c, "node cordon function called.")
+
+	n.setCordoned(c, true)
+}
+
+// Uncordon marks a node as schedulable again and aborts a drain in progress.
+func (n *NodeController) Uncordon(c *gin.Context) {
+	logger.CtxInfo(c, "node uncordon function called.")
+
+	n.setCordoned(c, false)
+}
+
+func (n *NodeController) setCordoned(c *gin.Context, cordoned bool) {
+	id := c.Param("id")
+	profile, err := n.drainer.Cordon(c, id, cordoned)
+	if err != nil {
+		core.WriteResponse(c, withCode(err, id), nil)
+		return
+	}
+
+	core.WriteResponse(c, nil, ToNode(profile))
+}
@@ -0,0 +1,35 @@
+package node
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/eidolon/internal/pkg/code"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/pkg/errorx"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// deleteQuery is the query string accepted by Delete.
+type deleteQuery struct {
+	// Force cancels the tasks still assigned to or running on the node.
+	Force bool `form:"force"`
+}
+
+// Delete deregisters a node without waiting for its tasks. Unless force is set
+// a node with running tasks is refused, drain it instead.
+func (n *NodeController) Delete(c *gin.Context) {
+	logger.CtxInfo(c, "node delete function called.")
+
+	var q deleteQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		core.WriteResponse(c, errorx.WithCode(code.ErrBind, "%s", err.Error()), nil)
+		return
+	}
+
+	id := c.Param("id")
+	if err := n.drainer.Delete(c, id, q.Force); err != nil {
+		core.WriteResponse(c, withCode(err, id), nil)
+		return
+	}
+
+	core.WriteResponse(c, nil, nil)
+}
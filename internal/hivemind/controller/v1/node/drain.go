@@ -0,0 +1,50 @@
+package node
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/eidolon/internal/hivemind/service/registry"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/internal/pkg/code"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/pkg/errorx"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// Drain cordons a node and deregisters it in the background once its running
+// tasks have finished. The response is the cordoned node.
+func (n *NodeController) Drain(c *gin.Context) {
+	logger.CtxInfo(c, "node drain function called.")
+
+	var r v1.DrainNodeRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&r); err != nil {
+			core.WriteResponse(c, errorx.WithCode(code.ErrBind, "%s", err.Error()), nil)
+			return
+		}
+	}
+
+	opts := registry.DrainOptions{Force: r.Force}
+	if r.Timeout != "" {
+		d, err := time.ParseDuration(r.Timeout)
+		if err != nil || d < 0 {
+			core.WriteResponse(c, errorx.WithCode(code.ErrValidation, "invalid timeout %q", r.Timeout), nil)
+			return
+		}
+		opts.Timeout = d
+	}
+	if opts.Force && opts.Timeout == 0 {
+		core.WriteResponse(c, errorx.WithCode(code.ErrValidation, "force requires a timeout"), nil)
+		return
+	}
+
+	id := c.Param("id")
+	profile, err := n.drainer.Drain(c, id, opts)
+	if err != nil {
+		core.WriteResponse(c, withCode(err, id), nil)
+		return
+	}
+
+	core.WriteResponse(c, nil, ToNode(profile))
+}
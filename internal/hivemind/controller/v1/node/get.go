@@ -0,0 +1,21 @@
+package node
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// Get returns the profile of a single node.
+func (n *NodeController) Get(c *gin.Context) {
+	logger.CtxInfo(c, "node get function called.")
+
+	id := c.Param("id")
+	profile, err := n.registry.GetProfile(c, id)
+	if err != nil {
+		core.WriteResponse(c, withCode(err, id), nil)
+		return
+	}
+
+	core.WriteResponse(c, nil, ToNode(profile))
+}
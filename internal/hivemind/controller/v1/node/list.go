@@ -0,0 +1,62 @@
+package node
+
+import (
+	"github.com/gin-gonic/gin"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/internal/pkg/code"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+	"github.com/kiosk404/eidolon/pkg/errorx"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// listQuery is the query string accepted by List.
+type listQuery struct {
+	v1.ListOptions
+
+	Status   string `form:"status"`
+	Cordoned *bool  `form:"cordoned"`
+}
+
+// List returns registered nodes, including offline ones, sorted by ID.
+func (n *NodeController) List(c *gin.Context) {
+	logger.CtxInfo(c, "node list function called.")
+
+	var q listQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		core.WriteResponse(c, errorx.WithCode(code.ErrBind, "%s", err.Error()), nil)
+		return
+	}
+	q.Complete()
+
+	profiles, err := n.registry.ListAll(c)
+	if err != nil {
+		core.WriteResponse(c, withCode(err, ""), nil)
+		return
+	}
+
+	items := make([]*v1.Node, 0, len(profiles))
+	for i := range profiles {
+		p := &profiles[i]
+		if q.Status != "" && p.NodeInfo.Status != protocol.NodeStatus(q.Status) {
+			continue
+		}
+		if q.Cordoned != nil && p.Cordoned != *q.Cordoned {
+			continue
+		}
+		items = append(items, ToNode(p))
+	}
+
+	list := &v1.NodeList{ListMeta: v1.ListMeta{TotalCount: len(items)}}
+	if q.Offset < len(items) {
+		items = items[q.Offset:]
+		if len(items) > q.Limit {
+			items = items[:q.Limit]
+		}
+		list.Items = items
+	} else {
+		list.Items = []*v1.Node{}
+	}
+
+	core.WriteResponse(c, nil, list)
+}
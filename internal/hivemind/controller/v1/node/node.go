@@ -0,0 +1,61 @@
+package node
+
+import (
+	"errors"
+
+	"github.com/kiosk404/eidolon/internal/hivemind/service/registry"
+	"github.com/kiosk404/eidolon/internal/hivemind/service/scheduler"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/internal/pkg/code"
+	"github.com/kiosk404/eidolon/pkg/errorx"
+)
+
+// NodeController handles requests for the node resource.
+type NodeController struct {
+	registry registry.Registry
+	drainer  *registry.Drainer
+}
+
+// NewNodeController creates a node handler.
+func NewNodeController(reg registry.Registry, drainer *registry.Drainer) *NodeController {
+	return &NodeController{registry: reg, drainer: drainer}
+}
+
+// ToNode converts a Golem profile into its API representation.
+func ToNode(p *scheduler.GolemProfile) *v1.Node {
+	n := &v1.Node{
+		NodeInfo:          p.NodeInfo,
+		Load:              p.Load,
+		SupportedFeatures: p.SupportedFeatures,
+		Tags:              p.Tags,
+		HealthScore:       p.HealthScore,
+		Cordoned:          p.Cordoned,
+		Draining:          p.Draining,
+		LastHeartbeat:     p.Load.ReportedAt,
+		LastUpdated:       p.LastUpdated,
+	}
+	for _, sk := range p.InstalledSkills {
+		n.InstalledSkills = append(n.InstalledSkills, v1.Skill{
+			ID:           sk.ID,
+			Name:         sk.Name,
+			Version:      sk.Version,
+			Capabilities: sk.Capabilities,
+		})
+	}
+
+	return n
+}
+
+// withCode maps registry sentinel errors onto API error codes.
+func withCode(err error, nodeID string) error {
+	switch {
+	case errors.Is(err, registry.ErrNodeNotFound):
+		return errorx.WrapC(err, code.ErrNodeNotFound, "node %s not found", nodeID)
+	case errors.Is(err, registry.ErrNodeDraining):
+		return errorx.WrapC(err, code.ErrNodeDraining, "node %s is already draining", nodeID)
+	case errors.Is(err, registry.ErrNodeBusy):
+		return errorx.WrapC(err, code.ErrNodeBusy, "node %s still has running tasks, drain it or delete it with force", nodeID)
+	default:
+		return errorx.WrapC(err, code.ErrUnknown, "%s", err.Error())
+	}
+}
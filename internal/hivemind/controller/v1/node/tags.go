@@ -0,0 +1,39 @@
+package node
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/eidolon/internal/hivemind/service/scheduler"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/internal/pkg/code"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/pkg/errorx"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// UpdateTags replaces the tags of a node.
+func (n *NodeController) UpdateTags(c *gin.Context) {
+	logger.CtxInfo(c, "node update tags function called.")
+
+	var r v1.UpdateNodeTagsRequest
+	if err := c.ShouldBindJSON(&r); err != nil {
+		core.WriteResponse(c, errorx.WithCode(code.ErrBind, "%s", err.Error()), nil)
+		return
+	}
+	for k := range r.Tags {
+		if k == "" {
+			core.WriteResponse(c, errorx.WithCode(code.ErrValidation, "tag keys must not be empty"), nil)
+			return
+		}
+	}
+
+	id := c.Param("id")
+	profile, err := n.registry.Update(c, id, func(p *scheduler.GolemProfile) {
+		p.Tags = r.Tags
+	})
+	if err != nil {
+		core.WriteResponse(c, withCode(err, id), nil)
+		return
+	}
+
+	core.WriteResponse(c, nil, ToNode(profile))
+}
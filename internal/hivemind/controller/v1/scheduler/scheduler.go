@@ -0,0 +1,15 @@
+package scheduler
+
+import (
+	srvscheduler "github.com/kiosk404/eidolon/internal/hivemind/service/scheduler"
+)
+
+// SchedulerController exposes scheduler statistics and dry-run selection.
+type SchedulerController struct {
+	scheduler srvscheduler.Scheduler
+}
+
+// NewSchedulerController creates a scheduler handler.
+func NewSchedulerController(s srvscheduler.Scheduler) *SchedulerController {
+	return &SchedulerController{scheduler: s}
+}
@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/eidolon/internal/hivemind/controller/v1/task"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/internal/pkg/code"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/pkg/errorx"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// simulatedTaskID identifies the hypothetical task in a simulation.
+const simulatedTaskID = "simulation"
+
+// Simulate runs node selection for a hypothetical task against the current
+// node profiles without dispatching it, returning every node's score.
+func (s *SchedulerController) Simulate(c *gin.Context) {
+	logger.CtxInfo(c, "scheduler simulate function called.")
+
+	var r v1.SubmitTaskRequest
+	if err := c.ShouldBindJSON(&r); err != nil {
+		core.WriteResponse(c, errorx.WithCode(code.ErrBind, "%s", err.Error()), nil)
+		return
+	}
+
+	req, err := task.ToScheduleRequest(simulatedTaskID, &r)
+	if err != nil {
+		core.WriteResponse(c, errorx.WithCode(code.ErrValidation, "%s", err.Error()), nil)
+		return
+	}
+
+	result, err := s.scheduler.Simulate(c, req)
+	if err != nil {
+		core.WriteResponse(c, errorx.WrapC(err, code.ErrUnknown, "%s", err.Error()), nil)
+		return
+	}
+
+	out := &v1.SimulationResult{
+		Decision: task.ToScheduleDecision(result.Decision),
+		Scores:   make([]v1.NodeScore, 0, len(result.Scores)),
+	}
+	for _, ns := range result.Scores {
+		out.Scores = append(out.Scores, task.ToNodeScore(ns))
+	}
+	if result.Err != nil {
+		out.Error = result.Err.Error()
+	}
+
+	core.WriteResponse(c, nil, out)
+}
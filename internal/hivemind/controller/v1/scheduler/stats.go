@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// Stats returns aggregate and per-node scheduling statistics.
+func (s *SchedulerController) Stats(c *gin.Context) {
+	logger.CtxInfo(c, "scheduler stats function called.")
+
+	st := s.scheduler.Stats()
+	out := &v1.SchedulerStats{
+		TotalSubmitted:       st.TotalSubmitted,
+		TotalCompleted:       st.TotalCompleted,
+		TotalFailed:          st.TotalFailed,
+		TotalCancelled:       st.TotalCancelled,
+		TotalTimedOut:        st.TotalTimedOut,
+		CurrentQueued:        st.CurrentQueued,
+		CurrentRunning:       st.CurrentRunning,
+		AverageLatency:       st.AverageLatency,
+		AverageExecutionTime: st.AverageExecutionTime,
+		Nodes:                make([]v1.NodeSchedulerStats, 0, len(st.NodeStats)),
+		CollectedAt:          st.CollectedAt,
+
+		SkillInstalls:           st.TotalSkillInstalls,
+		SkillInstallFailures:    st.TotalSkillInstallFailures,
+		AverageSkillInstallTime: st.AverageSkillInstallTime,
+	}
+	for _, ns := range st.NodeStats {
+		out.Nodes = append(out.Nodes, v1.NodeSchedulerStats{
+			NodeID:               ns.NodeID,
+			TasksAssigned:        ns.TasksAssigned,
+			TasksCompleted:       ns.TasksCompleted,
+			TasksFailed:          ns.TasksFailed,
+			AverageExecutionTime: ns.AverageExecutionTime,
+			LastAssignedAt:       ns.LastAssignedAt,
+			SkillInstalls:        ns.SkillInstalls,
+			SkillInstallFailures: ns.SkillInstallFailures,
+		})
+	}
+	sort.Slice(out.Nodes, func(i, j int) bool {
+		return out.Nodes[i].NodeID < out.Nodes[j].NodeID
+	})
+
+	core.WriteResponse(c, nil, out)
+}
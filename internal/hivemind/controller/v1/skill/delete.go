@@ -0,0 +1,25 @@
+package skill
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// Delete removes a version of a skill from the registry. Golems that
+// installed it keep their copy.
+func (s *SkillController) Delete(c *gin.Context) {
+	logger.CtxInfo(c, "skill delete function called.")
+
+	if err := s.enabled(); err != nil {
+		core.WriteResponse(c, err, nil)
+		return
+	}
+
+	if err := s.registry.Delete(c, c.Param("id"), c.Param("version")); err != nil {
+		core.WriteResponse(c, withCode(err), nil)
+		return
+	}
+
+	core.WriteResponse(c, nil, nil)
+}
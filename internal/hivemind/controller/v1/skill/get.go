@@ -0,0 +1,50 @@
+package skill
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// Get returns a version of a skill.
+func (s *SkillController) Get(c *gin.Context) {
+	logger.CtxInfo(c, "skill get function called.")
+
+	if err := s.enabled(); err != nil {
+		core.WriteResponse(c, err, nil)
+		return
+	}
+
+	pkg, err := s.registry.Get(c, c.Param("id"), c.Param("version"))
+	if err != nil {
+		core.WriteResponse(c, withCode(err), nil)
+		return
+	}
+
+	core.WriteResponse(c, nil, toSkillPackage(pkg))
+}
+
+// Download sends the archive of a version of a skill. Its digest is sent in
+// the ETag header so that golems can verify what they received.
+func (s *SkillController) Download(c *gin.Context) {
+	logger.CtxInfo(c, "skill download function called.")
+
+	if err := s.enabled(); err != nil {
+		core.WriteResponse(c, err, nil)
+		return
+	}
+
+	pkg, rc, err := s.registry.Open(c, c.Param("id"), c.Param("version"))
+	if err != nil {
+		core.WriteResponse(c, withCode(err), nil)
+		return
+	}
+	defer rc.Close()
+
+	c.DataFromReader(http.StatusOK, pkg.Size, "application/octet-stream", rc, map[string]string{
+		"ETag": strconv.Quote(pkg.Digest),
+	})
+}
@@ -0,0 +1,70 @@
+package skill
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/eidolon/internal/hivemind/service/skillregistry"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/internal/pkg/code"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/pkg/errorx"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// List returns every version of every skill, sorted by ID then newest first.
+func (s *SkillController) List(c *gin.Context) {
+	logger.CtxInfo(c, "skill list function called.")
+
+	if err := s.enabled(); err != nil {
+		core.WriteResponse(c, err, nil)
+		return
+	}
+
+	pkgs, err := s.registry.List(c)
+	if err != nil {
+		core.WriteResponse(c, withCode(err), nil)
+		return
+	}
+
+	writeList(c, pkgs)
+}
+
+// Versions returns the versions of a skill, newest first.
+func (s *SkillController) Versions(c *gin.Context) {
+	logger.CtxInfo(c, "skill versions function called.")
+
+	if err := s.enabled(); err != nil {
+		core.WriteResponse(c, err, nil)
+		return
+	}
+
+	pkgs, err := s.registry.Versions(c, c.Param("id"))
+	if err != nil {
+		core.WriteResponse(c, withCode(err), nil)
+		return
+	}
+
+	writeList(c, pkgs)
+}
+
+// writeList writes the page of pkgs the query asks for.
+func writeList(c *gin.Context, pkgs []*skillregistry.Package) {
+	var q v1.ListOptions
+	if err := c.ShouldBindQuery(&q); err != nil {
+		core.WriteResponse(c, errorx.WithCode(code.ErrBind, "%s", err.Error()), nil)
+		return
+	}
+	q.Complete()
+
+	list := &v1.SkillPackageList{ListMeta: v1.ListMeta{TotalCount: len(pkgs)}, Items: []*v1.SkillPackage{}}
+	if q.Offset < len(pkgs) {
+		pkgs = pkgs[q.Offset:]
+		if len(pkgs) > q.Limit {
+			pkgs = pkgs[:q.Limit]
+		}
+		for _, pkg := range pkgs {
+			list.Items = append(list.Items, toSkillPackage(pkg))
+		}
+	}
+
+	core.WriteResponse(c, nil, list)
+}
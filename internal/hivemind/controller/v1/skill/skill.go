@@ -0,0 +1,60 @@
+package skill
+
+import (
+	"errors"
+
+	"github.com/kiosk404/eidolon/internal/hivemind/service/skillregistry"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/internal/pkg/code"
+	"github.com/kiosk404/eidolon/pkg/errorx"
+)
+
+// SkillController handles requests for the skill package resource.
+type SkillController struct {
+	// registry is nil when the skill registry is not enabled.
+	registry *skillregistry.Registry
+}
+
+// NewSkillController creates a skill handler. reg may be nil, every request
+// then fails with ErrSkillRegistryDisabled.
+func NewSkillController(reg *skillregistry.Registry) *SkillController {
+	return &SkillController{registry: reg}
+}
+
+// enabled returns the error of the requests made while the registry is disabled.
+func (s *SkillController) enabled() error {
+	if s.registry == nil {
+		return errorx.WithCode(code.ErrSkillRegistryDisabled, "the skill registry is not enabled, see --skill-registry.dir")
+	}
+
+	return nil
+}
+
+// toSkillPackage converts a stored package into its API representation.
+func toSkillPackage(pkg *skillregistry.Package) *v1.SkillPackage {
+	return &v1.SkillPackage{
+		ID:               pkg.ID,
+		Name:             pkg.Name,
+		Version:          pkg.Version,
+		Description:      pkg.Description,
+		Capabilities:     pkg.Capabilities,
+		RequiredFeatures: pkg.RequiredFeatures,
+		Digest:           pkg.Digest,
+		Size:             pkg.Size,
+		UploadedAt:       pkg.UploadedAt,
+	}
+}
+
+// withCode maps skill registry sentinel errors onto API error codes.
+func withCode(err error) error {
+	switch {
+	case errors.Is(err, skillregistry.ErrSkillNotFound):
+		return errorx.WrapC(err, code.ErrSkillNotFound, "%s", err.Error())
+	case errors.Is(err, skillregistry.ErrVersionExists):
+		return errorx.WrapC(err, code.ErrSkillAlreadyExist, "%s", err.Error())
+	case errors.Is(err, skillregistry.ErrInvalidPackage):
+		return errorx.WrapC(err, code.ErrSkillPackageInvalid, "%s", err.Error())
+	default:
+		return errorx.WrapC(err, code.ErrUnknown, "%s", err.Error())
+	}
+}
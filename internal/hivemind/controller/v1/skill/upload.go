@@ -0,0 +1,27 @@
+package skill
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// Upload stores the skill package archive sent as the request body, a tar
+// file optionally compressed with gzip. Uploading a stored version again with
+// the same content succeeds.
+func (s *SkillController) Upload(c *gin.Context) {
+	logger.CtxInfo(c, "skill upload function called.")
+
+	if err := s.enabled(); err != nil {
+		core.WriteResponse(c, err, nil)
+		return
+	}
+
+	pkg, _, err := s.registry.Upload(c, c.Request.Body)
+	if err != nil {
+		core.WriteResponse(c, withCode(err), nil)
+		return
+	}
+
+	core.WriteResponse(c, nil, toSkillPackage(pkg))
+}
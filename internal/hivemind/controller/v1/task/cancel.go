@@ -0,0 +1,26 @@
+package task
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// Cancel aborts a pending or running task and returns its final state.
+func (t *TaskController) Cancel(c *gin.Context) {
+	logger.CtxInfo(c, "task cancel function called.")
+
+	id := c.Param("id")
+	if err := t.scheduler.Cancel(c, id); err != nil {
+		core.WriteResponse(c, withCode(err, id), nil)
+		return
+	}
+
+	info, err := t.scheduler.Get(c, id)
+	if err != nil {
+		core.WriteResponse(c, withCode(err, id), nil)
+		return
+	}
+
+	core.WriteResponse(c, nil, toTask(info))
+}
@@ -0,0 +1,51 @@
+package task
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/eidolon/internal/hivemind/service/scheduler"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/internal/pkg/code"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/pkg/errorx"
+	"github.com/kiosk404/eidolon/pkg/logger"
+	"github.com/kiosk404/eidolon/pkg/utils/idutil"
+)
+
+// Create submits a new task to the scheduler. A task that cannot be dispatched
+// immediately is queued and returned in the pending state.
+func (t *TaskController) Create(c *gin.Context) {
+	logger.CtxInfo(c, "task create function called.")
+
+	var r v1.SubmitTaskRequest
+	if err := c.ShouldBindJSON(&r); err != nil {
+		core.WriteResponse(c, errorx.WithCode(code.ErrBind, "%s", err.Error()), nil)
+		return
+	}
+
+	if r.Type == "" {
+		core.WriteResponse(c, errorx.WithCode(code.ErrValidation, "type is required"), nil)
+		return
+	}
+
+	id := idutil.NewID("task")
+	req, err := ToScheduleRequest(id, &r)
+	if err != nil {
+		core.WriteResponse(c, errorx.WithCode(code.ErrValidation, "%s", err.Error()), nil)
+		return
+	}
+
+	if _, err := t.scheduler.Schedule(c, req); err != nil && !errors.Is(err, scheduler.ErrTaskQueued) {
+		core.WriteResponse(c, errorx.WrapC(err, code.ErrTaskScheduleFailed, "%s", err.Error()), nil)
+		return
+	}
+
+	info, err := t.scheduler.Get(c, id)
+	if err != nil {
+		core.WriteResponse(c, withCode(err, id), nil)
+		return
+	}
+
+	core.WriteResponse(c, nil, toTask(info))
+}
@@ -0,0 +1,28 @@
+package task
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/eidolon/internal/pkg/code"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/pkg/errorx"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// Decision returns the latest scheduling decision of a task, including the
+// score breakdown and reject reason of every candidate node.
+func (t *TaskController) Decision(c *gin.Context) {
+	logger.CtxInfo(c, "task decision function called.")
+
+	id := c.Param("id")
+	info, err := t.scheduler.Get(c, id)
+	if err != nil {
+		core.WriteResponse(c, withCode(err, id), nil)
+		return
+	}
+	if info.Decision == nil {
+		core.WriteResponse(c, errorx.WithCode(code.ErrTaskNotScheduled, "task %s is %s", id, info.Task.Status), nil)
+		return
+	}
+
+	core.WriteResponse(c, nil, ToScheduleDecision(info.Decision))
+}
@@ -0,0 +1,102 @@
+package task
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/eidolon/internal/hivemind/service/tasklog"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/internal/pkg/code"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/pkg/errorx"
+	"github.com/kiosk404/eidolon/pkg/http/sse"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// eventsQuery is the query string accepted by Events.
+type eventsQuery struct {
+	// Watch streams the events as server-sent events until the task finishes.
+	Watch bool `form:"watch"`
+
+	// Since skips the events with a sequence number up to and including it.
+	Since int64 `form:"since"`
+}
+
+// Events returns the recorded lifecycle events of a task. With watch=true the
+// events are streamed as server-sent events, the recorded ones first, until
+// the task reaches a final state or the client goes away.
+func (t *TaskController) Events(c *gin.Context) {
+	logger.CtxInfo(c, "task events function called.")
+
+	var q eventsQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		core.WriteResponse(c, errorx.WithCode(code.ErrBind, "%s", err.Error()), nil)
+		return
+	}
+
+	id := c.Param("id")
+	info, err := t.scheduler.Get(c, id)
+	if err != nil {
+		core.WriteResponse(c, withCode(err, id), nil)
+		return
+	}
+
+	if !q.Watch {
+		list := &v1.TaskEventList{Items: make([]*v1.TaskEvent, 0)}
+		for _, e := range t.events.Events(id) {
+			if e.Seq > q.Since {
+				list.Items = append(list.Items, toTaskEvent(e))
+			}
+		}
+		core.WriteResponse(c, nil, list)
+
+		return
+	}
+
+	history, events, stop := t.events.Watch(id)
+	defer stop()
+
+	ctx := c.Request.Context()
+	sender := sse.NewSSESender(c)
+	defer sender.Close()
+
+	for _, e := range history {
+		if err := sendEvent(c, sender, q.Since, e); err != nil {
+			return
+		}
+	}
+	c.Writer.Flush()
+	// A finished task has no events left to wait for, even if its history was
+	// forgotten and no terminal event closes the stream.
+	if info.Task.Status.IsTerminal() {
+		return
+	}
+
+	heartbeat := time.NewTicker(sse.DefaultHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if err := sender.SendComment(ctx, "keep-alive"); err != nil {
+				return
+			}
+		case e, ok := <-events:
+			if !ok || sendEvent(c, sender, q.Since, e) != nil || e.IsTerminal() {
+				return
+			}
+		}
+	}
+}
+
+// sendEvent writes e to the stream unless the client has already seen it.
+func sendEvent(c *gin.Context, sender *sse.SSenderImpl, since int64, e *tasklog.Event) error {
+	if e.Seq <= since {
+		return nil
+	}
+
+	return sender.SendWithID(c.Request.Context(), strconv.FormatInt(e.Seq, 10), string(e.Type), toTaskEvent(e))
+}
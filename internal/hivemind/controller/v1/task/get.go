@@ -0,0 +1,21 @@
+package task
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// Get returns a task together with its latest scheduling decision.
+func (t *TaskController) Get(c *gin.Context) {
+	logger.CtxInfo(c, "task get function called.")
+
+	id := c.Param("id")
+	info, err := t.scheduler.Get(c, id)
+	if err != nil {
+		core.WriteResponse(c, withCode(err, id), nil)
+		return
+	}
+
+	core.WriteResponse(c, nil, toTask(info))
+}
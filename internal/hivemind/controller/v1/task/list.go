@@ -0,0 +1,78 @@
+package task
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/eidolon/internal/hivemind/service/scheduler"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/internal/pkg/code"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+	"github.com/kiosk404/eidolon/pkg/errorx"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// listQuery is the query string accepted by List.
+type listQuery struct {
+	v1.ListOptions
+
+	Status string `form:"status"`
+	NodeID string `form:"node"`
+	Mode   string `form:"mode"`
+}
+
+func (q *listQuery) validate() error {
+	switch protocol.TaskStatus(q.Status) {
+	case "", protocol.TaskStatusPending, protocol.TaskStatusAssigned, protocol.TaskStatusRunning,
+		protocol.TaskStatusCompleted, protocol.TaskStatusFailed, protocol.TaskStatusCancelled, protocol.TaskStatusTimedOut:
+	default:
+		return fmt.Errorf("unknown status %q", q.Status)
+	}
+
+	switch scheduler.ScheduleMode(q.Mode) {
+	case "", scheduler.AIMode, scheduler.DirectMode:
+	default:
+		return fmt.Errorf("unknown mode %q", q.Mode)
+	}
+
+	return nil
+}
+
+// List returns tasks filtered by status, node and mode, newest first.
+func (t *TaskController) List(c *gin.Context) {
+	logger.CtxInfo(c, "task list function called.")
+
+	var q listQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		core.WriteResponse(c, errorx.WithCode(code.ErrBind, "%s", err.Error()), nil)
+		return
+	}
+	if err := q.validate(); err != nil {
+		core.WriteResponse(c, errorx.WithCode(code.ErrValidation, "%s", err.Error()), nil)
+		return
+	}
+	q.Complete()
+
+	infos, total, err := t.scheduler.List(c, scheduler.TaskFilter{
+		Status: protocol.TaskStatus(q.Status),
+		NodeID: q.NodeID,
+		Mode:   scheduler.ScheduleMode(q.Mode),
+		Offset: q.Offset,
+		Limit:  q.Limit,
+	})
+	if err != nil {
+		core.WriteResponse(c, withCode(err, ""), nil)
+		return
+	}
+
+	list := &v1.TaskList{
+		ListMeta: v1.ListMeta{TotalCount: total},
+		Items:    make([]*v1.Task, 0, len(infos)),
+	}
+	for _, info := range infos {
+		list.Items = append(list.Items, toTask(info))
+	}
+
+	core.WriteResponse(c, nil, list)
+}
@@ -0,0 +1,181 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kiosk404/eidolon/internal/hivemind/service/scheduler"
+	"github.com/kiosk404/eidolon/internal/hivemind/service/tasklog"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/internal/pkg/code"
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+	"github.com/kiosk404/eidolon/pkg/errorx"
+)
+
+// TaskController handles requests for the task resource.
+type TaskController struct {
+	scheduler scheduler.Scheduler
+	events    *tasklog.Recorder
+}
+
+// NewTaskController creates a task handler.
+func NewTaskController(s scheduler.Scheduler, events *tasklog.Recorder) *TaskController {
+	return &TaskController{scheduler: s, events: events}
+}
+
+// ToScheduleRequest validates r and converts it into a scheduler request for a task with the given ID.
+func ToScheduleRequest(id string, r *v1.SubmitTaskRequest) (*scheduler.ScheduleRequest, error) {
+	if r.Priority < protocol.TaskPriorityLow || r.Priority > protocol.TaskPriorityCritical {
+		return nil, fmt.Errorf("priority must be between %d and %d", protocol.TaskPriorityLow, protocol.TaskPriorityCritical)
+	}
+
+	mode := scheduler.ScheduleMode(r.Mode)
+	switch mode {
+	case "":
+		mode = scheduler.AIMode
+	case scheduler.AIMode:
+	case scheduler.DirectMode:
+		if r.TargetNodeID == "" {
+			return nil, fmt.Errorf("target_node_id is required in %s mode", scheduler.DirectMode)
+		}
+	default:
+		return nil, fmt.Errorf("unknown mode %q, expected %s or %s", r.Mode, scheduler.AIMode, scheduler.DirectMode)
+	}
+
+	if _, err := scheduler.ParseSkillRequirements(r.RequiredSkills); err != nil {
+		return nil, err
+	}
+
+	var timeout time.Duration
+	if r.Timeout != "" {
+		d, err := time.ParseDuration(r.Timeout)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid timeout %q", r.Timeout)
+		}
+		timeout = d
+	}
+
+	name := r.Name
+	if name == "" {
+		name = id
+	}
+
+	req := &scheduler.ScheduleRequest{
+		Task: &protocol.Task{
+			ID:       id,
+			Name:     name,
+			Type:     r.Type,
+			Payload:  r.Payload,
+			Priority: r.Priority,
+			Timeout:  timeout,
+			Metadata: r.Metadata,
+		},
+		Mode:                 mode,
+		TargetNodeID:         r.TargetNodeID,
+		RequiredCapabilities: r.RequiredCapabilities,
+		RequiredSkills:       r.RequiredSkills,
+		RequiredFeatures:     r.RequiredFeatures,
+		InstallMissingSkills: r.InstallMissingSkills,
+		PreferredTags:        r.PreferredTags,
+	}
+	if res := r.Resources; res != nil {
+		req.ResourceRequirements = &scheduler.ResourceRequirements{
+			MinCPUCores:      res.MinCPUCores,
+			MinMemoryMB:      res.MinMemoryMB,
+			MinDiskFreeMB:    res.MinDiskFreeMB,
+			MaxCPUPercent:    res.MaxCPUPercent,
+			MaxMemoryPercent: res.MaxMemoryPercent,
+			MaxActiveTasks:   res.MaxActiveTasks,
+		}
+	}
+	if h := r.Hints; h != nil {
+		req.Hints = &scheduler.ScheduleHints{
+			Description:         h.Description,
+			PreferLowLatency:    h.PreferLowLatency,
+			PreferHighResources: h.PreferHighResources,
+			Affinity:            h.Affinity,
+			AntiAffinity:        h.AntiAffinity,
+			CustomContext:       h.CustomContext,
+		}
+	}
+
+	return req, nil
+}
+
+// toTask converts a scheduler snapshot into its API representation.
+func toTask(info *scheduler.TaskInfo) *v1.Task {
+	return &v1.Task{
+		Task:     info.Task,
+		Mode:     string(info.Mode),
+		Retries:  info.Retries,
+		Decision: ToScheduleDecision(info.Decision),
+	}
+}
+
+// ToScheduleDecision converts a scheduler decision into its API representation.
+func ToScheduleDecision(d *scheduler.ScheduleDecision) *v1.ScheduleDecision {
+	if d == nil {
+		return nil
+	}
+
+	out := &v1.ScheduleDecision{
+		RequestID:      d.RequestID,
+		Mode:           string(d.Mode),
+		SelectedNodeID: d.SelectedNodeID,
+		Reason:         d.Reason,
+		CandidateCount: d.CandidateCount,
+		EligibleCount:  d.EligibleCount,
+		DecidedAt:      d.DecidedAt,
+		Latency:        d.Latency,
+	}
+	for _, s := range d.Scores {
+		out.Scores = append(out.Scores, ToNodeScore(s))
+	}
+
+	return out
+}
+
+// ToNodeScore converts a scheduler node score into its API representation.
+func ToNodeScore(s scheduler.NodeScore) v1.NodeScore {
+	return v1.NodeScore{
+		NodeID:          s.NodeID,
+		TotalScore:      s.TotalScore,
+		CapabilityScore: s.CapabilityScore,
+		SkillScore:      s.SkillScore,
+		ResourceScore:   s.ResourceScore,
+		LoadScore:       s.LoadScore,
+		TagScore:        s.TagScore,
+		AffinityScore:   s.AffinityScore,
+		Eligible:        s.Eligible,
+		RejectReason:    s.RejectReason,
+	}
+}
+
+// toTaskEvent converts a recorded event into its API representation.
+func toTaskEvent(e *tasklog.Event) *v1.TaskEvent {
+	return &v1.TaskEvent{
+		Seq:       e.Seq,
+		Type:      string(e.Type),
+		TaskID:    e.TaskID,
+		Status:    e.Status,
+		NodeID:    e.NodeID,
+		Reason:    e.Reason,
+		Progress:  e.Progress,
+		Result:    e.Result,
+		Error:     e.Error,
+		Timestamp: e.Timestamp,
+	}
+}
+
+// withCode maps scheduler sentinel errors onto API error codes.
+func withCode(err error, taskID string) error {
+	switch {
+	case errors.Is(err, scheduler.ErrTaskNotFound):
+		return errorx.WrapC(err, code.ErrTaskNotFound, "task %s not found", taskID)
+	case errors.Is(err, scheduler.ErrTaskFinished):
+		return errorx.WrapC(err, code.ErrTaskAlreadyFinished, "task %s already finished", taskID)
+	default:
+		return errorx.WrapC(err, code.ErrUnknown, "%s", err.Error())
+	}
+}
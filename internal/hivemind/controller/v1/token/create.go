@@ -0,0 +1,56 @@
+package token
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/eidolon/internal/hivemind/service/bootstrap"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/internal/pkg/code"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/pkg/errorx"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// Create issues a new bootstrap token. The full token is only returned in this response.
+func (t *TokenController) Create(c *gin.Context) {
+	logger.CtxInfo(c, "token create function called.")
+
+	var r v1.CreateTokenRequest
+	if err := c.ShouldBindJSON(&r); err != nil {
+		core.WriteResponse(c, errorx.WithCode(code.ErrBind, "%s", err.Error()), nil)
+		return
+	}
+
+	opts := bootstrap.TokenOptions{
+		Description: r.Description,
+		Tags:        r.Tags,
+		TTL:         bootstrap.DefaultTokenTTL,
+		MaxUses:     1,
+	}
+	if r.TTL != "" {
+		ttl, err := time.ParseDuration(r.TTL)
+		if err != nil || ttl < 0 {
+			core.WriteResponse(c, errorx.WithCode(code.ErrValidation, "invalid ttl %q", r.TTL), nil)
+			return
+		}
+		opts.TTL = ttl
+	}
+	if r.MaxUses != nil {
+		if *r.MaxUses < 0 {
+			core.WriteResponse(c, errorx.WithCode(code.ErrValidation, "max_uses must not be negative"), nil)
+			return
+		}
+		opts.MaxUses = *r.MaxUses
+	}
+
+	token, plain, err := t.bootstrap.Create(c, opts)
+	if err != nil {
+		core.WriteResponse(c, withCode(err, ""), nil)
+		return
+	}
+
+	out := toToken(token)
+	out.Token = plain.String()
+	core.WriteResponse(c, nil, out)
+}
@@ -0,0 +1,21 @@
+package token
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// Get returns a bootstrap token by ID, without its secret.
+func (t *TokenController) Get(c *gin.Context) {
+	logger.CtxInfo(c, "token get function called.")
+
+	id := c.Param("id")
+	token, err := t.bootstrap.Get(c, id)
+	if err != nil {
+		core.WriteResponse(c, withCode(err, id), nil)
+		return
+	}
+
+	core.WriteResponse(c, nil, toToken(token))
+}
@@ -0,0 +1,41 @@
+package token
+
+import (
+	"github.com/gin-gonic/gin"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/internal/pkg/code"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/pkg/errorx"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// List returns bootstrap tokens, oldest first, without their secrets.
+func (t *TokenController) List(c *gin.Context) {
+	logger.CtxInfo(c, "token list function called.")
+
+	var q v1.ListOptions
+	if err := c.ShouldBindQuery(&q); err != nil {
+		core.WriteResponse(c, errorx.WithCode(code.ErrBind, "%s", err.Error()), nil)
+		return
+	}
+	q.Complete()
+
+	tokens, err := t.bootstrap.List(c)
+	if err != nil {
+		core.WriteResponse(c, withCode(err, ""), nil)
+		return
+	}
+
+	list := &v1.TokenList{ListMeta: v1.ListMeta{TotalCount: len(tokens)}, Items: []*v1.Token{}}
+	if q.Offset < len(tokens) {
+		tokens = tokens[q.Offset:]
+		if len(tokens) > q.Limit {
+			tokens = tokens[:q.Limit]
+		}
+		for _, token := range tokens {
+			list.Items = append(list.Items, toToken(token))
+		}
+	}
+
+	core.WriteResponse(c, nil, list)
+}
@@ -0,0 +1,20 @@
+package token
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// Revoke deletes a bootstrap token so that no more nodes can join with it.
+func (t *TokenController) Revoke(c *gin.Context) {
+	logger.CtxInfo(c, "token revoke function called.")
+
+	id := c.Param("id")
+	if err := t.bootstrap.Revoke(c, id); err != nil {
+		core.WriteResponse(c, withCode(err, id), nil)
+		return
+	}
+
+	core.WriteResponse(c, nil, nil)
+}
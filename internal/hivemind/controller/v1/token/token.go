@@ -0,0 +1,54 @@
+package token
+
+import (
+	"errors"
+	"time"
+
+	"github.com/kiosk404/eidolon/internal/hivemind/service/bootstrap"
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/internal/pkg/code"
+	"github.com/kiosk404/eidolon/pkg/errorx"
+)
+
+// TokenController handles requests for the bootstrap token resource.
+type TokenController struct {
+	bootstrap *bootstrap.Service
+}
+
+// NewTokenController creates a token handler.
+func NewTokenController(svc *bootstrap.Service) *TokenController {
+	return &TokenController{bootstrap: svc}
+}
+
+// toToken converts a stored token into its API representation.
+func toToken(t *bootstrap.Token) *v1.Token {
+	out := &v1.Token{
+		ID:          t.ID,
+		Description: t.Description,
+		Tags:        t.Tags,
+		MaxUses:     t.MaxUses,
+		Uses:        t.Uses,
+		Expired:     t.Expired(time.Now()),
+		CreatedAt:   t.CreatedAt,
+	}
+	if !t.ExpiresAt.IsZero() {
+		expiresAt := t.ExpiresAt
+		out.ExpiresAt = &expiresAt
+	}
+	if !t.LastUsedAt.IsZero() {
+		lastUsedAt := t.LastUsedAt
+		out.LastUsedAt = &lastUsedAt
+	}
+
+	return out
+}
+
+// withCode maps bootstrap sentinel errors onto API error codes.
+func withCode(err error, id string) error {
+	switch {
+	case errors.Is(err, bootstrap.ErrTokenNotFound):
+		return errorx.WrapC(err, code.ErrTokenNotFound, "bootstrap token %s not found", id)
+	default:
+		return errorx.WrapC(err, code.ErrUnknown, "%s", err.Error())
+	}
+}
@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	srvwebhook "github.com/kiosk404/eidolon/internal/hivemind/service/webhook"
+	"github.com/kiosk404/eidolon/internal/pkg/code"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/pkg/errorx"
+	"github.com/kiosk404/eidolon/pkg/logger"
+	"github.com/kiosk404/eidolon/pkg/utils/idutil"
+)
+
+// Create registers a new webhook. The secret is generated when not provided and
+// is only returned in this response.
+func (w *WebhookController) Create(c *gin.Context) {
+	logger.CtxInfo(c, "webhook create function called.")
+
+	var r webhookRequest
+	if err := c.ShouldBindJSON(&r); err != nil {
+		core.WriteResponse(c, errorx.WithCode(code.ErrBind, "%s", err.Error()), nil)
+		return
+	}
+
+	if r.URL == "" {
+		core.WriteResponse(c, errorx.WithCode(code.ErrValidation, "url is required"), nil)
+		return
+	}
+	if err := r.validate(); err != nil {
+		core.WriteResponse(c, errorx.WithCode(code.ErrValidation, "%s", err.Error()), nil)
+		return
+	}
+
+	now := time.Now()
+	wh := &srvwebhook.Webhook{
+		ID:          idutil.NewID("wh"),
+		Name:        r.Name,
+		Description: r.Description,
+		URL:         r.URL,
+		Secret:      r.Secret,
+		Headers:     r.Headers,
+		Events:      r.eventTypes(),
+		Enabled:     true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if wh.Secret == "" {
+		wh.Secret = idutil.RandomHex(24)
+	}
+	if r.Enabled != nil {
+		wh.Enabled = *r.Enabled
+	}
+
+	if err := w.store.Create(c, wh); err != nil {
+		core.WriteResponse(c, err, nil)
+		return
+	}
+
+	core.WriteResponse(c, nil, wh)
+}
@@ -0,0 +1,19 @@
+package webhook
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// Delete removes a webhook and its delivery history.
+func (w *WebhookController) Delete(c *gin.Context) {
+	logger.CtxInfo(c, "webhook delete function called.")
+
+	if err := w.store.Delete(c, c.Param("id")); err != nil {
+		core.WriteResponse(c, err, nil)
+		return
+	}
+
+	core.WriteResponse(c, nil, nil)
+}
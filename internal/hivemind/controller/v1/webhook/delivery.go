@@ -0,0 +1,36 @@
+package webhook
+
+import (
+	"github.com/gin-gonic/gin"
+	srvwebhook "github.com/kiosk404/eidolon/internal/hivemind/service/webhook"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/pkg/http/ginutil"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// defaultDeliveryLimit is the number of delivery records returned when no limit is given.
+const defaultDeliveryLimit = 20
+
+// ListDeliveries returns the most recent delivery attempts of a webhook.
+func (w *WebhookController) ListDeliveries(c *gin.Context) {
+	logger.CtxInfo(c, "webhook list deliveries function called.")
+
+	limit := int(ginutil.GetInt32(c, "limit"))
+	if limit <= 0 {
+		limit = defaultDeliveryLimit
+	}
+
+	deliveries, err := w.store.ListDeliveries(c, c.Param("id"), limit)
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+		return
+	}
+
+	core.WriteResponse(c, nil, struct {
+		TotalCount int                    `json:"total_count"`
+		Items      []*srvwebhook.Delivery `json:"items"`
+	}{
+		TotalCount: len(deliveries),
+		Items:      deliveries,
+	})
+}
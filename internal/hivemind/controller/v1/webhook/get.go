@@ -0,0 +1,20 @@
+package webhook
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// Get returns a webhook by ID.
+func (w *WebhookController) Get(c *gin.Context) {
+	logger.CtxInfo(c, "webhook get function called.")
+
+	wh, err := w.store.Get(c, c.Param("id"))
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+		return
+	}
+
+	core.WriteResponse(c, nil, redact(wh))
+}
@@ -0,0 +1,31 @@
+package webhook
+
+import (
+	"github.com/gin-gonic/gin"
+	srvwebhook "github.com/kiosk404/eidolon/internal/hivemind/service/webhook"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// List returns all webhooks.
+func (w *WebhookController) List(c *gin.Context) {
+	logger.CtxInfo(c, "webhook list function called.")
+
+	webhooks, err := w.store.List(c)
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+		return
+	}
+
+	for _, wh := range webhooks {
+		redact(wh)
+	}
+
+	core.WriteResponse(c, nil, struct {
+		TotalCount int                   `json:"total_count"`
+		Items      []*srvwebhook.Webhook `json:"items"`
+	}{
+		TotalCount: len(webhooks),
+		Items:      webhooks,
+	})
+}
@@ -0,0 +1,63 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/eidolon/internal/pkg/code"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/pkg/errorx"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// Update changes the fields present in the request body. Omitted fields keep
+// their current value; an explicit empty events list subscribes to all events.
+func (w *WebhookController) Update(c *gin.Context) {
+	logger.CtxInfo(c, "webhook update function called.")
+
+	var r webhookRequest
+	if err := c.ShouldBindJSON(&r); err != nil {
+		core.WriteResponse(c, errorx.WithCode(code.ErrBind, "%s", err.Error()), nil)
+		return
+	}
+	if err := r.validate(); err != nil {
+		core.WriteResponse(c, errorx.WithCode(code.ErrValidation, "%s", err.Error()), nil)
+		return
+	}
+
+	wh, err := w.store.Get(c, c.Param("id"))
+	if err != nil {
+		core.WriteResponse(c, err, nil)
+		return
+	}
+
+	if r.Name != "" {
+		wh.Name = r.Name
+	}
+	if r.Description != "" {
+		wh.Description = r.Description
+	}
+	if r.URL != "" {
+		wh.URL = r.URL
+	}
+	if r.Secret != "" {
+		wh.Secret = r.Secret
+	}
+	if r.Headers != nil {
+		wh.Headers = r.Headers
+	}
+	if r.Events != nil {
+		wh.Events = r.eventTypes()
+	}
+	if r.Enabled != nil {
+		wh.Enabled = *r.Enabled
+	}
+	wh.UpdatedAt = time.Now()
+
+	if err := w.store.Update(c, wh); err != nil {
+		core.WriteResponse(c, err, nil)
+		return
+	}
+
+	core.WriteResponse(c, nil, redact(wh))
+}
@@ -0,0 +1,65 @@
+package webhook
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/kiosk404/eidolon/internal/hivemind/service/scheduler"
+	srvwebhook "github.com/kiosk404/eidolon/internal/hivemind/service/webhook"
+)
+
+// WebhookController handles requests for the webhook resource.
+type WebhookController struct {
+	store srvwebhook.Store
+}
+
+// NewWebhookController creates a webhook handler.
+func NewWebhookController(store srvwebhook.Store) *WebhookController {
+	return &WebhookController{store: store}
+}
+
+// webhookRequest is the body accepted by create and update.
+type webhookRequest struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	URL         string            `json:"url"`
+	Secret      string            `json:"secret"`
+	Headers     map[string]string `json:"headers"`
+	Events      []string          `json:"events"`
+	Enabled     *bool             `json:"enabled"`
+}
+
+// validate checks the fields that are set on the request.
+func (r *webhookRequest) validate() error {
+	if r.URL != "" {
+		u, err := url.Parse(r.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("url %q must be an absolute http or https URL", r.URL)
+		}
+	}
+	for _, e := range r.Events {
+		if !srvwebhook.IsKnownEventType(scheduler.TaskEventType(e)) {
+			return fmt.Errorf("unknown event type %q, expected one of %v", e, srvwebhook.KnownEventTypes)
+		}
+	}
+
+	return nil
+}
+
+func (r *webhookRequest) eventTypes() []scheduler.TaskEventType {
+	types := make([]scheduler.TaskEventType, 0, len(r.Events))
+	for _, e := range r.Events {
+		types = append(types, scheduler.TaskEventType(e))
+	}
+
+	return types
+}
+
+// redact hides the webhook secret from responses.
+func redact(wh *srvwebhook.Webhook) *srvwebhook.Webhook {
+	if wh.Secret != "" {
+		wh.Secret = "******"
+	}
+
+	return wh
+}
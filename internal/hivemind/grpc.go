@@ -0,0 +1,27 @@
+package hivemind
+
+import (
+	"github.com/kiosk404/eidolon/internal/hivemind/controller/v1/bootstrap"
+	"github.com/kiosk404/eidolon/internal/hivemind/controller/v1/golem"
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+	genericapiserver "github.com/kiosk404/eidolon/internal/pkg/server"
+	"google.golang.org/grpc"
+)
+
+// grpcAccess opens the join to the golems without a client certificate and
+// the Node service to the node certificates issued at join. The other methods
+// require an admin certificate.
+var grpcAccess = genericapiserver.GRPCAccess{
+	Anonymous: []string{
+		"/grpc.reflection.",
+		protocol.BootstrapJoinFullMethodName,
+	},
+	Node: []string{
+		protocol.NodeServicePrefix,
+	},
+}
+
+func initGRPCServices(s *grpc.Server, svc *services) {
+	protocol.RegisterBootstrapServer(s, bootstrap.NewBootstrapController(svc.bootstrap))
+	protocol.RegisterNodeServer(s, golem.NewGolemController(svc.registry, svc.outbox, svc.scheduler))
+}
@@ -0,0 +1,170 @@
+// Package metrics exports the hivemind scheduler and golem fleet state as
+// Prometheus metrics.
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/kiosk404/eidolon/internal/hivemind/service/registry"
+	"github.com/kiosk404/eidolon/internal/hivemind/service/scheduler"
+	pkgmetrics "github.com/kiosk404/eidolon/internal/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	queueDepthDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(pkgmetrics.Namespace, "scheduler", "queue_depth"),
+		"Number of tasks waiting in the scheduling queue.", nil, nil)
+	runningDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(pkgmetrics.Namespace, "scheduler", "running_tasks"),
+		"Number of tasks assigned to a golem and not yet finished.", nil, nil)
+	tasksDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(pkgmetrics.Namespace, "scheduler", "tasks_total"),
+		"Total number of tasks by lifecycle outcome.", []string{"outcome"}, nil)
+	nodeTasksDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(pkgmetrics.Namespace, "scheduler", "node_tasks_total"),
+		"Total number of tasks per golem node by outcome.", []string{"node", "outcome"}, nil)
+
+	nodesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(pkgmetrics.Namespace, "golem", "nodes"),
+		"Number of registered golem nodes by status.", []string{"status"}, nil)
+	heartbeatAgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(pkgmetrics.Namespace, "golem", "heartbeat_age_seconds"),
+		"Seconds since the last heartbeat of a golem node.", []string{"node"}, nil)
+	cordonedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(pkgmetrics.Namespace, "golem", "cordoned"),
+		"Whether a golem node is cordoned (1) or schedulable (0).", []string{"node"}, nil)
+)
+
+// Collector reads scheduler statistics and registry state on every scrape and
+// observes task events for the latency histograms.
+type Collector struct {
+	scheduler scheduler.Scheduler
+	registry  registry.Registry
+
+	assignmentLatency *prometheus.HistogramVec
+	executionTime     *prometheus.HistogramVec
+}
+
+var (
+	_ prometheus.Collector        = &Collector{}
+	_ scheduler.TaskEventListener = &Collector{}
+)
+
+// NewCollector creates a Collector. It must be subscribed to the scheduler to
+// populate the histograms.
+func NewCollector(sched scheduler.Scheduler, reg registry.Registry) *Collector {
+	return &Collector{
+		scheduler: sched,
+		registry:  reg,
+		assignmentLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: pkgmetrics.Namespace,
+			Subsystem: "scheduler",
+			Name:      "assignment_latency_seconds",
+			Help:      "Time from task submission to assignment to a golem, by scheduling mode.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+		}, []string{"mode"}),
+		executionTime: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: pkgmetrics.Namespace,
+			Subsystem: "scheduler",
+			Name:      "task_execution_seconds",
+			Help:      "Time from task assignment to its result, by golem node and outcome.",
+			Buckets:   prometheus.ExponentialBuckets(0.1, 3, 10),
+		}, []string{"node", "outcome"}),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- queueDepthDesc
+	ch <- runningDesc
+	ch <- tasksDesc
+	ch <- nodeTasksDesc
+	ch <- nodesDesc
+	ch <- heartbeatAgeDesc
+	ch <- cordonedDesc
+	c.assignmentLatency.Describe(ch)
+	c.executionTime.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.scheduler.Stats()
+
+	ch <- prometheus.MustNewConstMetric(queueDepthDesc, prometheus.GaugeValue, float64(stats.CurrentQueued))
+	ch <- prometheus.MustNewConstMetric(runningDesc, prometheus.GaugeValue, float64(stats.CurrentRunning))
+	for outcome, v := range map[string]int64{
+		"submitted": stats.TotalSubmitted,
+		"completed": stats.TotalCompleted,
+		"failed":    stats.TotalFailed,
+		"cancelled": stats.TotalCancelled,
+		"timed_out": stats.TotalTimedOut,
+	} {
+		ch <- prometheus.MustNewConstMetric(tasksDesc, prometheus.CounterValue, float64(v), outcome)
+	}
+	for _, ns := range stats.NodeStats {
+		ch <- prometheus.MustNewConstMetric(nodeTasksDesc, prometheus.CounterValue, float64(ns.TasksAssigned), ns.NodeID, "assigned")
+		ch <- prometheus.MustNewConstMetric(nodeTasksDesc, prometheus.CounterValue, float64(ns.TasksCompleted), ns.NodeID, "completed")
+		ch <- prometheus.MustNewConstMetric(nodeTasksDesc, prometheus.CounterValue, float64(ns.TasksFailed), ns.NodeID, "failed")
+	}
+
+	c.collectNodes(ch)
+
+	c.assignmentLatency.Collect(ch)
+	c.executionTime.Collect(ch)
+}
+
+func (c *Collector) collectNodes(ch chan<- prometheus.Metric) {
+	profiles, err := c.registry.ListAll(context.Background())
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(nodesDesc, err)
+		return
+	}
+
+	now := time.Now()
+	byStatus := make(map[string]int)
+	for _, p := range profiles {
+		byStatus[string(p.NodeInfo.Status)]++
+
+		last := p.Load.ReportedAt
+		if last.IsZero() {
+			last = p.NodeInfo.RegisteredAt
+		}
+		ch <- prometheus.MustNewConstMetric(heartbeatAgeDesc, prometheus.GaugeValue, now.Sub(last).Seconds(), p.NodeInfo.ID)
+
+		cordoned := 0.0
+		if p.Cordoned {
+			cordoned = 1
+		}
+		ch <- prometheus.MustNewConstMetric(cordonedDesc, prometheus.GaugeValue, cordoned, p.NodeInfo.ID)
+	}
+	for status, n := range byStatus {
+		ch <- prometheus.MustNewConstMetric(nodesDesc, prometheus.GaugeValue, float64(n), status)
+	}
+}
+
+// OnEvent implements scheduler.TaskEventListener.
+func (c *Collector) OnEvent(event *scheduler.TaskEvent) {
+	task := event.Task
+	if task == nil {
+		return
+	}
+
+	switch event.Type {
+	case scheduler.EventTypeAssigned:
+		if task.CreatedAt.IsZero() {
+			return
+		}
+		mode := ""
+		if event.Decision != nil {
+			mode = string(event.Decision.Mode)
+		}
+		c.assignmentLatency.WithLabelValues(mode).Observe(event.Timestamp.Sub(task.CreatedAt).Seconds())
+	case scheduler.EventTypeCompleted, scheduler.EventTypeFailed:
+		if task.StartedAt == nil || task.CompletedAt == nil {
+			return
+		}
+		c.executionTime.WithLabelValues(event.NodeID, string(event.Type)).Observe(task.CompletedAt.Sub(*task.StartedAt).Seconds())
+	}
+}
@@ -8,13 +8,21 @@ import (
 )
 
 type Options struct {
-	GRPCOptions             *genericoptions.GRPCOptions      `json:"grpc"     mapstructure:"grpc"`
-	GenericServerRunOptions *genericoptions.ServerRunOptions `json:"serving"     mapstructure:"serving"`
+	GRPCOptions             *genericoptions.GRPCOptions          `json:"grpc"     mapstructure:"grpc"`
+	GenericServerRunOptions *genericoptions.ServerRunOptions     `json:"serving"     mapstructure:"serving"`
+	FeatureOptions          *genericoptions.FeatureOptions       `json:"feature"     mapstructure:"feature"`
+	SecureServing           *genericoptions.SecureServingOptions `json:"secure"  mapstructure:"secure"`
+	SkillRegistry           *genericoptions.SkillRegistryOptions `json:"skill-registry" mapstructure:"skill-registry"`
+	Model                   *genericoptions.ModelOptions         `json:"model" mapstructure:"model"`
 }
 
 func (o *Options) Flags() (fss cliflag.NamedFlagSets) {
 	o.GRPCOptions.AddFlags(fss.FlagSet("grpc"))
 	o.GenericServerRunOptions.AddFlags(fss.FlagSet("generic"))
+	o.FeatureOptions.AddFlags(fss.FlagSet("features"))
+	o.SecureServing.AddFlags(fss.FlagSet("secure serving"))
+	o.SkillRegistry.AddFlags(fss.FlagSet("skill registry"))
+	o.Model.AddFlags(fss.FlagSet("model"))
 
 	return fss
 }
@@ -23,6 +31,10 @@ func NewOptions() *Options {
 	return &Options{
 		GRPCOptions:             genericoptions.NewGRPCOptions(),
 		GenericServerRunOptions: genericoptions.NewServerRunOptions(),
+		FeatureOptions:          genericoptions.NewFeatureOptions(),
+		SecureServing:           genericoptions.NewSecureServingOptions(),
+		SkillRegistry:           genericoptions.NewSkillRegistryOptions(),
+		Model:                   genericoptions.NewModelOptions(),
 	}
 }
 
@@ -39,5 +51,5 @@ func (o *Options) String() string {
 
 // Complete set default Options.
 func (o *Options) Complete() error {
-	return nil
+	return o.Model.Complete()
 }
@@ -4,5 +4,9 @@ func (o *Options) Validate() []error {
 	var errs []error
 	errs = append(errs, o.GenericServerRunOptions.Validate()...)
 	errs = append(errs, o.GRPCOptions.Validate()...)
+	errs = append(errs, o.FeatureOptions.Validate()...)
+	errs = append(errs, o.SecureServing.Validate()...)
+	errs = append(errs, o.SkillRegistry.Validate()...)
+	errs = append(errs, o.Model.Validate()...)
 	return errs
 }
@@ -2,16 +2,106 @@ package hivemind
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/eidolon/internal/hivemind/controller/v1/node"
+	"github.com/kiosk404/eidolon/internal/hivemind/controller/v1/scheduler"
+	"github.com/kiosk404/eidolon/internal/hivemind/controller/v1/skill"
+	"github.com/kiosk404/eidolon/internal/hivemind/controller/v1/task"
+	"github.com/kiosk404/eidolon/internal/hivemind/controller/v1/token"
+	"github.com/kiosk404/eidolon/internal/hivemind/controller/v1/webhook"
 )
 
-func initRouter(g *gin.Engine) {
+func initRouter(g *gin.Engine, auth, admin gin.HandlerFunc, svc *services) {
 	installMiddleware(g)
-	installController(g)
+	installController(g, auth, admin, svc)
 }
 
 func installMiddleware(g *gin.Engine) {
 }
 
-func installController(g *gin.Engine) *gin.Engine {
+func installController(g *gin.Engine, auth, admin gin.HandlerFunc, svc *services) *gin.Engine {
+	skillController := skill.NewSkillController(svc.skills)
+
+	// v1 handlers, requiring authentication
+	authenticated := g.Group("/api/v1", auth)
+	{
+		// golem handlers, open to the node certificates
+		authenticated.GET("/skills/:id/versions/:version/package", skillController.Download)
+	}
+
+	// v1 management handlers, requiring an admin identity
+	v1 := authenticated.Group("", admin)
+	{
+		// task RESTful resource
+		taskv1 := v1.Group("/tasks")
+		{
+			taskController := task.NewTaskController(svc.scheduler, svc.tasklog)
+
+			taskv1.POST("", taskController.Create)
+			taskv1.GET("", taskController.List)
+			taskv1.GET(":id", taskController.Get)
+			taskv1.GET(":id/decision", taskController.Decision)
+			taskv1.GET(":id/events", taskController.Events)
+			taskv1.POST(":id/cancel", taskController.Cancel)
+			taskv1.DELETE(":id", taskController.Cancel)
+		}
+
+		// scheduler introspection
+		schedulerv1 := v1.Group("/scheduler")
+		{
+			schedulerController := scheduler.NewSchedulerController(svc.scheduler)
+
+			schedulerv1.GET("stats", schedulerController.Stats)
+			schedulerv1.POST("simulate", schedulerController.Simulate)
+		}
+
+		// node RESTful resource
+		nodev1 := v1.Group("/nodes")
+		{
+			nodeController := node.NewNodeController(svc.registry, svc.drainer)
+
+			nodev1.GET("", nodeController.List)
+			nodev1.GET(":id", nodeController.Get)
+			nodev1.PUT(":id/tags", nodeController.UpdateTags)
+			nodev1.POST(":id/cordon", nodeController.Cordon)
+			nodev1.POST(":id/uncordon", nodeController.Uncordon)
+			nodev1.POST(":id/drain", nodeController.Drain)
+			nodev1.DELETE(":id", nodeController.Delete)
+		}
+
+		// bootstrap token RESTful resource
+		tokenv1 := v1.Group("/tokens")
+		{
+			tokenController := token.NewTokenController(svc.bootstrap)
+
+			tokenv1.POST("", tokenController.Create)
+			tokenv1.GET("", tokenController.List)
+			tokenv1.GET(":id", tokenController.Get)
+			tokenv1.DELETE(":id", tokenController.Revoke)
+		}
+
+		// skill package RESTful resource
+		skillv1 := v1.Group("/skills")
+		{
+			skillv1.POST("", skillController.Upload)
+			skillv1.GET("", skillController.List)
+			skillv1.GET(":id", skillController.Versions)
+			skillv1.GET(":id/versions/:version", skillController.Get)
+			skillv1.DELETE(":id/versions/:version", skillController.Delete)
+		}
+
+		// webhook RESTful resource
+		webhookv1 := v1.Group("/webhooks")
+		{
+			webhookController := webhook.NewWebhookController(svc.webhooks)
+
+			webhookv1.POST("", webhookController.Create)
+			webhookv1.GET("", webhookController.List)
+			webhookv1.GET(":id", webhookController.Get)
+			webhookv1.PUT(":id", webhookController.Update)
+			webhookv1.DELETE(":id", webhookController.Delete)
+			webhookv1.GET(":id/deliveries", webhookController.ListDeliveries)
+		}
+	}
+
 	return g
 }
@@ -1,14 +1,20 @@
 package hivemind
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/kiosk404/eidolon/internal/hivemind/config"
+	"github.com/kiosk404/eidolon/internal/pkg/metrics"
 	genericapiserver "github.com/kiosk404/eidolon/internal/pkg/server"
 	"github.com/kiosk404/eidolon/pkg/http/shutdown"
 	"github.com/kiosk404/eidolon/pkg/http/shutdown/posixsignal"
+	"github.com/kiosk404/eidolon/pkg/utils/certutil"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -16,6 +22,7 @@ type apiServer struct {
 	gs               *shutdown.GracefulShutdown
 	gRPCAPIServer    *genericapiserver.GRPCAPIServer
 	genericAPIServer *genericapiserver.GenericAPIServer
+	services         *services
 }
 
 type preparedAPIServer struct {
@@ -24,8 +31,13 @@ type preparedAPIServer struct {
 
 // ExtraConfig defines extra configuration for the API server.
 type ExtraConfig struct {
-	Addr       string
-	MaxMsgSize int
+	Addr          string
+	MaxMsgSize    int
+	EnableMetrics bool
+
+	// SecureServing turns on TLS for the gRPC server. Golems must then present a
+	// client certificate, issued when they join, unless no client CA is configured.
+	SecureServing *genericapiserver.SecureServingInfo
 }
 
 type completedExtraConfig struct {
@@ -44,11 +56,38 @@ func (c *ExtraConfig) complete() *completedExtraConfig {
 // New create a grpcAPIServer instance.
 func (c *completedExtraConfig) New() (*genericapiserver.GRPCAPIServer, error) {
 	opts := []grpc.ServerOption{grpc.MaxRecvMsgSize(c.MaxMsgSize)}
-	grpcServer := grpc.NewServer(opts...)
+	if c.EnableMetrics {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(metrics.UnaryServerInterceptor()),
+			grpc.ChainStreamInterceptor(metrics.StreamServerInterceptor()),
+		)
+	}
+
+	if c.SecureServing == nil {
+		grpcServer := grpc.NewServer(opts...)
+		reflection.Register(grpcServer)
+
+		return genericapiserver.NewGRPCAPIServer(grpcServer, c.Addr), nil
+	}
 
+	reloader, err := c.SecureServing.NewReloader()
+	if err != nil {
+		return nil, err
+	}
+	// Joining golems have no client certificate yet, the interceptors below
+	// require one for every other method.
+	tlsConfig := certutil.ServerConfig(reloader, c.SecureServing.MinVersion, tls.VerifyClientCertIfGiven)
+	opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	if c.SecureServing.ClientCAFile != "" {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(genericapiserver.ClientCertUnaryInterceptor(grpcAccess)),
+			grpc.ChainStreamInterceptor(genericapiserver.ClientCertStreamInterceptor(grpcAccess)),
+		)
+	}
+	grpcServer := grpc.NewServer(opts...)
 	reflection.Register(grpcServer)
 
-	return genericapiserver.NewGRPCAPIServer(grpcServer, c.Addr), nil
+	return genericapiserver.NewSecureGRPCAPIServer(grpcServer, c.Addr, reloader), nil
 }
 
 func createAPIServer(cfg *config.Config) (*apiServer, error) {
@@ -64,6 +103,7 @@ func createAPIServer(cfg *config.Config) (*apiServer, error) {
 	if err != nil {
 		return nil, err
 	}
+	extraConfig.SecureServing = genericConfig.SecureServing
 
 	genericServer, err := genericConfig.Complete().New()
 	if err != nil {
@@ -74,28 +114,42 @@ func createAPIServer(cfg *config.Config) (*apiServer, error) {
 		return nil, err
 	}
 
+	svc, err := newServices(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	server := &apiServer{
 		gs:               gs,
 		genericAPIServer: genericServer,
 		gRPCAPIServer:    extraServer,
+		services:         svc,
 	}
 
 	return server, nil
 }
 
 func (s *apiServer) PrepareRun() preparedAPIServer {
-	initRouter(s.genericAPIServer.Engine)
+	initRouter(s.genericAPIServer.Engine, s.genericAPIServer.Authenticate(),
+		s.genericAPIServer.AuthorizeAdmin(), s.services)
+	initGRPCServices(s.gRPCAPIServer.Server, s.services)
 
 	s.gs.AddShutdownCallback(shutdown.Func(func(string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
 
-		s.gRPCAPIServer.Stop()
+		s.gRPCAPIServer.Close()
 		s.genericAPIServer.Close()
-		return nil
+		return s.services.stop(ctx)
 	}))
 	return preparedAPIServer{s}
 }
 
 func (s preparedAPIServer) Run() error {
+	if err := s.services.start(context.Background()); err != nil {
+		return err
+	}
+
 	go s.gRPCAPIServer.Run()
 
 	// start shutdown managers
@@ -112,12 +166,21 @@ func buildGenericConfig(cfg *config.Config) (genericConfig *genericapiserver.Con
 		return
 	}
 
+	if lastErr = cfg.FeatureOptions.ApplyTo(genericConfig); lastErr != nil {
+		return
+	}
+
+	if lastErr = cfg.SecureServing.ApplyTo(genericConfig); lastErr != nil {
+		return
+	}
+
 	return
 }
 
 func buildExtraConfig(cfg *config.Config) (*ExtraConfig, error) {
 	return &ExtraConfig{
-		Addr:       fmt.Sprintf("%s:%d", cfg.GRPCOptions.BindAddress, cfg.GRPCOptions.BindPort),
-		MaxMsgSize: cfg.GRPCOptions.MaxMsgSize,
+		Addr:          fmt.Sprintf("%s:%d", cfg.GRPCOptions.BindAddress, cfg.GRPCOptions.BindPort),
+		MaxMsgSize:    cfg.GRPCOptions.MaxMsgSize,
+		EnableMetrics: cfg.FeatureOptions.EnableMetrics,
 	}, nil
 }
@@ -0,0 +1,191 @@
+package bootstrap
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kiosk404/eidolon/internal/hivemind/service/registry"
+	"github.com/kiosk404/eidolon/internal/hivemind/service/scheduler"
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+	"github.com/kiosk404/eidolon/pkg/logger"
+	"github.com/kiosk404/eidolon/pkg/utils/certutil"
+	"github.com/kiosk404/eidolon/pkg/utils/idutil"
+)
+
+const logModule = "bootstrap"
+
+const (
+	// DefaultTokenTTL is the lifetime of a token created without an explicit TTL.
+	DefaultTokenTTL = 24 * time.Hour
+
+	// DefaultNodeCertTTL is the lifetime of the client certificates issued at join time.
+	DefaultNodeCertTTL = 365 * 24 * time.Hour
+)
+
+// ErrInvalidJoinRequest is returned when a join request is malformed.
+var ErrInvalidJoinRequest = errors.New("invalid join request")
+
+// ErrJoinDisabled is returned by Join when the hivemind has no CA to issue
+// the client certificates of the joining nodes.
+var ErrJoinDisabled = errors.New("joining is disabled, the hivemind has no client CA key")
+
+// TokenOptions describes a token to create.
+type TokenOptions struct {
+	Description string
+	Tags        map[string]string
+
+	// TTL is the lifetime of the token; 0 means it never expires.
+	TTL time.Duration
+
+	// MaxUses is the number of nodes that may join with the token; 0 means unlimited.
+	MaxUses int
+}
+
+// Service manages bootstrap tokens and lets golems join with them.
+type Service struct {
+	store    Store
+	registry registry.Registry
+
+	// ca signs the client certificates of joining nodes; nil disables joining.
+	ca *certutil.CA
+
+	// CertTTL is the lifetime of issued client certificates.
+	CertTTL time.Duration
+}
+
+// NewService creates a Service. ca may be nil when the hivemind serves without
+// mutual TLS, nodes cannot join then since they would hold no credential.
+func NewService(store Store, reg registry.Registry, ca *certutil.CA) *Service {
+	return &Service{
+		store:    store,
+		registry: reg,
+		ca:       ca,
+		CertTTL:  DefaultNodeCertTTL,
+	}
+}
+
+// Create generates a token. The returned plain token is the only copy of its
+// secret and cannot be retrieved later.
+func (s *Service) Create(ctx context.Context, opts TokenOptions) (*Token, protocol.BootstrapToken, error) {
+	plain, err := protocol.NewBootstrapToken()
+	if err != nil {
+		return nil, protocol.BootstrapToken{}, err
+	}
+
+	now := time.Now()
+	t := &Token{
+		ID:          plain.ID,
+		SecretHash:  HashSecret(plain.Secret),
+		Description: opts.Description,
+		Tags:        opts.Tags,
+		MaxUses:     opts.MaxUses,
+		CreatedAt:   now,
+	}
+	if opts.TTL > 0 {
+		t.ExpiresAt = now.Add(opts.TTL)
+	}
+
+	if err := s.store.Create(ctx, t); err != nil {
+		return nil, protocol.BootstrapToken{}, err
+	}
+
+	return cloneToken(t), plain, nil
+}
+
+// Get returns a token by ID.
+func (s *Service) Get(ctx context.Context, id string) (*Token, error) {
+	return s.store.Get(ctx, id)
+}
+
+// List returns every token, oldest first, including expired ones.
+func (s *Service) List(ctx context.Context) ([]*Token, error) {
+	return s.store.List(ctx)
+}
+
+// Revoke deletes a token; nodes that already joined with it are not affected.
+func (s *Service) Revoke(ctx context.Context, id string) error {
+	return s.store.Delete(ctx, id)
+}
+
+// Join exchanges a bootstrap token for a node ID and a client certificate for
+// the request's CSR. The node is registered offline with the token's tags
+// until it starts heartbeating.
+func (s *Service) Join(ctx context.Context, req *protocol.JoinRequest) (*protocol.JoinResponse, error) {
+	token, err := protocol.ParseBootstrapToken(req.Token)
+	if err != nil {
+		return nil, fmt.Errorf("bootstrap: %s: %w", err.Error(), ErrTokenInvalid)
+	}
+	if s.ca == nil {
+		return nil, ErrJoinDisabled
+	}
+	if len(req.CSR) == 0 {
+		return nil, fmt.Errorf("bootstrap: a certificate request is required: %w", ErrInvalidJoinRequest)
+	}
+
+	// Check the token before doing any signing work for the caller.
+	if _, err := s.store.Verify(ctx, token.ID, token.Secret); err != nil {
+		logger.WarnX(logModule, "join rejected: %s", err.Error())
+		return nil, err
+	}
+	csr, err := certutil.ParseCSR(req.CSR)
+	if err != nil {
+		return nil, fmt.Errorf("bootstrap: %s: %w", err.Error(), ErrInvalidJoinRequest)
+	}
+
+	resp := &protocol.JoinResponse{NodeID: s.nodeID(ctx, req.NodeInfo.ID, csr)}
+	if resp.Certificate, err = s.ca.SignClientCSR(req.CSR, resp.NodeID, s.CertTTL); err != nil {
+		return nil, fmt.Errorf("bootstrap: %s: %w", err.Error(), ErrInvalidJoinRequest)
+	}
+	resp.CACertificate = s.ca.CertPEM()
+
+	// Consume the token last so that a failed join does not burn a use.
+	used, err := s.store.Use(ctx, token.ID, token.Secret)
+	if err != nil {
+		logger.WarnX(logModule, "join rejected: %s", err.Error())
+		return nil, err
+	}
+	resp.Tags = used.Tags
+
+	info := req.NodeInfo
+	info.ID = resp.NodeID
+	info.Status = protocol.NodeStatusOffline
+	info.RegisteredAt = time.Now()
+	if info.Name == "" {
+		info.Name = info.SystemInfo.Hostname
+	}
+	if err := s.registry.Register(ctx, &scheduler.GolemProfile{
+		NodeInfo:        info,
+		InstalledSkills: registry.SkillsFromProtocol(req.InstalledSkills),
+		Tags:            used.Tags,
+	}); err != nil {
+		return nil, err
+	}
+
+	logger.InfoX(logModule, "node %s (%s) joined with token %s", resp.NodeID, info.Name, token.ID)
+
+	return resp, nil
+}
+
+// nodeID returns the ID requested by a joining node when it is derived from
+// the identity key that signed the certificate request and unused, so that the
+// ID stays the one the node generated for itself, and a new one otherwise.
+func (s *Service) nodeID(ctx context.Context, requested string, csr *x509.CertificateRequest) string {
+	if requested == "" {
+		return idutil.NewID("golem")
+	}
+	if pub, ok := csr.PublicKey.(ed25519.PublicKey); !ok || protocol.NodeIDFromKey(pub) != requested {
+		logger.WarnX(logModule, "join requested node ID %s without proving it holds its identity key, assigning a new one",
+			requested)
+		return idutil.NewID("golem")
+	}
+	if _, err := s.registry.GetProfile(ctx, requested); !errors.Is(err, registry.ErrNodeNotFound) {
+		logger.WarnX(logModule, "join requested node ID %s which is already registered, assigning a new one", requested)
+		return idutil.NewID("golem")
+	}
+
+	return requested
+}
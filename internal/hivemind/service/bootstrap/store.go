@@ -0,0 +1,148 @@
+package bootstrap
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Store persists bootstrap tokens.
+type Store interface {
+	Create(ctx context.Context, t *Token) error
+	Get(ctx context.Context, id string) (*Token, error)
+	List(ctx context.Context) ([]*Token, error)
+	Delete(ctx context.Context, id string) error
+
+	// Verify checks the secret, expiry and remaining uses of a token without using it.
+	Verify(ctx context.Context, id, secret string) (*Token, error)
+
+	// Use verifies the token like Verify and consumes one of its uses atomically.
+	Use(ctx context.Context, id, secret string) (*Token, error)
+}
+
+type memoryStore struct {
+	mu     sync.Mutex
+	tokens map[string]*Token
+}
+
+var _ Store = &memoryStore{}
+
+// NewMemoryStore creates an in-memory Store.
+func NewMemoryStore() Store {
+	return &memoryStore{
+		tokens: make(map[string]*Token),
+	}
+}
+
+func (s *memoryStore) Create(_ context.Context, t *Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.tokens[t.ID]; ok {
+		return fmt.Errorf("bootstrap token %q already exists", t.ID)
+	}
+	s.tokens[t.ID] = cloneToken(t)
+
+	return nil
+}
+
+func (s *memoryStore) Get(_ context.Context, id string) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tokens[id]
+	if !ok {
+		return nil, fmt.Errorf("bootstrap: token %q: %w", id, ErrTokenNotFound)
+	}
+
+	return cloneToken(t), nil
+}
+
+func (s *memoryStore) List(_ context.Context) ([]*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := make([]*Token, 0, len(s.tokens))
+	for _, t := range s.tokens {
+		list = append(list, cloneToken(t))
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CreatedAt.Before(list[j].CreatedAt)
+	})
+
+	return list, nil
+}
+
+func (s *memoryStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.tokens[id]; !ok {
+		return fmt.Errorf("bootstrap: token %q: %w", id, ErrTokenNotFound)
+	}
+	delete(s.tokens, id)
+
+	return nil
+}
+
+func (s *memoryStore) Verify(_ context.Context, id, secret string) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, err := s.verify(id, secret, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return cloneToken(t), nil
+}
+
+func (s *memoryStore) Use(_ context.Context, id, secret string) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	t, err := s.verify(id, secret, now)
+	if err != nil {
+		return nil, err
+	}
+	t.Uses++
+	t.LastUsedAt = now
+
+	return cloneToken(t), nil
+}
+
+// verify must be called with s.mu held.
+func (s *memoryStore) verify(id, secret string, now time.Time) (*Token, error) {
+	t, ok := s.tokens[id]
+	if !ok {
+		// Do not tell unknown tokens apart from wrong secrets.
+		return nil, fmt.Errorf("bootstrap: token %q: %w", id, ErrTokenInvalid)
+	}
+	if subtle.ConstantTimeCompare([]byte(t.SecretHash), []byte(HashSecret(secret))) != 1 {
+		return nil, fmt.Errorf("bootstrap: token %q: secret mismatch: %w", id, ErrTokenInvalid)
+	}
+	if t.Expired(now) {
+		return nil, fmt.Errorf("bootstrap: token %q: expired at %s: %w", id, t.ExpiresAt.Format(time.RFC3339), ErrTokenInvalid)
+	}
+	if t.Exhausted() {
+		return nil, fmt.Errorf("bootstrap: token %q: all %d uses consumed: %w", id, t.MaxUses, ErrTokenInvalid)
+	}
+
+	return t, nil
+}
+
+func cloneToken(t *Token) *Token {
+	c := *t
+	if t.Tags != nil {
+		c.Tags = make(map[string]string, len(t.Tags))
+		for k, v := range t.Tags {
+			c.Tags[k] = v
+		}
+	}
+
+	return &c
+}
@@ -0,0 +1,54 @@
+// Package bootstrap issues the bootstrap tokens golems use to join the hivemind
+// and exchanges them for a node identity.
+package bootstrap
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrTokenNotFound is returned when a token ID is unknown.
+	ErrTokenNotFound = errors.New("bootstrap token not found")
+
+	// ErrTokenInvalid is returned when a token cannot be used to join: its secret
+	// does not match, it expired or it has no uses left.
+	ErrTokenInvalid = errors.New("bootstrap token is invalid")
+)
+
+// Token is a bootstrap token as stored by the hivemind. Only a digest of the
+// secret is kept; the secret itself is returned once, when the token is created.
+type Token struct {
+	ID          string            `json:"id"`
+	SecretHash  string            `json:"-"`
+	Description string            `json:"description,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+
+	// MaxUses is the number of nodes that may join with the token; 0 means unlimited.
+	MaxUses int `json:"max_uses"`
+	Uses    int `json:"uses"`
+
+	// ExpiresAt is zero for tokens that never expire.
+	ExpiresAt  time.Time `json:"expires_at,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at,omitempty"`
+}
+
+// Expired reports whether the token expired at the given time.
+func (t *Token) Expired(now time.Time) bool {
+	return !t.ExpiresAt.IsZero() && !now.Before(t.ExpiresAt)
+}
+
+// Exhausted reports whether the token has no uses left.
+func (t *Token) Exhausted() bool {
+	return t.MaxUses > 0 && t.Uses >= t.MaxUses
+}
+
+// HashSecret returns the digest stored in Token.SecretHash.
+func HashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -1,5 +1,9 @@
 package entity
 
+import (
+	"fmt"
+)
+
 type ModelInstance struct {
 	ID          int64
 	Type        ModelType
@@ -49,3 +53,27 @@ func (p ModelClass) String() string {
 	}
 	return "<UNSET>"
 }
+
+func ModelClassFromString(s string) (ModelClass, error) {
+	switch s {
+	case "gpt":
+		return ModelClass_GPT, nil
+	case "qwen":
+		return ModelClass_QWen, nil
+	case "gemini":
+		return ModelClass_Gemini, nil
+	case "deepseek":
+		return ModelClass_DeepSeek, nil
+	case "ollama":
+		return ModelClass_Ollama, nil
+	case "claude":
+		return ModelClass_Claude, nil
+	case "kimi":
+		return ModelClass_Kimi, nil
+	case "glm":
+		return ModelClass_GLM, nil
+	case "other":
+		return ModelClass_Other, nil
+	}
+	return ModelClass(0), fmt.Errorf("not a valid ModelClass string")
+}
@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/jinzhu/copier"
+	entity2 "github.com/kiosk404/eidolon/internal/hivemind/service/llm/domain/entity"
+	"github.com/kiosk404/eidolon/internal/pkg/workspace"
+)
+
+// fileState is the content of the file of a fileRepository.
+type fileState struct {
+	// NextID is the ID of the next model created.
+	NextID int64 `json:"next_id"`
+
+	Models []*entity2.ModelInstance `json:"models"`
+}
+
+type fileRepository struct {
+	path string
+
+	mu     sync.Mutex
+	nextID int64
+	models map[int64]*entity2.ModelInstance
+}
+
+var _ ModelRepository = &fileRepository{}
+
+// NewFileRepository creates a ModelRepository that keeps the models in memory
+// and saves them to the JSON file at path after every change. The file holds
+// the API keys of the models, so it is only readable by its owner. An empty
+// path keeps the models in memory only.
+func NewFileRepository(path string) (ModelRepository, error) {
+	r := &fileRepository{
+		path:   path,
+		nextID: 1,
+		models: make(map[int64]*entity2.ModelInstance),
+	}
+	if path == "" {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return r, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	state := &fileState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for _, m := range state.Models {
+		r.models[m.ID] = m
+		if m.ID >= r.nextID {
+			r.nextID = m.ID + 1
+		}
+	}
+	if state.NextID > r.nextID {
+		r.nextID = state.NextID
+	}
+
+	return r, nil
+}
+
+func (r *fileRepository) Create(_ context.Context, m *entity2.ModelInstance) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, err := cloneModel(m)
+	if err != nil {
+		return 0, err
+	}
+	stored.ID = r.nextID
+	r.models[stored.ID] = stored
+	r.nextID++
+
+	if stored.IsSelected {
+		r.unselectOthers(stored)
+	}
+	if err := r.save(); err != nil {
+		delete(r.models, stored.ID)
+		r.nextID--
+		return 0, err
+	}
+	m.ID = stored.ID
+
+	return stored.ID, nil
+}
+
+func (r *fileRepository) Get(_ context.Context, id int64) (*entity2.ModelInstance, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m, ok := r.models[id]
+	if !ok {
+		return nil, fmt.Errorf("model %d: %w", id, ErrModelNotFound)
+	}
+
+	return cloneModel(m)
+}
+
+func (r *fileRepository) List(_ context.Context) ([]*entity2.ModelInstance, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list := make([]*entity2.ModelInstance, 0, len(r.models))
+	for _, m := range r.sorted() {
+		c, err := cloneModel(m)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, c)
+	}
+
+	return list, nil
+}
+
+func (r *fileRepository) SetDefault(_ context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m, ok := r.models[id]
+	if !ok {
+		return fmt.Errorf("model %d: %w", id, ErrModelNotFound)
+	}
+
+	selected := make(map[int64]bool, len(r.models))
+	for _, o := range r.models {
+		selected[o.ID] = o.IsSelected
+	}
+	m.IsSelected = true
+	r.unselectOthers(m)
+
+	if err := r.save(); err != nil {
+		for _, o := range r.models {
+			o.IsSelected = selected[o.ID]
+		}
+		return err
+	}
+
+	return nil
+}
+
+// unselectOthers unselects the models of the type of m but m; r.mu must be held.
+func (r *fileRepository) unselectOthers(m *entity2.ModelInstance) {
+	for _, o := range r.models {
+		if o.ID != m.ID && o.Type == m.Type {
+			o.IsSelected = false
+		}
+	}
+}
+
+// sorted returns the models by ascending ID; r.mu must be held.
+func (r *fileRepository) sorted() []*entity2.ModelInstance {
+	list := make([]*entity2.ModelInstance, 0, len(r.models))
+	for _, m := range r.models {
+		list = append(list, m)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+
+	return list
+}
+
+// save writes the models to the file; r.mu must be held.
+func (r *fileRepository) save() error {
+	if r.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(&fileState{NextID: r.nextID, Models: r.sorted()}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return workspace.WriteFile(r.path, data, 0o600)
+}
+
+func cloneModel(m *entity2.ModelInstance) (*entity2.ModelInstance, error) {
+	out := &entity2.ModelInstance{}
+	if err := copier.CopyWithOption(out, m, copier.Option{DeepCopy: true}); err != nil {
+		return nil, fmt.Errorf("error copy model: %w", err)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	entity2 "github.com/kiosk404/eidolon/internal/hivemind/service/llm/domain/entity"
+)
+
+func newModel(t entity2.ModelType, name string, selected bool) *entity2.ModelInstance {
+	return &entity2.ModelInstance{
+		Type:        t,
+		DisplayInfo: entity2.DisplayInfo{Name: name},
+		IsSelected:  selected,
+		Connection: entity2.Connection{
+			BaseConnInfo: &entity2.BaseConnectionInfo{Model: name, APIKey: "sk-" + name},
+		},
+		Extra: entity2.ModelExtra{EnableBase64URL: true},
+	}
+}
+
+func TestFileRepositoryReload(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "models.json")
+
+	r, err := NewFileRepository(path)
+	if err != nil {
+		t.Fatalf("NewFileRepository: %v", err)
+	}
+	for _, name := range []string{"a", "b"} {
+		if _, err := r.Create(ctx, newModel(entity2.ModelType_LLM, name, name == "a")); err != nil {
+			t.Fatalf("Create %s: %v", name, err)
+		}
+	}
+	if err := r.SetDefault(ctx, 2); err != nil {
+		t.Fatalf("SetDefault: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("file mode = %v, want 0600", perm)
+	}
+
+	reloaded, err := NewFileRepository(path)
+	if err != nil {
+		t.Fatalf("NewFileRepository reload: %v", err)
+	}
+	models, err := reloaded.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(models) != 2 {
+		t.Fatalf("reloaded %d models, want 2", len(models))
+	}
+	for i, m := range models {
+		if m.ID != int64(i+1) {
+			t.Errorf("model %d has ID %d, want %d", i, m.ID, i+1)
+		}
+		if m.IsSelected != (m.ID == 2) {
+			t.Errorf("model %d selected = %v after reload", m.ID, m.IsSelected)
+		}
+		if m.Connection.BaseConnInfo == nil || m.Connection.BaseConnInfo.APIKey != "sk-"+m.DisplayInfo.Name {
+			t.Errorf("model %d connection = %+v after reload", m.ID, m.Connection.BaseConnInfo)
+		}
+		if !m.Extra.EnableBase64URL {
+			t.Errorf("model %d lost its extra settings after reload", m.ID)
+		}
+	}
+
+	id, err := reloaded.Create(ctx, newModel(entity2.ModelType_LLM, "c", false))
+	if err != nil {
+		t.Fatalf("Create after reload: %v", err)
+	}
+	if id != 3 {
+		t.Errorf("ID after reload = %d, want 3", id)
+	}
+}
+
+func TestFileRepositoryMissingFile(t *testing.T) {
+	r, err := NewFileRepository(filepath.Join(t.TempDir(), "missing", "models.json"))
+	if err != nil {
+		t.Fatalf("NewFileRepository: %v", err)
+	}
+	models, err := r.List(context.Background())
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(models) != 0 {
+		t.Errorf("got %d models, want none", len(models))
+	}
+}
+
+func TestFileRepositoryCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "models.json")
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewFileRepository(path); err == nil {
+		t.Error("a corrupt file was accepted")
+	}
+}
+
+func TestFileRepositorySingleDefaultPerType(t *testing.T) {
+	ctx := context.Background()
+	r, err := NewFileRepository("")
+	if err != nil {
+		t.Fatalf("NewFileRepository: %v", err)
+	}
+
+	llm1, _ := r.Create(ctx, newModel(entity2.ModelType_LLM, "llm1", true))
+	emb, _ := r.Create(ctx, newModel(entity2.ModelType_TextEmbedding, "emb", true))
+	llm2, _ := r.Create(ctx, newModel(entity2.ModelType_LLM, "llm2", true))
+
+	selected := func() map[int64]bool {
+		models, err := r.List(ctx)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		s := make(map[int64]bool, len(models))
+		for _, m := range models {
+			s[m.ID] = m.IsSelected
+		}
+		return s
+	}
+
+	if s := selected(); s[llm1] || !s[llm2] || !s[emb] {
+		t.Errorf("after create selected = %v, want llm %d and embedding %d", s, llm2, emb)
+	}
+
+	if err := r.SetDefault(ctx, llm1); err != nil {
+		t.Fatalf("SetDefault: %v", err)
+	}
+	if s := selected(); !s[llm1] || s[llm2] || !s[emb] {
+		t.Errorf("after SetDefault selected = %v, want llm %d and embedding %d", s, llm1, emb)
+	}
+
+	if err := r.SetDefault(ctx, 42); !errors.Is(err, ErrModelNotFound) {
+		t.Errorf("SetDefault(42) error = %v, want %v", err, ErrModelNotFound)
+	}
+}
+
+func TestFileRepositoryReturnsCopies(t *testing.T) {
+	ctx := context.Background()
+	r, err := NewFileRepository("")
+	if err != nil {
+		t.Fatalf("NewFileRepository: %v", err)
+	}
+
+	m := newModel(entity2.ModelType_LLM, "a", false)
+	id, err := r.Create(ctx, m)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if m.ID != id {
+		t.Errorf("Create set ID %d on the model, want %d", m.ID, id)
+	}
+	m.Connection.BaseConnInfo.APIKey = "changed"
+
+	got, err := r.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	got.DisplayInfo.Name = "changed"
+
+	again, err := r.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if again.Connection.BaseConnInfo.APIKey != "sk-a" || again.DisplayInfo.Name != "a" {
+		t.Errorf("stored model was changed through a copy: %+v", again)
+	}
+}
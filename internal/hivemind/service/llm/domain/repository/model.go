@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	entity2 "github.com/kiosk404/eidolon/internal/hivemind/service/llm/domain/entity"
+)
+
+// ErrModelNotFound is returned when a model ID is unknown to the repository.
+var ErrModelNotFound = errors.New("model not found")
+
+// ModelRepository persists the model instances of the hivemind.
+type ModelRepository interface {
+	// Create stores m under a new ID, which is set on m and returned.
+	Create(ctx context.Context, m *entity2.ModelInstance) (int64, error)
+
+	Get(ctx context.Context, id int64) (*entity2.ModelInstance, error)
+
+	// List returns every model, by ascending ID.
+	List(ctx context.Context) ([]*entity2.ModelInstance, error)
+
+	// SetDefault selects model id and unselects every other model of its type,
+	// so that a type has a single default model.
+	SetDefault(ctx context.Context, id int64) error
+}
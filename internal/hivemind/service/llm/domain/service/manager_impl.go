@@ -1,5 +1,215 @@
 package service
 
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jinzhu/copier"
+	entity2 "github.com/kiosk404/eidolon/internal/hivemind/service/llm/domain/entity"
+	"github.com/kiosk404/eidolon/internal/hivemind/service/llm/domain/repository"
+	"github.com/kiosk404/eidolon/internal/pkg"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// ErrNoDefaultModel is returned when no model of a type is selected as its default.
+var ErrNoDefaultModel = errors.New("no default model")
+
 type modelManageImpl struct {
-	ModelMeta *ModelMetaConf
+	ModelMeta ModelMetaSource
+
+	repo repository.ModelRepository
+}
+
+var _ ModelManager = &modelManageImpl{}
+
+// NewModelManager creates a ModelManager storing models in repo. New models
+// take their defaults from meta, which may be nil.
+func NewModelManager(repo repository.ModelRepository, meta ModelMetaSource) ModelManager {
+	return &modelManageImpl{ModelMeta: meta, repo: repo}
+}
+
+// CreateLLMModel stores a LLM of modelClass reached through conn. The display
+// information, capabilities, parameters and unset connection and extra
+// settings come from the model meta of the class and model name, or of the
+// default model of the class. The first LLM becomes the default one.
+func (m *modelManageImpl) CreateLLMModel(ctx context.Context, modelClass entity2.ModelClass, modelShowName string,
+	conn *entity2.Connection, extra *entity2.ModelExtra,
+) (int64, error) {
+	if modelClass.String() == "<UNSET>" {
+		return 0, fmt.Errorf("unknown model class %d", modelClass)
+	}
+	if conn == nil || conn.BaseConnInfo == nil || conn.BaseConnInfo.Model == "" {
+		return 0, fmt.Errorf("the connection must name the model")
+	}
+
+	meta := m.modelMeta(modelClass, conn.BaseConnInfo.Model)
+	instance := &entity2.ModelInstance{
+		Type: entity2.ModelType_LLM,
+		Provider: entity2.ModelProvider{
+			Name:       &entity2.I18nText{ZhCn: modelClass.String(), EnUs: modelClass.String()},
+			ModelClass: modelClass,
+		},
+		Extra: entity2.ModelExtra{EnableBase64URL: meta.EnableBase64URL},
+	}
+	if meta.DisplayInfo != nil {
+		instance.DisplayInfo = *meta.DisplayInfo
+	}
+	instance.DisplayInfo.Name = modelShowName
+	if instance.DisplayInfo.Name == "" {
+		instance.DisplayInfo.Name = conn.BaseConnInfo.Model
+	}
+	if meta.Capability != nil {
+		instance.Capability = *meta.Capability
+	}
+	for _, p := range meta.Parameters {
+		if p != nil {
+			instance.Parameters = append(instance.Parameters, *p)
+		}
+	}
+	// The settings the caller leaves unset keep the value of the model meta.
+	if extra != nil {
+		if err := copier.CopyWithOption(&instance.Extra, extra, copier.Option{IgnoreEmpty: true}); err != nil {
+			return 0, fmt.Errorf("error copy model extra: %w", err)
+		}
+	}
+
+	connection, err := mergeConnection(conn, meta.Connection)
+	if err != nil {
+		return 0, err
+	}
+	instance.Connection = *connection
+
+	if _, err := m.GetDefaultModel(ctx); errors.Is(err, ErrNoDefaultModel) {
+		instance.IsSelected = true
+	} else if err != nil {
+		return 0, err
+	}
+
+	id, err := m.repo.Create(ctx, instance)
+	if err != nil {
+		return 0, err
+	}
+	logger.InfoX(pkg.LLMModel, "model %d created: %s %s", id, modelClass, conn.BaseConnInfo.Model)
+
+	return id, nil
+}
+
+func (m *modelManageImpl) GetModelByID(ctx context.Context, id int64) (*entity2.ModelInstance, error) {
+	return m.repo.Get(ctx, id)
+}
+
+// GetDefaultModel returns the default LLM.
+func (m *modelManageImpl) GetDefaultModel(ctx context.Context) (*entity2.ModelInstance, error) {
+	models, err := m.ListModelByType(ctx, entity2.ModelType_LLM, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, model := range models {
+		if model.IsSelected {
+			return model, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s: %w", entity2.ModelType_LLM, ErrNoDefaultModel)
+}
+
+// SetDefaultModel makes model id the default model of its type.
+func (m *modelManageImpl) SetDefaultModel(ctx context.Context, id int64) error {
+	if err := m.repo.SetDefault(ctx, id); err != nil {
+		return err
+	}
+	logger.InfoX(pkg.LLMModel, "model %d is the default model of its type", id)
+
+	return nil
+}
+
+// ListModelByType returns at most limit models of modelType by ascending ID,
+// every one of them when limit is not positive.
+func (m *modelManageImpl) ListModelByType(ctx context.Context, modelType entity2.ModelType, limit int) ([]*entity2.ModelInstance, error) {
+	models, err := m.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*entity2.ModelInstance, 0, len(models))
+	for _, model := range models {
+		if model.Type != modelType {
+			continue
+		}
+		if limit > 0 && len(list) == limit {
+			break
+		}
+		list = append(list, model)
+	}
+
+	return list, nil
+}
+
+func (m *modelManageImpl) ListAllModelList(ctx context.Context) ([]*entity2.ModelInstance, error) {
+	return m.repo.List(ctx)
+}
+
+// modelMeta returns the model meta of modelName, an empty one when there is
+// none.
+func (m *modelManageImpl) modelMeta(modelClass entity2.ModelClass, modelName string) *ModelMeta {
+	if m.ModelMeta == nil {
+		return &ModelMeta{}
+	}
+
+	meta, err := m.ModelMeta.GetModelMeta(modelClass, modelName)
+	if err != nil || meta == nil {
+		logger.WarnX(pkg.LLMModel, "no model meta for model class %v and model name %v, using empty defaults", modelClass, modelName)
+		return &ModelMeta{}
+	}
+
+	return meta
+}
+
+// mergeConnection returns a copy of conn whose unset settings are taken from
+// defaults, which may be nil.
+func mergeConnection(conn, defaults *entity2.Connection) (*entity2.Connection, error) {
+	out := &entity2.Connection{}
+	if err := copier.CopyWithOption(out, conn, copier.Option{DeepCopy: true}); err != nil {
+		return nil, fmt.Errorf("error copy connection: %w", err)
+	}
+	if defaults == nil {
+		return out, nil
+	}
+	d := &entity2.Connection{}
+	if err := copier.CopyWithOption(d, defaults, copier.Option{DeepCopy: true}); err != nil {
+		return nil, fmt.Errorf("error copy connection: %w", err)
+	}
+
+	if base := d.BaseConnInfo; base != nil {
+		if out.BaseConnInfo.BaseURL == "" {
+			out.BaseConnInfo.BaseURL = base.BaseURL
+		}
+		if out.BaseConnInfo.APIKey == "" {
+			out.BaseConnInfo.APIKey = base.APIKey
+		}
+		if out.BaseConnInfo.ThinkingType == entity2.ThinkingType_Default {
+			out.BaseConnInfo.ThinkingType = base.ThinkingType
+		}
+	}
+	if out.Openai == nil {
+		out.Openai = d.Openai
+	}
+	if out.Deepseek == nil {
+		out.Deepseek = d.Deepseek
+	}
+	if out.Gemini == nil {
+		out.Gemini = d.Gemini
+	}
+	if out.Qwen == nil {
+		out.Qwen = d.Qwen
+	}
+	if out.Ollama == nil {
+		out.Ollama = d.Ollama
+	}
+	if out.Claude == nil {
+		out.Claude = d.Claude
+	}
+
+	return out, nil
 }
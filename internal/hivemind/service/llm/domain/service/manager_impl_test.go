@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	entity2 "github.com/kiosk404/eidolon/internal/hivemind/service/llm/domain/entity"
+	"github.com/kiosk404/eidolon/internal/hivemind/service/llm/domain/repository"
+)
+
+func testModelMeta() *ModelMetaConf {
+	return &ModelMetaConf{Provider2Models: map[string]map[string]ModelMeta{
+		entity2.ModelClass_GPT.String(): {
+			"gpt-4o": {
+				DisplayInfo: &entity2.DisplayInfo{Name: "GPT-4o", MaxTokens: 128000},
+				Capability:  &entity2.ModelAbility{FunctionCall: true, ImageUnderstanding: true},
+				Connection: &entity2.Connection{
+					BaseConnInfo: &entity2.BaseConnectionInfo{BaseURL: "https://api.openai.com/v1"},
+					Openai:       &entity2.OpenAIConnInfo{},
+				},
+				Parameters:      []*entity2.ModelParameter{{Name: "temperature", Type: entity2.ModelParamType_Float}},
+				EnableBase64URL: true,
+			},
+			"default": {
+				DisplayInfo: &entity2.DisplayInfo{MaxTokens: 8192},
+			},
+		},
+	}}
+}
+
+func newTestManager(t *testing.T) ModelManager {
+	t.Helper()
+
+	repo, err := repository.NewFileRepository("")
+	if err != nil {
+		t.Fatalf("NewFileRepository: %v", err)
+	}
+
+	return NewModelManager(repo, testModelMeta())
+}
+
+func gptConn(model string) *entity2.Connection {
+	return &entity2.Connection{BaseConnInfo: &entity2.BaseConnectionInfo{Model: model, APIKey: "sk-test"}}
+}
+
+func TestCreateLLMModelMergesMeta(t *testing.T) {
+	ctx := context.Background()
+	m := newTestManager(t)
+
+	id, err := m.CreateLLMModel(ctx, entity2.ModelClass_GPT, "", gptConn("gpt-4o"), nil)
+	if err != nil {
+		t.Fatalf("CreateLLMModel: %v", err)
+	}
+	got, err := m.GetModelByID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetModelByID: %v", err)
+	}
+
+	if got.DisplayInfo.Name != "gpt-4o" || got.DisplayInfo.MaxTokens != 128000 {
+		t.Errorf("display info = %+v, want the one of the meta named after the model", got.DisplayInfo)
+	}
+	if !got.Capability.FunctionCall || !got.Capability.ImageUnderstanding {
+		t.Errorf("capability = %+v, want the one of the meta", got.Capability)
+	}
+	if len(got.Parameters) != 1 || got.Parameters[0].Name != "temperature" {
+		t.Errorf("parameters = %+v, want the ones of the meta", got.Parameters)
+	}
+	if got.Connection.BaseConnInfo.BaseURL != "https://api.openai.com/v1" {
+		t.Errorf("base URL = %q, want the one of the meta", got.Connection.BaseConnInfo.BaseURL)
+	}
+	if got.Connection.BaseConnInfo.APIKey != "sk-test" {
+		t.Errorf("API key = %q, want the one of the connection", got.Connection.BaseConnInfo.APIKey)
+	}
+	if got.Connection.Openai == nil {
+		t.Error("the OpenAI settings of the meta are missing")
+	}
+	if !got.Extra.EnableBase64URL {
+		t.Error("EnableBase64URL of the meta is lost")
+	}
+}
+
+func TestCreateLLMModelKeepsMetaExtra(t *testing.T) {
+	ctx := context.Background()
+	m := newTestManager(t)
+
+	id, err := m.CreateLLMModel(ctx, entity2.ModelClass_GPT, "mine", gptConn("gpt-4o"), &entity2.ModelExtra{})
+	if err != nil {
+		t.Fatalf("CreateLLMModel: %v", err)
+	}
+	got, err := m.GetModelByID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetModelByID: %v", err)
+	}
+
+	if !got.Extra.EnableBase64URL {
+		t.Error("an empty extra overrode EnableBase64URL of the meta")
+	}
+	if got.DisplayInfo.Name != "mine" {
+		t.Errorf("name = %q, want mine", got.DisplayInfo.Name)
+	}
+}
+
+func TestCreateLLMModelDefaultMeta(t *testing.T) {
+	ctx := context.Background()
+	m := newTestManager(t)
+
+	id, err := m.CreateLLMModel(ctx, entity2.ModelClass_GPT, "", gptConn("gpt-unknown"),
+		&entity2.ModelExtra{EnableBase64URL: true})
+	if err != nil {
+		t.Fatalf("CreateLLMModel: %v", err)
+	}
+	got, err := m.GetModelByID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetModelByID: %v", err)
+	}
+
+	if got.DisplayInfo.Name != "gpt-unknown" || got.DisplayInfo.MaxTokens != 8192 {
+		t.Errorf("display info = %+v, want the default meta named after the model", got.DisplayInfo)
+	}
+	if !got.Extra.EnableBase64URL {
+		t.Error("EnableBase64URL of the caller is lost")
+	}
+}
+
+func TestCreateLLMModelAssignsIDs(t *testing.T) {
+	ctx := context.Background()
+	m := newTestManager(t)
+
+	for want := int64(1); want <= 3; want++ {
+		id, err := m.CreateLLMModel(ctx, entity2.ModelClass_GPT, "", gptConn("gpt-4o"), nil)
+		if err != nil {
+			t.Fatalf("CreateLLMModel: %v", err)
+		}
+		if id != want {
+			t.Errorf("id = %d, want %d", id, want)
+		}
+	}
+
+	if _, err := m.GetModelByID(ctx, 4); !errors.Is(err, repository.ErrModelNotFound) {
+		t.Errorf("GetModelByID(4) error = %v, want %v", err, repository.ErrModelNotFound)
+	}
+}
+
+func TestCreateLLMModelRejectsInvalid(t *testing.T) {
+	ctx := context.Background()
+	m := newTestManager(t)
+
+	if _, err := m.CreateLLMModel(ctx, entity2.ModelClass(99), "", gptConn("gpt-4o"), nil); err == nil {
+		t.Error("an unknown model class was accepted")
+	}
+	if _, err := m.CreateLLMModel(ctx, entity2.ModelClass_GPT, "", gptConn(""), nil); err == nil {
+		t.Error("a connection without a model was accepted")
+	}
+}
+
+func TestDefaultModel(t *testing.T) {
+	ctx := context.Background()
+	m := newTestManager(t)
+
+	if _, err := m.GetDefaultModel(ctx); !errors.Is(err, ErrNoDefaultModel) {
+		t.Fatalf("GetDefaultModel error = %v, want %v", err, ErrNoDefaultModel)
+	}
+
+	first, err := m.CreateLLMModel(ctx, entity2.ModelClass_GPT, "", gptConn("gpt-4o"), nil)
+	if err != nil {
+		t.Fatalf("CreateLLMModel: %v", err)
+	}
+	second, err := m.CreateLLMModel(ctx, entity2.ModelClass_GPT, "", gptConn("gpt-4o-mini"), nil)
+	if err != nil {
+		t.Fatalf("CreateLLMModel: %v", err)
+	}
+	assertDefault(t, m, first)
+
+	if err := m.SetDefaultModel(ctx, second); err != nil {
+		t.Fatalf("SetDefaultModel: %v", err)
+	}
+	assertDefault(t, m, second)
+
+	if err := m.SetDefaultModel(ctx, 42); !errors.Is(err, repository.ErrModelNotFound) {
+		t.Errorf("SetDefaultModel(42) error = %v, want %v", err, repository.ErrModelNotFound)
+	}
+	assertDefault(t, m, second)
+}
+
+// assertDefault checks that model id is the single default LLM.
+func assertDefault(t *testing.T, m ModelManager, id int64) {
+	t.Helper()
+
+	models, err := m.ListModelByType(context.Background(), entity2.ModelType_LLM, 0)
+	if err != nil {
+		t.Fatalf("ListModelByType: %v", err)
+	}
+	for _, model := range models {
+		if model.IsSelected != (model.ID == id) {
+			t.Errorf("model %d selected = %v, want %v", model.ID, model.IsSelected, model.ID == id)
+		}
+	}
+
+	def, err := m.GetDefaultModel(context.Background())
+	if err != nil {
+		t.Fatalf("GetDefaultModel: %v", err)
+	}
+	if def.ID != id {
+		t.Errorf("default model = %d, want %d", def.ID, id)
+	}
+}
@@ -1,32 +1,101 @@
 package service
 
 import (
-	"context"
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/jinzhu/copier"
 	entity2 "github.com/kiosk404/eidolon/internal/hivemind/service/llm/domain/entity"
 	"github.com/kiosk404/eidolon/internal/pkg"
-	"github.com/kiosk404/eidolon/internal/pkg/options"
 	"github.com/kiosk404/eidolon/pkg/logger"
+	"go.yaml.in/yaml/v3"
 )
 
+// defaultModelMetaKey names the model meta of a model class used for the
+// models that have none of their own.
+const defaultModelMetaKey = "default"
+
+// ModelMetaSource looks up the model meta of a model.
+type ModelMetaSource interface {
+	GetModelMeta(modelClass entity2.ModelClass, modelName string) (*ModelMeta, error)
+}
+
 type ModelMetaConf struct {
 	Provider2Models map[string]map[string]ModelMeta `thrift:"provider2models,2" form:"provider2models" json:"provider2models" query:"provider2models"`
 }
 
 type ModelMeta entity2.ModelMeta
 
-var modelMetaConf *ModelMetaConf
+var _ ModelMetaSource = &ModelMetaConf{}
+
+// LoadModelMetaConf reads and validates the model meta catalog in path, a
+// YAML file when its extension is .yaml or .yml and a JSON file otherwise.
+// The catalog maps every model class, like: gpt, to the meta of its models by
+// name, plus a "default" entry for the other models of the class.
+func LoadModelMetaConf(path string) (*ModelMetaConf, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read model meta %s: %w", path, err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		// The entities only carry json tags, decode YAML through JSON.
+		var raw interface{}
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("parse model meta %s: %w", path, err)
+		}
+		if data, err = json.Marshal(raw); err != nil {
+			return nil, fmt.Errorf("parse model meta %s: %w", path, err)
+		}
+	}
 
-func initModelCOnf(ctx context.Context, options *options.ModelOptions) (*ModelMetaConf, error) {
-	if modelMetaConf != nil {
-		return modelMetaConf, nil
+	conf := &ModelMetaConf{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(conf); err != nil {
+		return nil, fmt.Errorf("parse model meta %s: %w", path, err)
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid model meta %s: %w", path, err)
 	}
-	return nil, nil
+
+	return conf, nil
+}
+
+// Validate checks that every model class is known and has a default entry,
+// and that the parameters of every model are consistent.
+func (c *ModelMetaConf) Validate() error {
+	var errs []error
+	for class, models := range c.Provider2Models {
+		if _, err := entity2.ModelClassFromString(class); err != nil {
+			errs = append(errs, fmt.Errorf("unknown model class %q", class))
+			continue
+		}
+		if _, ok := models[defaultModelMetaKey]; !ok {
+			errs = append(errs, fmt.Errorf("model class %q has no %q model", class, defaultModelMetaKey))
+		}
+		for name, meta := range models {
+			if err := validateModelParameters(meta.Parameters); err != nil {
+				errs = append(errs, fmt.Errorf("model %s/%s: %w", class, name, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
 }
 
 func (c *ModelMetaConf) GetModelMeta(modelClass entity2.ModelClass, modelName string) (*ModelMeta, error) {
+	if c == nil {
+		return nil, fmt.Errorf("model meta not found for model class %v", modelClass)
+	}
 	modelName2Meta, ok := c.Provider2Models[modelClass.String()]
 	if !ok {
 		return nil, fmt.Errorf("model meta not found for model class %v", modelClass)
@@ -38,8 +107,7 @@ func (c *ModelMetaConf) GetModelMeta(modelClass entity2.ModelClass, modelName st
 		return deepCopyModelMeta(&modelMeta)
 	}
 
-	const defaultKey = "default"
-	modelMeta, ok = modelName2Meta[defaultKey]
+	modelMeta, ok = modelName2Meta[defaultModelMetaKey]
 	if ok {
 		logger.InfoX(pkg.LLMModel, "use default model meta for model class %v and model name %v", modelClass, modelName)
 		return deepCopyModelMeta(&modelMeta)
@@ -60,3 +128,147 @@ func deepCopyModelMeta(meta *ModelMeta) (*ModelMeta, error) {
 
 	return newObj, nil
 }
+
+// validateModelParameters checks that the parameters have unique names and a
+// known type, that numeric bounds parse and are ordered, and that the default
+// values fit the bounds, the precision and the options.
+func validateModelParameters(params []*entity2.ModelParameter) error {
+	var errs []error
+	seen := make(map[string]bool, len(params))
+	for i, p := range params {
+		if p == nil {
+			errs = append(errs, fmt.Errorf("parameter %d is empty", i))
+			continue
+		}
+		if p.Name == "" {
+			errs = append(errs, fmt.Errorf("parameter %d has no name", i))
+			continue
+		}
+		if seen[p.Name] {
+			errs = append(errs, fmt.Errorf("parameter %q is declared twice", p.Name))
+			continue
+		}
+		seen[p.Name] = true
+		if err := validateModelParameter(p); err != nil {
+			errs = append(errs, fmt.Errorf("parameter %q: %w", p.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateModelParameter(p *entity2.ModelParameter) error {
+	if p.Type.String() == "<UNSET>" {
+		return fmt.Errorf("unknown type %d", p.Type)
+	}
+	if p.Precision < 0 {
+		return fmt.Errorf("precision %d is negative", p.Precision)
+	}
+	if p.Precision > 0 && p.Type != entity2.ModelParamType_Float {
+		return fmt.Errorf("precision is only allowed for Float parameters")
+	}
+
+	numeric := p.Type == entity2.ModelParamType_Float || p.Type == entity2.ModelParamType_Int
+	if !numeric && (p.Min != "" || p.Max != "") {
+		return fmt.Errorf("min and max are only allowed for Float and Int parameters")
+	}
+	lower, upper := math.Inf(-1), math.Inf(1)
+	if p.Min != "" {
+		v, err := parseModelParamValue(p, p.Min)
+		if err != nil {
+			return fmt.Errorf("min: %w", err)
+		}
+		lower = v
+	}
+	if p.Max != "" {
+		v, err := parseModelParamValue(p, p.Max)
+		if err != nil {
+			return fmt.Errorf("max: %w", err)
+		}
+		upper = v
+	}
+	if lower > upper {
+		return fmt.Errorf("min %s is greater than max %s", p.Min, p.Max)
+	}
+
+	options := make(map[string]bool, len(p.Options))
+	for i, o := range p.Options {
+		if o == nil || o.Value == "" {
+			return fmt.Errorf("option %d has no value", i)
+		}
+		if options[o.Value] {
+			return fmt.Errorf("option %q is declared twice", o.Value)
+		}
+		options[o.Value] = true
+		if _, err := parseModelParamValue(p, o.Value); err != nil {
+			return fmt.Errorf("option %q: %w", o.Value, err)
+		}
+	}
+
+	if p.DefaultVal == nil {
+		return nil
+	}
+	defaults := []struct{ name, value string }{
+		{"default_val", p.DefaultVal.DefaultVal},
+		{"creative", p.DefaultVal.Creative},
+		{"balance", p.DefaultVal.Balance},
+		{"precise", p.DefaultVal.Precise},
+	}
+	for _, d := range defaults {
+		if d.value == "" {
+			continue
+		}
+		v, err := parseModelParamValue(p, d.value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", d.name, err)
+		}
+		if len(options) > 0 && !options[d.value] {
+			return fmt.Errorf("%s %q is not one of the options", d.name, d.value)
+		}
+		if !numeric {
+			continue
+		}
+		if v < lower || v > upper {
+			return fmt.Errorf("%s %s is out of [%g, %g]", d.name, d.value, lower, upper)
+		}
+		if p.Type == entity2.ModelParamType_Float && decimals(d.value) > int(p.Precision) {
+			return fmt.Errorf("%s %s has more than %d decimals", d.name, d.value, p.Precision)
+		}
+	}
+
+	return nil
+}
+
+// parseModelParamValue checks that s is a value of the type of p and returns
+// it as a number for Float and Int parameters.
+func parseModelParamValue(p *entity2.ModelParameter, s string) (float64, error) {
+	switch p.Type {
+	case entity2.ModelParamType_Float:
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a float", s)
+		}
+		return v, nil
+	case entity2.ModelParamType_Int:
+		v, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not an integer", s)
+		}
+		return float64(v), nil
+	case entity2.ModelParamType_Boolean:
+		if _, err := strconv.ParseBool(s); err != nil {
+			return 0, fmt.Errorf("%q is not a boolean", s)
+		}
+	}
+
+	return 0, nil
+}
+
+// decimals returns the number of digits after the decimal point of s.
+func decimals(s string) int {
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(strings.TrimRight(s[i+1:], "0"))
+	}
+
+	return 0
+}
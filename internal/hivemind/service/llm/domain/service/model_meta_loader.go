@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	entity2 "github.com/kiosk404/eidolon/internal/hivemind/service/llm/domain/entity"
+	"github.com/kiosk404/eidolon/internal/pkg"
+	"github.com/kiosk404/eidolon/internal/pkg/options"
+	"github.com/kiosk404/eidolon/pkg/logger"
+	"github.com/kiosk404/eidolon/pkg/utils/fswatch"
+)
+
+// ModelMetaLoader keeps the model meta catalog in memory and reloads it
+// whenever the file changes on disk. A catalog that fails to load or to
+// validate is logged and the previous one is kept.
+type ModelMetaLoader struct {
+	path string
+
+	mu   sync.RWMutex
+	conf *ModelMetaConf
+}
+
+var _ ModelMetaSource = &ModelMetaLoader{}
+
+// NewModelMetaLoader loads the model meta catalog referenced by opts. It
+// returns nil when no catalog is configured.
+func NewModelMetaLoader(opts *options.ModelOptions) (*ModelMetaLoader, error) {
+	if opts == nil || opts.MetaFile == "" {
+		return nil, nil
+	}
+
+	conf, err := LoadModelMetaConf(opts.MetaFile)
+	if err != nil {
+		return nil, err
+	}
+	logger.InfoX(pkg.LLMModel, "loaded model meta of %d model classes from %s", len(conf.Provider2Models), opts.MetaFile)
+
+	return &ModelMetaLoader{path: opts.MetaFile, conf: conf}, nil
+}
+
+// Conf returns the current catalog, nil when l is nil.
+func (l *ModelMetaLoader) Conf() *ModelMetaConf {
+	if l == nil {
+		return nil
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.conf
+}
+
+// GetModelMeta looks modelName up in the current catalog.
+func (l *ModelMetaLoader) GetModelMeta(modelClass entity2.ModelClass, modelName string) (*ModelMeta, error) {
+	return l.Conf().GetModelMeta(modelClass, modelName)
+}
+
+// Run watches the catalog and reloads it on change until ctx is done. The
+// parent directory is watched so that editors saving through a rename and
+// config map updates are noticed too.
+func (l *ModelMetaLoader) Run(ctx context.Context) error {
+	return fswatch.Watch(ctx, []string{l.path}, fswatch.DefaultDebounce, l.reload, func(err error) {
+		logger.WarnX(pkg.LLMModel, "watch model meta failed: %s", err.Error())
+	})
+}
+
+func (l *ModelMetaLoader) reload() {
+	conf, err := LoadModelMetaConf(l.path)
+	if err != nil {
+		logger.ErrorX(pkg.LLMModel, "reload model meta failed, keeping the previous one: %s", err.Error())
+		return
+	}
+	l.mu.Lock()
+	l.conf = conf
+	l.mu.Unlock()
+	logger.InfoX(pkg.LLMModel, "reloaded model meta of %d model classes from %s", len(conf.Provider2Models), l.path)
+}
@@ -0,0 +1,240 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kiosk404/eidolon/internal/hivemind/service/scheduler"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+const logModule = "registry"
+
+var (
+	// ErrNodeDraining is returned when a drain is requested for a node that is already draining.
+	ErrNodeDraining = errors.New("node is already draining")
+
+	// ErrNodeBusy is returned when a node with assigned or running tasks is deleted without force.
+	ErrNodeBusy = errors.New("node still has running tasks")
+)
+
+// DrainOptions controls how a node is drained.
+type DrainOptions struct {
+	// Timeout bounds how long the drain waits for running tasks; 0 means wait forever.
+	Timeout time.Duration
+
+	// Force cancels the tasks still running when Timeout expires and deregisters
+	// the node anyway. Without it the node stays cordoned and registered.
+	Force bool
+}
+
+// Drainer cordons nodes, waits for their running tasks to finish and then
+// deregisters them.
+type Drainer struct {
+	registry  Registry
+	scheduler scheduler.Scheduler
+
+	// PollInterval is how often the running tasks of a draining node are checked.
+	PollInterval time.Duration
+
+	mu     sync.Mutex
+	active map[string]*drain
+	wg     sync.WaitGroup
+}
+
+// drain is a drain in progress.
+type drain struct {
+	cancel context.CancelFunc
+}
+
+// NewDrainer creates a Drainer.
+func NewDrainer(registry Registry, sched scheduler.Scheduler) *Drainer {
+	return &Drainer{
+		registry:     registry,
+		scheduler:    sched,
+		PollInterval: time.Second,
+		active:       make(map[string]*drain),
+	}
+}
+
+// Cordon marks a node as (un)schedulable. Uncordoning a draining node aborts the drain.
+func (d *Drainer) Cordon(ctx context.Context, nodeID string, cordoned bool) (*scheduler.GolemProfile, error) {
+	if !cordoned {
+		d.abort(nodeID)
+	}
+
+	return d.registry.Update(ctx, nodeID, func(p *scheduler.GolemProfile) {
+		p.Cordoned = cordoned
+		if !cordoned {
+			p.Draining = false
+		}
+	})
+}
+
+// Drain cordons the node and returns immediately; the node is deregistered in
+// the background once it has no assigned or running tasks left.
+func (d *Drainer) Drain(ctx context.Context, nodeID string, opts DrainOptions) (*scheduler.GolemProfile, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.active[nodeID]; ok {
+		return nil, fmt.Errorf("registry: node %q: %w", nodeID, ErrNodeDraining)
+	}
+
+	profile, err := d.registry.Update(ctx, nodeID, func(p *scheduler.GolemProfile) {
+		p.Cordoned = true
+		p.Draining = true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		drainCtx context.Context
+		cancel   context.CancelFunc
+	)
+	if opts.Timeout > 0 {
+		drainCtx, cancel = context.WithTimeout(context.Background(), opts.Timeout)
+	} else {
+		drainCtx, cancel = context.WithCancel(context.Background())
+	}
+	dr := &drain{cancel: cancel}
+	d.active[nodeID] = dr
+
+	d.wg.Add(1)
+	go d.run(drainCtx, nodeID, dr, opts)
+
+	return profile, nil
+}
+
+// Delete deregisters a node right away, aborting a drain in progress. A node
+// with assigned or running tasks is only deleted with force, which cancels
+// those tasks.
+func (d *Drainer) Delete(ctx context.Context, nodeID string, force bool) error {
+	if _, err := d.registry.GetProfile(ctx, nodeID); err != nil {
+		return err
+	}
+
+	remaining, err := d.activeTasks(nodeID)
+	if err != nil {
+		return err
+	}
+	if len(remaining) > 0 && !force {
+		return fmt.Errorf("registry: node %q has %d tasks: %w", nodeID, len(remaining), ErrNodeBusy)
+	}
+
+	d.abort(nodeID)
+	for _, id := range remaining {
+		if err := d.scheduler.Cancel(ctx, id); err != nil && !errors.Is(err, scheduler.ErrTaskFinished) {
+			logger.WarnX(logModule, "delete node %s: cancel task %s failed: %s", nodeID, id, err.Error())
+		}
+	}
+
+	return d.registry.Deregister(ctx, nodeID)
+}
+
+// Stop aborts every drain in progress and waits for them to exit.
+func (d *Drainer) Stop() {
+	d.mu.Lock()
+	for _, dr := range d.active {
+		dr.cancel()
+	}
+	d.mu.Unlock()
+
+	d.wg.Wait()
+}
+
+// abort cancels the drain in progress for the node, if any.
+func (d *Drainer) abort(nodeID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if dr, ok := d.active[nodeID]; ok {
+		dr.cancel()
+		delete(d.active, nodeID)
+	}
+}
+
+// finish releases dr unless it was already replaced by a newer drain of the same node.
+func (d *Drainer) finish(nodeID string, dr *drain) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	dr.cancel()
+	if d.active[nodeID] == dr {
+		delete(d.active, nodeID)
+	}
+}
+
+func (d *Drainer) run(ctx context.Context, nodeID string, dr *drain, opts DrainOptions) {
+	defer d.wg.Done()
+	defer d.finish(nodeID, dr)
+
+	ticker := time.NewTicker(d.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		remaining, err := d.activeTasks(nodeID)
+		if err != nil {
+			logger.ErrorX(logModule, "drain node %s: list tasks failed: %s", nodeID, err.Error())
+		} else if len(remaining) == 0 {
+			d.deregister(nodeID)
+			return
+		}
+
+		select {
+		case <-ticker.C:
+			continue
+		case <-ctx.Done():
+		}
+
+		if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			// Aborted by uncordon or shutdown.
+			return
+		}
+		if !opts.Force {
+			logger.WarnX(logModule, "drain node %s timed out with %d tasks still running, node stays cordoned",
+				nodeID, len(remaining))
+			_, _ = d.registry.Update(context.Background(), nodeID, func(p *scheduler.GolemProfile) {
+				p.Draining = false
+			})
+			return
+		}
+
+		for _, id := range remaining {
+			if err := d.scheduler.Cancel(context.Background(), id); err != nil {
+				logger.WarnX(logModule, "drain node %s: cancel task %s failed: %s", nodeID, id, err.Error())
+			}
+		}
+		d.deregister(nodeID)
+		return
+	}
+}
+
+// activeTasks returns the IDs of the tasks that are assigned to or running on the node.
+func (d *Drainer) activeTasks(nodeID string) ([]string, error) {
+	infos, _, err := d.scheduler.List(context.Background(), scheduler.TaskFilter{NodeID: nodeID})
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, info := range infos {
+		if !info.Task.Status.IsTerminal() {
+			ids = append(ids, info.Task.ID)
+		}
+	}
+
+	return ids, nil
+}
+
+func (d *Drainer) deregister(nodeID string) {
+	if err := d.registry.Deregister(context.Background(), nodeID); err != nil && !errors.Is(err, ErrNodeNotFound) {
+		logger.ErrorX(logModule, "drain node %s: deregister failed: %s", nodeID, err.Error())
+		return
+	}
+	logger.InfoX(logModule, "node %s drained and deregistered", nodeID)
+}
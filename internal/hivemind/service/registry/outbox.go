@@ -0,0 +1,79 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/kiosk404/eidolon/internal/hivemind/service/scheduler"
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+)
+
+// Outbox is a TaskDispatcher that parks dispatched tasks in a per-node queue
+// until the Golem's transport connection picks them up.
+type Outbox struct {
+	registry Registry
+
+	mu     sync.Mutex
+	queues map[string][]*protocol.Task
+	notify map[string]chan struct{}
+}
+
+var _ scheduler.TaskDispatcher = &Outbox{}
+
+// NewOutbox creates an Outbox that only accepts tasks for registered nodes.
+func NewOutbox(registry Registry) *Outbox {
+	return &Outbox{
+		registry: registry,
+		queues:   make(map[string][]*protocol.Task),
+		notify:   make(map[string]chan struct{}),
+	}
+}
+
+// Dispatch queues a task for the given node.
+func (o *Outbox) Dispatch(ctx context.Context, nodeID string, task *protocol.Task) error {
+	if _, err := o.registry.GetProfile(ctx, nodeID); err != nil {
+		return fmt.Errorf("outbox: %w", err)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.queues[nodeID] = append(o.queues[nodeID], task)
+	if ch, ok := o.notify[nodeID]; ok {
+		close(ch)
+		delete(o.notify, nodeID)
+	}
+
+	return nil
+}
+
+// Drain returns and removes every task queued for the node.
+func (o *Outbox) Drain(nodeID string) []*protocol.Task {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	tasks := o.queues[nodeID]
+	delete(o.queues, nodeID)
+
+	return tasks
+}
+
+// Wait returns a channel that is closed the next time a task is queued for the node.
+func (o *Outbox) Wait(nodeID string) <-chan struct{} {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if len(o.queues[nodeID]) > 0 {
+		ch := make(chan struct{})
+		close(ch)
+		return ch
+	}
+	ch, ok := o.notify[nodeID]
+	if !ok {
+		ch = make(chan struct{})
+		o.notify[nodeID] = ch
+	}
+
+	return ch
+}
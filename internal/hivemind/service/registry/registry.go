@@ -0,0 +1,213 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kiosk404/eidolon/internal/hivemind/service/scheduler"
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+)
+
+// ErrNodeNotFound is returned when a node ID is unknown to the registry.
+var ErrNodeNotFound = errors.New("node not registered")
+
+// Registry keeps the hivemind's view of every Golem node that has registered.
+// It is the ProfileProvider the scheduler selects candidates from.
+type Registry interface {
+	scheduler.ProfileProvider
+
+	// Register adds a node or replaces its static registration data.
+	Register(ctx context.Context, profile *scheduler.GolemProfile) error
+
+	// Heartbeat records the latest load report for a node, and its skills when
+	// the report carries them, and marks it online.
+	Heartbeat(ctx context.Context, hb *protocol.Heartbeat) error
+
+	// Deregister removes a node from the registry.
+	Deregister(ctx context.Context, nodeID string) error
+
+	// ListAll returns every registered node, including ones that are offline.
+	scheduler.AllProfileLister
+
+	// Update applies fn to the stored profile of a node under the registry lock
+	// and returns a copy of the result.
+	Update(ctx context.Context, nodeID string, fn func(p *scheduler.GolemProfile)) (*scheduler.GolemProfile, error)
+}
+
+type memoryRegistry struct {
+	mu    sync.RWMutex
+	nodes map[string]*scheduler.GolemProfile
+}
+
+var _ Registry = &memoryRegistry{}
+
+// NewMemoryRegistry creates an in-memory Registry.
+func NewMemoryRegistry() Registry {
+	return &memoryRegistry{
+		nodes: make(map[string]*scheduler.GolemProfile),
+	}
+}
+
+// Register adds a node or replaces its static registration data. The cordon and
+// drain state set by operators survives re-registration.
+func (r *memoryRegistry) Register(_ context.Context, profile *scheduler.GolemProfile) error {
+	if profile == nil || profile.NodeInfo.ID == "" {
+		return fmt.Errorf("registry: node ID must not be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p := cloneProfile(profile)
+	if p.NodeInfo.RegisteredAt.IsZero() {
+		p.NodeInfo.RegisteredAt = time.Now()
+	}
+	if p.NodeInfo.Status == "" {
+		p.NodeInfo.Status = protocol.NodeStatusOnline
+	}
+	if old, ok := r.nodes[p.NodeInfo.ID]; ok {
+		p.Cordoned = old.Cordoned
+		p.Draining = old.Draining
+	}
+	p.LastUpdated = time.Now()
+	r.nodes[p.NodeInfo.ID] = p
+
+	return nil
+}
+
+// Heartbeat records the latest load report for a node, and its skills when
+// the report carries them, and marks it online.
+func (r *memoryRegistry) Heartbeat(_ context.Context, hb *protocol.Heartbeat) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.nodes[hb.NodeID]
+	if !ok {
+		return fmt.Errorf("registry: node %q: %w", hb.NodeID, ErrNodeNotFound)
+	}
+	load := hb.Load
+	if load.ReportedAt.IsZero() {
+		load.ReportedAt = time.Now()
+	}
+	p.Load = load
+	if hb.InstalledSkills != nil {
+		p.InstalledSkills = SkillsFromProtocol(hb.InstalledSkills)
+	}
+	p.NodeInfo.Status = protocol.NodeStatusOnline
+	p.LastUpdated = time.Now()
+
+	return nil
+}
+
+// Deregister removes a node from the registry.
+func (r *memoryRegistry) Deregister(_ context.Context, nodeID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.nodes[nodeID]; !ok {
+		return fmt.Errorf("registry: node %q: %w", nodeID, ErrNodeNotFound)
+	}
+	delete(r.nodes, nodeID)
+
+	return nil
+}
+
+// ListProfiles returns the nodes that are online.
+func (r *memoryRegistry) ListProfiles(ctx context.Context) ([]scheduler.GolemProfile, error) {
+	all, err := r.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := all[:0]
+	for _, p := range all {
+		if p.NodeInfo.Status == protocol.NodeStatusOnline {
+			profiles = append(profiles, p)
+		}
+	}
+
+	return profiles, nil
+}
+
+// GetProfile returns the profile for a specific node.
+func (r *memoryRegistry) GetProfile(_ context.Context, nodeID string) (*scheduler.GolemProfile, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.nodes[nodeID]
+	if !ok {
+		return nil, fmt.Errorf("registry: node %q: %w", nodeID, ErrNodeNotFound)
+	}
+
+	return cloneProfile(p), nil
+}
+
+// ListAll returns every registered node sorted by ID.
+func (r *memoryRegistry) ListAll(_ context.Context) ([]scheduler.GolemProfile, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	profiles := make([]scheduler.GolemProfile, 0, len(r.nodes))
+	for _, p := range r.nodes {
+		profiles = append(profiles, *cloneProfile(p))
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].NodeInfo.ID < profiles[j].NodeInfo.ID
+	})
+
+	return profiles, nil
+}
+
+// Update applies fn to the stored profile of a node and returns a copy of the result.
+func (r *memoryRegistry) Update(_ context.Context, nodeID string, fn func(p *scheduler.GolemProfile)) (*scheduler.GolemProfile, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.nodes[nodeID]
+	if !ok {
+		return nil, fmt.Errorf("registry: node %q: %w", nodeID, ErrNodeNotFound)
+	}
+	fn(p)
+	p.LastUpdated = time.Now()
+
+	return cloneProfile(p), nil
+}
+
+// cloneProfile copies the slices and maps of a profile so callers cannot mutate registry state.
+func cloneProfile(p *scheduler.GolemProfile) *scheduler.GolemProfile {
+	c := *p
+	c.NodeInfo.Capabilities = append([]protocol.Capability(nil), p.NodeInfo.Capabilities...)
+	c.InstalledSkills = make([]scheduler.SkillInfo, len(p.InstalledSkills))
+	for i, sk := range p.InstalledSkills {
+		sk.Capabilities = append([]string(nil), sk.Capabilities...)
+		c.InstalledSkills[i] = sk
+	}
+	c.SupportedFeatures = append([]string(nil), p.SupportedFeatures...)
+	if p.Tags != nil {
+		c.Tags = make(map[string]string, len(p.Tags))
+		for k, v := range p.Tags {
+			c.Tags[k] = v
+		}
+	}
+
+	return &c
+}
+
+// SkillsFromProtocol converts the skills a golem reported into their scheduler form.
+func SkillsFromProtocol(skills []protocol.SkillInfo) []scheduler.SkillInfo {
+	out := make([]scheduler.SkillInfo, len(skills))
+	for i, sk := range skills {
+		out[i] = scheduler.SkillInfo{
+			ID:           sk.ID,
+			Name:         sk.Name,
+			Version:      sk.Version,
+			Capabilities: append([]string(nil), sk.Capabilities...),
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,202 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+)
+
+// skillInstall is a node installing the missing skills of a queued task.
+type skillInstall struct {
+	// taskID is the ID of the install task sent to the node.
+	taskID string
+
+	nodeID string
+
+	// skills are the installed packages, like: web-search@1.2.0.
+	skills []string
+
+	startedAt time.Time
+}
+
+// installMissingSkills is called when no node qualifies for req. If the
+// request allows it, a node that meets every constraint but the skills is
+// asked to install them, and the task stays queued until the node reports
+// them. selErr, the reason no node qualified, is returned wrapped.
+func (s *defaultScheduler) installMissingSkills(ctx context.Context, req *ScheduleRequest, selector NodeSelector, candidates []GolemProfile, selErr error) error {
+	if !req.InstallMissingSkills || len(req.RequiredSkills) == 0 || s.config.SkillResolver == nil {
+		return selErr
+	}
+	reqs, err := ParseSkillRequirements(req.RequiredSkills)
+	if err != nil {
+		return selErr
+	}
+
+	s.mu.Lock()
+	rec, ok := s.tasks[req.Task.ID]
+	if !ok {
+		s.mu.Unlock()
+		return selErr
+	}
+	if inst := rec.install; inst != nil {
+		if time.Since(inst.startedAt) < s.config.SkillInstallTimeout {
+			s.mu.Unlock()
+			return fmt.Errorf("waiting for node %q to install %s: %w", inst.nodeID, strings.Join(inst.skills, ", "), selErr)
+		}
+		// The node took too long, give the installation to another one.
+		s.abortSkillInstall(rec)
+	}
+	excluded := make(map[string]bool, len(rec.installFailed))
+	for _, nodeID := range rec.installFailed {
+		excluded[nodeID] = true
+	}
+	s.mu.Unlock()
+
+	remaining := make([]GolemProfile, 0, len(candidates))
+	for _, c := range candidates {
+		if !excluded[c.NodeInfo.ID] {
+			remaining = append(remaining, c)
+		}
+	}
+	if len(remaining) == 0 {
+		return selErr
+	}
+
+	// Pick the node as if the skills were not required.
+	relaxed := *req
+	relaxed.RequiredSkills = nil
+	decision, err := selector.Select(ctx, &relaxed, remaining)
+	if err != nil {
+		return selErr
+	}
+	var profile *GolemProfile
+	for i := range remaining {
+		if remaining[i].NodeInfo.ID == decision.SelectedNodeID {
+			profile = &remaining[i]
+			break
+		}
+	}
+	if profile == nil {
+		return selErr
+	}
+
+	var missing []*SkillRequirement
+	for _, r := range reqs {
+		if sk, _ := r.Match(profile); sk == nil {
+			missing = append(missing, r)
+		}
+	}
+	if len(missing) == 0 {
+		return selErr
+	}
+
+	pkgs, err := s.config.SkillResolver.ResolveSkills(ctx, missing)
+	if err != nil {
+		return fmt.Errorf("%w (cannot install the missing skills: %v)", selErr, err)
+	}
+
+	nodeID := decision.SelectedNodeID
+	now := time.Now()
+	task := &protocol.Task{
+		ID:             fmt.Sprintf("%s-skills-%d", req.Task.ID, len(excluded)+1),
+		Name:           "install skills of " + req.Task.ID,
+		Type:           protocol.TaskTypeInstallSkills,
+		Payload:        (&protocol.InstallSkillsPayload{Packages: pkgs}).Payload(),
+		Priority:       req.Task.Priority,
+		Status:         protocol.TaskStatusAssigned,
+		Timeout:        s.config.SkillInstallTimeout,
+		AssignedNodeID: nodeID,
+		CreatedAt:      now,
+		StartedAt:      &now,
+	}
+	if err := s.dispatcher.Dispatch(ctx, nodeID, task); err != nil {
+		return fmt.Errorf("failed to send skill install task to node %q: %w", nodeID, err)
+	}
+
+	skills := make([]string, len(pkgs))
+	for i, pkg := range pkgs {
+		skills[i] = pkg.ID + "@" + pkg.Version
+	}
+	s.mu.Lock()
+	rec.install = &skillInstall{taskID: task.ID, nodeID: nodeID, skills: skills, startedAt: now}
+	s.installs[task.ID] = req.Task.ID
+	s.mu.Unlock()
+
+	decision.RequestID = req.Task.ID
+	decision.Reason = fmt.Sprintf("installing %s, %s", strings.Join(skills, ", "), decision.Reason)
+	s.emitEvent(&TaskEvent{
+		Type:      EventTypeSkillInstalling,
+		Task:      req.Task,
+		Decision:  decision,
+		NodeID:    nodeID,
+		Timestamp: now,
+	})
+
+	return fmt.Errorf("installing %s on node %q: %w", strings.Join(skills, ", "), nodeID, selErr)
+}
+
+// installingSkills reports whether a node is installing the skills of the
+// task.
+func (s *defaultScheduler) installingSkills(taskID string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rec, ok := s.tasks[taskID]
+	return ok && rec.install != nil
+}
+
+// abortSkillInstall gives up the installation rec waits for and excludes its
+// node from the next ones; s.mu must be held.
+func (s *defaultScheduler) abortSkillInstall(rec *taskRecord) {
+	inst := rec.install
+	rec.install = nil
+	rec.installFailed = append(rec.installFailed, inst.nodeID)
+	delete(s.installs, inst.taskID)
+	s.stats.RecordSkillInstallFailure(inst.nodeID)
+}
+
+// finishSkillInstall is called when a task is dispatched to nodeID. The install
+// time is recorded when the node is the one that installed its skills.
+func (s *defaultScheduler) finishSkillInstall(taskID, nodeID string) {
+	s.mu.Lock()
+	rec, ok := s.tasks[taskID]
+	if !ok || rec.install == nil {
+		s.mu.Unlock()
+		return
+	}
+	inst := rec.install
+	rec.install = nil
+	delete(s.installs, inst.taskID)
+	s.mu.Unlock()
+
+	if inst.nodeID == nodeID {
+		s.stats.RecordSkillInstall(nodeID, time.Since(inst.startedAt))
+	}
+}
+
+// reportSkillInstall handles the result of an install task and reports whether
+// result was one. A failed installation is given to another node on the next
+// scheduling attempt; a successful one completes once the node reports its
+// new skills.
+func (s *defaultScheduler) reportSkillInstall(result *protocol.TaskResult) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	taskID, ok := s.installs[result.TaskID]
+	if !ok {
+		return false
+	}
+	rec, ok := s.tasks[taskID]
+	if !ok || rec.install == nil || rec.install.taskID != result.TaskID {
+		delete(s.installs, result.TaskID)
+		return true
+	}
+	if !result.Success {
+		s.abortSkillInstall(rec)
+	}
+
+	return true
+}
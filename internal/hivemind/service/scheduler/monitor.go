@@ -4,6 +4,8 @@ import (
 	"context"
 	"sync"
 	"time"
+
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
 )
 
 // --------------------------------------------------------------------------
@@ -250,6 +252,18 @@ type SchedulerStats struct {
 	// AverageExecutionTime is the average time from assignment to completion.
 	AverageExecutionTime time.Duration
 
+	// TotalSkillInstalls is the number of times a node installed the missing
+	// skills of a task.
+	TotalSkillInstalls int64
+
+	// TotalSkillInstallFailures is the number of skill installations that
+	// failed or timed out.
+	TotalSkillInstallFailures int64
+
+	// AverageSkillInstallTime is the average time from asking a node to
+	// install skills to dispatching the task to it.
+	AverageSkillInstallTime time.Duration
+
 	// NodeStats maps node IDs to per-node scheduling statistics.
 	NodeStats map[string]*NodeSchedulerStats
 
@@ -276,6 +290,13 @@ type NodeSchedulerStats struct {
 
 	// LastAssignedAt records when a task was last assigned to this node.
 	LastAssignedAt time.Time
+
+	// SkillInstalls is the number of skill installations this node completed.
+	SkillInstalls int64
+
+	// SkillInstallFailures is the number of skill installations this node
+	// failed or did not finish in time.
+	SkillInstallFailures int64
 }
 
 // StatsCollector tracks and aggregates scheduler statistics.
@@ -289,6 +310,7 @@ type StatsCollector struct {
 	// Track latency samples for averaging.
 	latencySamples   []time.Duration
 	executionSamples []time.Duration
+	installSamples   []time.Duration
 	maxSampleCount   int
 }
 
@@ -380,6 +402,32 @@ func (c *StatsCollector) RecordTimeout(taskID string) {
 	delete(c.running, taskID)
 }
 
+// RecordSkillInstall records that nodeID installed the skills of a task in d.
+func (c *StatsCollector) RecordSkillInstall(nodeID string, d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.stats.TotalSkillInstalls++
+	c.installSamples = append(c.installSamples, d)
+	if len(c.installSamples) > c.maxSampleCount {
+		c.installSamples = c.installSamples[1:]
+	}
+
+	ns := c.getOrCreateNodeStats(nodeID)
+	ns.SkillInstalls++
+}
+
+// RecordSkillInstallFailure records a skill installation that failed or timed out.
+func (c *StatsCollector) RecordSkillInstallFailure(nodeID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.stats.TotalSkillInstallFailures++
+
+	ns := c.getOrCreateNodeStats(nodeID)
+	ns.SkillInstallFailures++
+}
+
 // Snapshot returns a copy of the current statistics.
 func (c *StatsCollector) Snapshot(queueLen int) SchedulerStats {
 	c.mu.Lock()
@@ -390,6 +438,7 @@ func (c *StatsCollector) Snapshot(queueLen int) SchedulerStats {
 	snap.CurrentRunning = len(c.running)
 	snap.AverageLatency = averageDuration(c.latencySamples)
 	snap.AverageExecutionTime = averageDuration(c.executionSamples)
+	snap.AverageSkillInstallTime = averageDuration(c.installSamples)
 	snap.CollectedAt = time.Now()
 
 	// Deep-copy NodeStats.
@@ -2,7 +2,10 @@ package scheduler
 
 import (
 	"container/heap"
+	"sort"
 	"sync"
+
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
 )
 
 // --------------------------------------------------------------------------
@@ -26,6 +29,9 @@ type Queue interface {
 	// Len returns the number of requests in the queue.
 	Len() int
 
+	// List returns the queued requests in priority order without removing them.
+	List() []*ScheduleRequest
+
 	// Remove removes a specific request by task ID.
 	Remove(taskID string) bool
 
@@ -97,6 +103,21 @@ func (q *PriorityQueue) Len() int {
 	return q.heap.Len()
 }
 
+// List returns the queued requests in priority order without removing them.
+func (q *PriorityQueue) List() []*ScheduleRequest {
+	q.mu.Lock()
+	items := make(requestHeap, q.heap.Len())
+	copy(items, *q.heap)
+	q.mu.Unlock()
+
+	sort.Slice(items, items.Less)
+	result := make([]*ScheduleRequest, len(items))
+	for i, item := range items {
+		result[i] = item.request
+	}
+	return result
+}
+
 // Remove removes a request by task ID.
 func (q *PriorityQueue) Remove(taskID string) bool {
 	q.mu.Lock()
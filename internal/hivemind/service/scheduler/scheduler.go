@@ -2,9 +2,13 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
+
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
 )
 
 type Scheduler interface {
@@ -19,6 +23,18 @@ type Scheduler interface {
 	// Status returns the current state of a task.
 	Status(ctx context.Context, taskID string) (*protocol.Task, error)
 
+	// Get returns a snapshot of a task together with its scheduling metadata.
+	Get(ctx context.Context, taskID string) (*TaskInfo, error)
+
+	// List returns the tasks matching the filter, newest first, and the total
+	// number of matches before pagination.
+	List(ctx context.Context, filter TaskFilter) ([]*TaskInfo, int, error)
+
+	// Simulate runs node selection for a hypothetical request against the
+	// current profiles without recording or dispatching anything. The nodes
+	// that are not candidates, like offline ones, are reported as rejected.
+	Simulate(ctx context.Context, req *ScheduleRequest) (*SimulationResult, error)
+
 	// Stats returns aggregate scheduler statistics.
 	Stats() SchedulerStats
 
@@ -28,6 +44,12 @@ type Scheduler interface {
 	// Unsubscribe removes a previously registered listener.
 	Unsubscribe(listener TaskEventListener)
 
+	// ReportProgress records incremental progress reported by a Golem.
+	ReportProgress(ctx context.Context, progress *protocol.TaskProgress)
+
+	// ReportResult records the final result of a task reported by a Golem.
+	ReportResult(ctx context.Context, result *protocol.TaskResult)
+
 	// Start begins the scheduler's background processing loops.
 	Start(ctx context.Context) error
 
@@ -35,6 +57,57 @@ type Scheduler interface {
 	Stop(ctx context.Context) error
 }
 
+var (
+	// ErrTaskNotFound is returned when a task ID is unknown to the scheduler.
+	ErrTaskNotFound = errors.New("task not found")
+
+	// ErrTaskQueued is returned by Schedule when the task could not be dispatched
+	// immediately and was queued for background retry.
+	ErrTaskQueued = errors.New("task queued")
+
+	// ErrTaskFinished is returned when cancelling a task that already reached a terminal state.
+	ErrTaskFinished = errors.New("task already finished")
+)
+
+// TaskInfo is a point-in-time copy of a task tracked by the scheduler.
+type TaskInfo struct {
+	// Task is a copy of the task.
+	Task protocol.Task
+
+	// Mode is the scheduling mode requested for the task.
+	Mode ScheduleMode
+
+	// Decision is the most recent scheduling decision, nil while the task is queued.
+	Decision *ScheduleDecision
+
+	// Retries is the number of times the task has been rescheduled.
+	Retries int
+}
+
+// SimulationResult is the outcome of a dry-run selection.
+type SimulationResult struct {
+	// Decision is the node the request would be placed on, nil if none qualifies.
+	Decision *ScheduleDecision
+
+	// Scores holds the evaluation of every known node, including the rejected
+	// ones and, when the ProfileProvider can list them, the offline ones.
+	Scores []NodeScore
+
+	// Err explains why no node was selected.
+	Err error
+}
+
+// TaskFilter selects the tasks returned by List. Zero values match everything.
+type TaskFilter struct {
+	Status protocol.TaskStatus
+	NodeID string
+	Mode   ScheduleMode
+
+	// Offset and Limit paginate the result; Limit <= 0 means no limit.
+	Offset int
+	Limit  int
+}
+
 // --------------------------------------------------------------------------
 // TaskDispatcher — abstraction for actually sending tasks to Golem nodes
 // --------------------------------------------------------------------------
@@ -47,6 +120,14 @@ type TaskDispatcher interface {
 	Dispatch(ctx context.Context, nodeID string, task *protocol.Task) error
 }
 
+// SkillResolver finds the packages that provide skills, so that the scheduler
+// can have them installed on a node that lacks them.
+type SkillResolver interface {
+	// ResolveSkills returns, for every requirement, the newest package that
+	// satisfies it.
+	ResolveSkills(ctx context.Context, reqs []*SkillRequirement) ([]protocol.SkillPackage, error)
+}
+
 // --------------------------------------------------------------------------
 // SchedulerConfig — Options pattern (k8s style)
 // --------------------------------------------------------------------------
@@ -69,6 +150,14 @@ type SchedulerConfig struct {
 
 	// MonitorConfig configures the task execution monitor.
 	MonitorConfig MonitorConfig
+
+	// SkillResolver locates the skills to install for requests that set
+	// InstallMissingSkills; nil disables installing skills.
+	SkillResolver SkillResolver
+
+	// SkillInstallTimeout bounds the time a node is given to install the
+	// missing skills of a task before another node is tried.
+	SkillInstallTimeout time.Duration
 }
 
 // DefaultSchedulerConfig returns a SchedulerConfig with sensible defaults.
@@ -79,6 +168,7 @@ func DefaultSchedulerConfig() SchedulerConfig {
 		MaxRetries:            3,
 		DefaultScoringWeights: DefaultScoringWeights(),
 		MonitorConfig:         DefaultMonitorConfig(),
+		SkillInstallTimeout:   5 * time.Minute,
 	}
 }
 
@@ -104,6 +194,9 @@ func (c SchedulerConfig) Complete(provider ProfileProvider, dispatcher TaskDispa
 	if c.ScheduleLoopInterval <= 0 {
 		c.ScheduleLoopInterval = 500 * time.Millisecond
 	}
+	if c.SkillInstallTimeout <= 0 {
+		c.SkillInstallTimeout = 5 * time.Minute
+	}
 	return &CompletedSchedulerConfig{
 		config:     c,
 		provider:   provider,
@@ -129,6 +222,7 @@ func (cc *CompletedSchedulerConfig) New() Scheduler {
 		aiSel:      aiSel,
 		stats:      stats,
 		tasks:      make(map[string]*taskRecord),
+		installs:   make(map[string]string),
 		stopCh:     make(chan struct{}),
 	}
 
@@ -146,6 +240,43 @@ type taskRecord struct {
 	decision *ScheduleDecision
 	request  *ScheduleRequest
 	retries  int
+
+	// install is the skill installation the task waits for, if any.
+	install *skillInstall
+
+	// installFailed lists the nodes that failed to install the skills of the task.
+	installFailed []string
+}
+
+// snapshot copies the record; the caller must hold the scheduler lock.
+func (r *taskRecord) snapshot() *TaskInfo {
+	info := &TaskInfo{
+		Task:    *r.task,
+		Retries: r.retries,
+	}
+	if r.request != nil {
+		info.Mode = r.request.Mode
+	}
+	if r.decision != nil {
+		d := *r.decision
+		d.Scores = append([]NodeScore(nil), r.decision.Scores...)
+		info.Decision = &d
+	}
+	return info
+}
+
+// matches reports whether the record satisfies the filter.
+func (r *taskRecord) matches(f TaskFilter) bool {
+	if f.Status != "" && r.task.Status != f.Status {
+		return false
+	}
+	if f.NodeID != "" && r.task.AssignedNodeID != f.NodeID {
+		return false
+	}
+	if f.Mode != "" && (r.request == nil || r.request.Mode != f.Mode) {
+		return false
+	}
+	return true
 }
 
 type defaultScheduler struct {
@@ -160,6 +291,7 @@ type defaultScheduler struct {
 
 	mu        sync.RWMutex
 	tasks     map[string]*taskRecord
+	installs  map[string]string // install task ID -> task ID
 	listeners []TaskEventListener
 
 	stopCh   chan struct{}
@@ -172,6 +304,24 @@ func (s *defaultScheduler) Schedule(ctx context.Context, req *ScheduleRequest) (
 		return nil, fmt.Errorf("scheduler: task must not be nil")
 	}
 
+	s.mu.Lock()
+	if _, exists := s.tasks[req.Task.ID]; exists {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("scheduler: task %q already exists", req.Task.ID)
+	}
+	if req.Task.CreatedAt.IsZero() {
+		req.Task.CreatedAt = time.Now()
+	}
+	if req.RequestedAt.IsZero() {
+		req.RequestedAt = req.Task.CreatedAt
+	}
+	req.Task.Status = protocol.TaskStatusPending
+	s.tasks[req.Task.ID] = &taskRecord{
+		task:    req.Task,
+		request: req,
+	}
+	s.mu.Unlock()
+
 	// Record submission.
 	s.stats.RecordSubmission()
 
@@ -183,6 +333,14 @@ func (s *defaultScheduler) Schedule(ctx context.Context, req *ScheduleRequest) (
 
 	// If immediate dispatch fails, enqueue for background processing.
 	if enqErr := s.queue.Enqueue(req); enqErr != nil {
+		// The task was never accepted, forget it.
+		s.mu.Lock()
+		if rec, ok := s.tasks[req.Task.ID]; ok && rec.install != nil {
+			delete(s.installs, rec.install.taskID)
+		}
+		delete(s.tasks, req.Task.ID)
+		s.mu.Unlock()
+
 		return nil, fmt.Errorf("scheduler: failed to enqueue task %q: %w", req.Task.ID, enqErr)
 	}
 
@@ -192,11 +350,22 @@ func (s *defaultScheduler) Schedule(ctx context.Context, req *ScheduleRequest) (
 		Timestamp: time.Now(),
 	})
 
-	return nil, fmt.Errorf("scheduler: immediate dispatch failed (%w), task %q queued for retry", err, req.Task.ID)
+	return nil, fmt.Errorf("scheduler: immediate dispatch failed (%v), task %q queued for retry: %w", err, req.Task.ID, ErrTaskQueued)
 }
 
 // Cancel aborts a pending or running task.
 func (s *defaultScheduler) Cancel(ctx context.Context, taskID string) error {
+	s.mu.RLock()
+	rec, ok := s.tasks[taskID]
+	finished := ok && rec.task.Status.IsTerminal()
+	s.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("scheduler: task %q: %w", taskID, ErrTaskNotFound)
+	}
+	if finished {
+		return fmt.Errorf("scheduler: task %q: %w", taskID, ErrTaskFinished)
+	}
+
 	// Try to remove from queue first.
 	if s.queue.Remove(taskID) {
 		s.stats.RecordCancellation(taskID)
@@ -239,9 +408,95 @@ func (s *defaultScheduler) Status(_ context.Context, taskID string) (*protocol.T
 
 	rec, ok := s.tasks[taskID]
 	if !ok {
-		return nil, fmt.Errorf("scheduler: task %q not found", taskID)
+		return nil, fmt.Errorf("scheduler: task %q: %w", taskID, ErrTaskNotFound)
+	}
+	task := *rec.task
+	return &task, nil
+}
+
+// Get returns a snapshot of a task together with its scheduling metadata.
+func (s *defaultScheduler) Get(_ context.Context, taskID string) (*TaskInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rec, ok := s.tasks[taskID]
+	if !ok {
+		return nil, fmt.Errorf("scheduler: task %q: %w", taskID, ErrTaskNotFound)
+	}
+	return rec.snapshot(), nil
+}
+
+// List returns the tasks matching the filter, newest first.
+func (s *defaultScheduler) List(_ context.Context, filter TaskFilter) ([]*TaskInfo, int, error) {
+	s.mu.RLock()
+	matched := make([]*TaskInfo, 0, len(s.tasks))
+	for _, rec := range s.tasks {
+		if rec.matches(filter) {
+			matched = append(matched, rec.snapshot())
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].Task.CreatedAt.Equal(matched[j].Task.CreatedAt) {
+			return matched[i].Task.CreatedAt.After(matched[j].Task.CreatedAt)
+		}
+		return matched[i].Task.ID < matched[j].Task.ID
+	})
+
+	total := len(matched)
+	if filter.Offset > 0 {
+		if filter.Offset >= total {
+			return []*TaskInfo{}, total, nil
+		}
+		matched = matched[filter.Offset:]
+	}
+	if filter.Limit > 0 && filter.Limit < len(matched) {
+		matched = matched[:filter.Limit]
+	}
+
+	return matched, total, nil
+}
+
+// Simulate runs node selection for req without dispatching it.
+func (s *defaultScheduler) Simulate(ctx context.Context, req *ScheduleRequest) (*SimulationResult, error) {
+	if req.Task == nil {
+		return nil, fmt.Errorf("scheduler: task must not be nil")
+	}
+
+	candidates, err := s.provider.ListProfiles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("scheduler: failed to list Golem profiles: %w", err)
+	}
+
+	// Score every known node, so that the ones left out of the candidates,
+	// like offline nodes, are reported with the reason they were skipped.
+	evaluated := candidates
+	if lister, ok := s.provider.(AllProfileLister); ok {
+		if evaluated, err = lister.ListAll(ctx); err != nil {
+			return nil, fmt.Errorf("scheduler: failed to list Golem profiles: %w", err)
+		}
+	}
+
+	result := &SimulationResult{}
+	if ai, ok := s.aiSel.(*AISelector); ok {
+		result.Scores = ai.Evaluate(req, evaluated)
+	}
+	if len(candidates) == 0 {
+		result.Err = fmt.Errorf("no Golem nodes available")
+		return result, nil
+	}
+
+	selector, err := s.selectorFor(req.Mode)
+	if err != nil {
+		return nil, err
+	}
+	result.Decision, result.Err = selector.Select(ctx, req, candidates)
+	if result.Decision != nil {
+		result.Decision.RequestID = req.Task.ID
 	}
-	return rec.task, nil
+
+	return result, nil
 }
 
 // Stats returns aggregate scheduler statistics.
@@ -360,45 +615,51 @@ func (s *defaultScheduler) tryDispatch(ctx context.Context, req *ScheduleRequest
 	}
 
 	// Choose selector based on mode.
-	var selector NodeSelector
-	switch req.Mode {
-	case DirectMode:
-		selector = s.directSel
-	case AIMode:
-		selector = s.aiSel
-	default:
-		return nil, fmt.Errorf("unknown schedule mode %q", req.Mode)
+	selector, err := s.selectorFor(req.Mode)
+	if err != nil {
+		return nil, err
 	}
 
 	// Select the best node.
 	decision, err := selector.Select(ctx, req, candidates)
 	if err != nil {
-		return nil, err
+		return nil, s.installMissingSkills(ctx, req, selector, candidates, err)
 	}
 
-	// Assign the task to the selected node.
+	decision.RequestID = req.Task.ID
+
+	// Assign the task to the selected node and record it in the task map,
+	// keeping the retry count of a rescheduled task.
+	s.mu.Lock()
 	req.Task.AssignedNodeID = decision.SelectedNodeID
 	req.Task.Status = protocol.TaskStatusAssigned
 	now := time.Now()
 	req.Task.StartedAt = &now
-	decision.RequestID = req.Task.ID
-
-	// Record in task map.
-	s.mu.Lock()
-	s.tasks[req.Task.ID] = &taskRecord{
-		task:     req.Task,
-		decision: decision,
-		request:  req,
+	if rec, ok := s.tasks[req.Task.ID]; ok {
+		rec.decision = decision
+		rec.request = req
+	} else {
+		s.tasks[req.Task.ID] = &taskRecord{
+			task:     req.Task,
+			decision: decision,
+			request:  req,
+		}
 	}
 	s.mu.Unlock()
 
 	// Dispatch to the Golem node.
 	if err := s.dispatcher.Dispatch(ctx, decision.SelectedNodeID, req.Task); err != nil {
+		s.mu.Lock()
+		req.Task.AssignedNodeID = ""
+		req.Task.Status = protocol.TaskStatusPending
+		req.Task.StartedAt = nil
+		s.mu.Unlock()
 		return nil, fmt.Errorf("failed to dispatch task %q to node %q: %w", req.Task.ID, decision.SelectedNodeID, err)
 	}
 
 	// Record assignment stats.
 	s.stats.RecordAssignment(req.Task.ID, decision.SelectedNodeID, decision.Latency)
+	s.finishSkillInstall(req.Task.ID, decision.SelectedNodeID)
 
 	// Start monitoring.
 	_ = s.monitor.Watch(ctx, req.Task)
@@ -415,6 +676,18 @@ func (s *defaultScheduler) tryDispatch(ctx context.Context, req *ScheduleRequest
 	return decision, nil
 }
 
+// selectorFor returns the selection strategy for a scheduling mode.
+func (s *defaultScheduler) selectorFor(mode ScheduleMode) (NodeSelector, error) {
+	switch mode {
+	case DirectMode:
+		return s.directSel, nil
+	case AIMode:
+		return s.aiSel, nil
+	default:
+		return nil, fmt.Errorf("unknown schedule mode %q", mode)
+	}
+}
+
 // scheduleLoop is the background goroutine that processes the queue.
 func (s *defaultScheduler) scheduleLoop(ctx context.Context) {
 	ticker := time.NewTicker(s.config.ScheduleLoopInterval)
@@ -432,22 +705,23 @@ func (s *defaultScheduler) scheduleLoop(ctx context.Context) {
 	}
 }
 
-// processQueue attempts to dispatch all pending requests in the queue.
+// processQueue attempts to dispatch all pending requests in the queue, in
+// priority order. A request that cannot be dispatched holds back the ones
+// behind it, unless it waits for a node to install its skills.
 func (s *defaultScheduler) processQueue(ctx context.Context) {
-	for {
-		req := s.queue.Peek()
-		if req == nil {
-			return
-		}
-
+	for _, req := range s.queue.List() {
 		_, err := s.tryDispatch(ctx, req)
 		if err != nil {
+			if s.installingSkills(req.Task.ID) {
+				// Leave it in queue until the node has the skills.
+				continue
+			}
 			// Cannot dispatch right now — leave in queue and retry later.
 			return
 		}
 
 		// Successfully dispatched — remove from queue.
-		s.queue.Dequeue()
+		s.queue.Remove(req.Task.ID)
 	}
 }
 
@@ -493,6 +767,9 @@ func (s *defaultScheduler) ReportProgress(ctx context.Context, progress *protoco
 
 // ReportResult records the final result of a completed task.
 func (s *defaultScheduler) ReportResult(_ context.Context, result *protocol.TaskResult) {
+	if s.reportSkillInstall(result) {
+		return
+	}
 	s.monitor.Unwatch(result.TaskID)
 
 	s.mu.Lock()
@@ -0,0 +1,228 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+)
+
+// fakeProvider serves a fixed set of profiles, the online ones as candidates.
+type fakeProvider struct {
+	profiles []GolemProfile
+}
+
+var _ AllProfileLister = &fakeProvider{}
+
+func (p *fakeProvider) ListProfiles(context.Context) ([]GolemProfile, error) {
+	var online []GolemProfile
+	for _, profile := range p.profiles {
+		if profile.NodeInfo.Status == protocol.NodeStatusOnline {
+			online = append(online, profile)
+		}
+	}
+	return online, nil
+}
+
+func (p *fakeProvider) GetProfile(_ context.Context, nodeID string) (*GolemProfile, error) {
+	for i := range p.profiles {
+		if p.profiles[i].NodeInfo.ID == nodeID {
+			profile := p.profiles[i]
+			return &profile, nil
+		}
+	}
+	return nil, fmt.Errorf("node %q not found", nodeID)
+}
+
+func (p *fakeProvider) ListAll(context.Context) ([]GolemProfile, error) {
+	return p.profiles, nil
+}
+
+// fakeDispatcher accepts every task.
+type fakeDispatcher struct{}
+
+func (fakeDispatcher) Dispatch(context.Context, string, *protocol.Task) error { return nil }
+
+func testProfile(id string, status protocol.NodeStatus, skills ...SkillInfo) GolemProfile {
+	return GolemProfile{
+		NodeInfo: protocol.NodeInfo{
+			ID:     id,
+			Status: status,
+			SystemInfo: protocol.SystemInfo{
+				CPUCores: 4,
+				MemoryMB: 8192,
+			},
+		},
+		InstalledSkills: skills,
+		HealthScore:     1,
+	}
+}
+
+func newTestScheduler(t *testing.T, profiles ...GolemProfile) Scheduler {
+	t.Helper()
+
+	cc, err := DefaultSchedulerConfig().Complete(&fakeProvider{profiles: profiles}, fakeDispatcher{})
+	if err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+
+	return cc.New()
+}
+
+func TestSimulateReportsSkippedNodes(t *testing.T) {
+	s := newTestScheduler(t,
+		testProfile("node-a", protocol.NodeStatusOnline),
+		testProfile("node-b", protocol.NodeStatusOffline),
+	)
+
+	result, err := s.Simulate(context.Background(), &ScheduleRequest{
+		Task: &protocol.Task{ID: "simulation"},
+		Mode: AIMode,
+	})
+	if err != nil {
+		t.Fatalf("Simulate: %v", err)
+	}
+
+	if result.Decision == nil || result.Decision.SelectedNodeID != "node-a" {
+		t.Fatalf("decision = %+v, want node-a", result.Decision)
+	}
+	scores := make(map[string]NodeScore, len(result.Scores))
+	for _, ns := range result.Scores {
+		scores[ns.NodeID] = ns
+	}
+	if ns, ok := scores["node-a"]; !ok || !ns.Eligible {
+		t.Errorf("node-a score = %+v, want eligible", ns)
+	}
+	ns, ok := scores["node-b"]
+	if !ok {
+		t.Fatal("the offline node-b is not reported")
+	}
+	if ns.Eligible || !strings.Contains(ns.RejectReason, "offline") {
+		t.Errorf("node-b score = %+v, want rejected as offline", ns)
+	}
+}
+
+func TestSimulateWithoutOnlineNodes(t *testing.T) {
+	s := newTestScheduler(t, testProfile("node-b", protocol.NodeStatusOffline))
+
+	result, err := s.Simulate(context.Background(), &ScheduleRequest{
+		Task: &protocol.Task{ID: "simulation"},
+		Mode: AIMode,
+	})
+	if err != nil {
+		t.Fatalf("Simulate: %v", err)
+	}
+
+	if result.Decision != nil {
+		t.Errorf("decision = %+v, want none", result.Decision)
+	}
+	if result.Err == nil {
+		t.Error("no error explains why no node was selected")
+	}
+	if len(result.Scores) != 1 || result.Scores[0].Eligible {
+		t.Errorf("scores = %+v, want node-b rejected", result.Scores)
+	}
+}
+
+// recordingDispatcher records the tasks sent to every node.
+type recordingDispatcher struct {
+	mu    sync.Mutex
+	tasks map[string][]*protocol.Task
+}
+
+func (d *recordingDispatcher) Dispatch(_ context.Context, nodeID string, task *protocol.Task) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.tasks == nil {
+		d.tasks = make(map[string][]*protocol.Task)
+	}
+	d.tasks[nodeID] = append(d.tasks[nodeID], task)
+	return nil
+}
+
+func (d *recordingDispatcher) sent(nodeID string) []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var ids []string
+	for _, task := range d.tasks[nodeID] {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+// fakeResolver resolves every requirement to a 1.0.0 package of the skill.
+type fakeResolver struct{}
+
+func (fakeResolver) ResolveSkills(_ context.Context, reqs []*SkillRequirement) ([]protocol.SkillPackage, error) {
+	pkgs := make([]protocol.SkillPackage, len(reqs))
+	for i, r := range reqs {
+		pkgs[i] = protocol.SkillPackage{ID: r.Skill, Version: "1.0.0"}
+	}
+	return pkgs, nil
+}
+
+func TestProcessQueueSkipsSkillInstalls(t *testing.T) {
+	ctx := context.Background()
+	provider := &fakeProvider{profiles: []GolemProfile{
+		testProfile("node-a", protocol.NodeStatusOnline),
+		testProfile("node-b", protocol.NodeStatusOffline),
+	}}
+	dispatcher := &recordingDispatcher{}
+	config := DefaultSchedulerConfig()
+	config.SkillResolver = fakeResolver{}
+	cc, err := config.Complete(provider, dispatcher)
+	if err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	s := cc.New().(*defaultScheduler)
+
+	// The urgent task waits for node-a to install its skill, the other one
+	// for node-b to come online.
+	_, err = s.Schedule(ctx, &ScheduleRequest{
+		Task:                 &protocol.Task{ID: "urgent", Priority: protocol.TaskPriorityCritical},
+		Mode:                 AIMode,
+		RequiredSkills:       []string{"web-search"},
+		InstallMissingSkills: true,
+	})
+	if !errors.Is(err, ErrTaskQueued) {
+		t.Fatalf("Schedule urgent error = %v, want %v", err, ErrTaskQueued)
+	}
+	_, err = s.Schedule(ctx, &ScheduleRequest{
+		Task:         &protocol.Task{ID: "normal", Priority: protocol.TaskPriorityNormal},
+		Mode:         DirectMode,
+		TargetNodeID: "node-b",
+	})
+	if !errors.Is(err, ErrTaskQueued) {
+		t.Fatalf("Schedule normal error = %v, want %v", err, ErrTaskQueued)
+	}
+	if got := dispatcher.sent("node-a"); len(got) != 1 || got[0] != "urgent-skills-1" {
+		t.Fatalf("tasks sent to node-a = %v, want the skill install", got)
+	}
+
+	provider.profiles[1].NodeInfo.Status = protocol.NodeStatusOnline
+	s.processQueue(ctx)
+
+	if got := dispatcher.sent("node-b"); len(got) != 1 || got[0] != "normal" {
+		t.Errorf("tasks sent to node-b = %v, want normal", got)
+	}
+	if got := s.queue.List(); len(got) != 1 || got[0].Task.ID != "urgent" {
+		t.Errorf("queue = %v, want urgent left", got)
+	}
+
+	// The node reports the skill, the urgent task follows.
+	provider.profiles[0].InstalledSkills = []SkillInfo{{ID: "web-search", Version: "1.0.0"}}
+	s.processQueue(ctx)
+
+	if got := dispatcher.sent("node-a"); len(got) != 2 || got[1] != "urgent" {
+		t.Errorf("tasks sent to node-a = %v, want urgent after the install", got)
+	}
+	if n := s.queue.Len(); n != 0 {
+		t.Errorf("queue length = %d, want 0", n)
+	}
+}
@@ -7,9 +7,22 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	versionutil "github.com/kiosk404/eidolon/pkg/version/util"
 )
 
+// --------------------------------------------------------------------------
+// NodeSelector — Strategy pattern
+// --------------------------------------------------------------------------
+
+// NodeSelector picks a Golem node for a ScheduleRequest from a set of candidates.
+// Each scheduling mode is backed by its own strategy.
 type NodeSelector interface {
+	// Name returns a short identifier for the selector, used in logs and decisions.
+	Name() string
+
+	// Select evaluates the candidates and returns the scheduling decision.
+	Select(ctx context.Context, req *ScheduleRequest, candidates []GolemProfile) (*ScheduleDecision, error)
 }
 
 // --------------------------------------------------------------------------
@@ -28,6 +41,14 @@ type ProfileProvider interface {
 	GetProfile(ctx context.Context, nodeID string) (*GolemProfile, error)
 }
 
+// AllProfileLister is implemented by the ProfileProviders that can also list
+// the nodes ListProfiles leaves out, like the offline ones, so that a
+// simulation can explain why they were not considered.
+type AllProfileLister interface {
+	// ListAll returns every known Golem profile, whatever its status.
+	ListAll(ctx context.Context) ([]GolemProfile, error)
+}
+
 // --------------------------------------------------------------------------
 // DirectSelector — explicit node targeting
 // --------------------------------------------------------------------------
@@ -141,23 +162,9 @@ func (s *AISelector) Select(ctx context.Context, req *ScheduleRequest, candidate
 		return nil, fmt.Errorf("scheduler: AISelector received 0 candidates")
 	}
 
-	checker := &constraintChecker{}
-	scores := make([]NodeScore, 0, len(candidates))
+	scores := s.Evaluate(req, candidates)
 	var eligible []NodeScore
-
-	for i := range candidates {
-		profile := &candidates[i]
-		ns := s.score(req, profile)
-
-		// Hard-constraint check.
-		if reason := checker.check(req, profile); reason != "" {
-			ns.Eligible = false
-			ns.RejectReason = reason
-		} else {
-			ns.Eligible = true
-		}
-
-		scores = append(scores, ns)
+	for _, ns := range scores {
 		if ns.Eligible {
 			eligible = append(eligible, ns)
 		}
@@ -186,14 +193,42 @@ func (s *AISelector) Select(ctx context.Context, req *ScheduleRequest, candidate
 	}, nil
 }
 
+// Evaluate scores every candidate and checks its hard constraints, without
+// picking a winner. Rejected nodes keep their scores and carry a RejectReason.
+func (s *AISelector) Evaluate(req *ScheduleRequest, candidates []GolemProfile) []NodeScore {
+	checker := &constraintChecker{}
+
+	// Hard-constraint check first, so that skills are scored against the
+	// versions the eligible nodes run.
+	reasons := make([]string, len(candidates))
+	var eligible []GolemProfile
+	for i := range candidates {
+		if reasons[i] = checker.check(req, &candidates[i]); reasons[i] == "" {
+			eligible = append(eligible, candidates[i])
+		}
+	}
+	skills := newSkillScoring(req, eligible)
+
+	scores := make([]NodeScore, 0, len(candidates))
+	for i := range candidates {
+		ns := s.score(req, &candidates[i], skills)
+		ns.Eligible = reasons[i] == ""
+		ns.RejectReason = reasons[i]
+
+		scores = append(scores, ns)
+	}
+
+	return scores
+}
+
 // score computes the multi-dimensional score for a single candidate.
-func (s *AISelector) score(req *ScheduleRequest, profile *GolemProfile) NodeScore {
+func (s *AISelector) score(req *ScheduleRequest, profile *GolemProfile, skills *skillScoring) NodeScore {
 	ns := NodeScore{
 		NodeID: profile.NodeInfo.ID,
 	}
 
 	ns.CapabilityScore = s.scoreCapabilities(req, profile)
-	ns.SkillScore = s.scoreSkills(req, profile)
+	ns.SkillScore = s.scoreSkills(skills, profile)
 	ns.ResourceScore = s.scoreResources(req, profile)
 	ns.LoadScore = s.scoreLoad(profile)
 	ns.TagScore = s.scoreTags(req, profile)
@@ -229,23 +264,30 @@ func (s *AISelector) scoreCapabilities(req *ScheduleRequest, profile *GolemProfi
 	return float64(matched) / float64(len(req.RequiredCapabilities))
 }
 
-// scoreSkills returns the fraction of required skills that are installed.
-func (s *AISelector) scoreSkills(req *ScheduleRequest, profile *GolemProfile) float64 {
-	if len(req.RequiredSkills) == 0 {
-		return 1.0
+// scoreSkills returns the fraction of required skills that are installed in a
+// compatible version. A skill counts less when a newer compatible version is
+// installed on another candidate.
+func (s *AISelector) scoreSkills(skills *skillScoring, profile *GolemProfile) float64 {
+	if skills.invalid {
+		return 0
 	}
-	skillSet := make(map[string]struct{}, len(profile.InstalledSkills))
-	for _, sk := range profile.InstalledSkills {
-		skillSet[sk.ID] = struct{}{}
-		skillSet[sk.Name] = struct{}{}
+	if len(skills.reqs) == 0 {
+		return 1.0
 	}
-	matched := 0
-	for _, rs := range req.RequiredSkills {
-		if _, ok := skillSet[rs]; ok {
-			matched++
+	score := 0.0
+	for i, r := range skills.reqs {
+		sk, _ := r.Match(profile)
+		if sk == nil {
+			continue
+		}
+		score += 1.0
+		if newest := skills.newest[i]; newest != nil {
+			if v, err := versionutil.ParseSemantic(sk.Version); err != nil || v.LessThan(newest) {
+				score -= olderSkillPenalty
+			}
 		}
 	}
-	return float64(matched) / float64(len(req.RequiredSkills))
+	return score / float64(len(skills.reqs))
 }
 
 // scoreResources evaluates available system resources (higher is better).
@@ -443,10 +485,13 @@ type constraintChecker struct{}
 // check returns an empty string if the node passes all constraints, or a
 // human-readable rejection reason.
 func (c *constraintChecker) check(req *ScheduleRequest, profile *GolemProfile) string {
-	// 1. Node must be online.
+	// 1. Node must be online and schedulable.
 	if profile.NodeInfo.Status != "online" {
 		return fmt.Sprintf("node status is %q, expected online", profile.NodeInfo.Status)
 	}
+	if profile.Cordoned {
+		return "node is cordoned"
+	}
 
 	// 2. Required capabilities.
 	if len(req.RequiredCapabilities) > 0 {
@@ -461,16 +506,15 @@ func (c *constraintChecker) check(req *ScheduleRequest, profile *GolemProfile) s
 		}
 	}
 
-	// 3. Required skills.
+	// 3. Required skills, in a version satisfying their constraints.
 	if len(req.RequiredSkills) > 0 {
-		skillSet := make(map[string]struct{}, len(profile.InstalledSkills))
-		for _, sk := range profile.InstalledSkills {
-			skillSet[sk.ID] = struct{}{}
-			skillSet[sk.Name] = struct{}{}
+		reqs, err := ParseSkillRequirements(req.RequiredSkills)
+		if err != nil {
+			return fmt.Sprintf("invalid skill requirement: %s", err.Error())
 		}
-		for _, rs := range req.RequiredSkills {
-			if _, ok := skillSet[rs]; !ok {
-				return fmt.Sprintf("missing required skill %q", rs)
+		for _, r := range reqs {
+			if sk, reason := r.Match(profile); sk == nil {
+				return reason
 			}
 		}
 	}
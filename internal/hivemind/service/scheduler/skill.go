@@ -0,0 +1,179 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kiosk404/eidolon/internal/pkg/skill"
+	versionutil "github.com/kiosk404/eidolon/pkg/version/util"
+)
+
+// SkillRequirement is a parsed entry of ScheduleRequest.RequiredSkills: a skill
+// ID or name optionally followed by comma separated version constraints, like:
+//
+//	web-search
+//	web-search>=1.2,<2
+//	web-search@1.2.0
+//
+// The constraints are skill.VersionConstraints, '@' is a shorthand for '='.
+// Tilde and caret ranges are not supported and rejected, write >=1.2,<1.3
+// instead of ~1.2.
+type SkillRequirement struct {
+	// Skill is the ID or name of the skill.
+	Skill string
+
+	constraints skill.VersionConstraints
+}
+
+// unsupportedSkillOperators are the range operators of other package managers
+// that are rejected rather than read as part of a skill name.
+const unsupportedSkillOperators = "~^"
+
+// ParseSkillRequirement parses a skill requirement.
+func ParseSkillRequirement(s string) (*SkillRequirement, error) {
+	s = strings.TrimSpace(s)
+	if j := strings.IndexAny(s, unsupportedSkillOperators); j >= 0 {
+		return nil, fmt.Errorf("skill requirement %q: unsupported operator %c", s, s[j])
+	}
+	i := strings.IndexAny(s, "<>=!@")
+	if i < 0 {
+		if s == "" {
+			return nil, fmt.Errorf("empty skill requirement")
+		}
+		return &SkillRequirement{Skill: s}, nil
+	}
+
+	r := &SkillRequirement{Skill: strings.TrimSpace(s[:i])}
+	if r.Skill == "" {
+		return nil, fmt.Errorf("skill requirement %q does not name a skill", s)
+	}
+
+	spec := s[i:]
+	if v, ok := strings.CutPrefix(spec, "@"); ok {
+		spec = "=" + v
+	}
+	constraints, err := skill.ParseVersionConstraints(spec)
+	if err != nil {
+		return nil, fmt.Errorf("skill requirement %q: %w", s, err)
+	}
+	r.constraints = constraints
+
+	return r, nil
+}
+
+// ParseSkillRequirements parses every requirement of a ScheduleRequest.
+func ParseSkillRequirements(list []string) ([]*SkillRequirement, error) {
+	reqs := make([]*SkillRequirement, 0, len(list))
+	for _, s := range list {
+		r, err := ParseSkillRequirement(s)
+		if err != nil {
+			return nil, err
+		}
+		reqs = append(reqs, r)
+	}
+
+	return reqs, nil
+}
+
+// String returns the requirement as it is written in a request.
+func (r *SkillRequirement) String() string {
+	if len(r.constraints) == 0 {
+		return r.Skill
+	}
+
+	return r.Skill + r.Constraints()
+}
+
+// Constraints returns the version constraints, like: >=1.2.0,<2.0.0.
+func (r *SkillRequirement) Constraints() string {
+	return r.constraints.String()
+}
+
+// Names reports whether sk is the skill the requirement is about.
+func (r *SkillRequirement) Names(sk *SkillInfo) bool {
+	return sk.ID == r.Skill || sk.Name == r.Skill
+}
+
+// Allows reports whether version satisfies the constraints.
+func (r *SkillRequirement) Allows(version string) bool {
+	return r.constraints.Allows(version)
+}
+
+// Match returns the newest skill of profile that satisfies the requirement,
+// or nil and the reason why none does.
+func (r *SkillRequirement) Match(profile *GolemProfile) (*SkillInfo, string) {
+	var (
+		best       *SkillInfo
+		bestV      *versionutil.Version
+		mismatched []string
+	)
+	for i := range profile.InstalledSkills {
+		sk := &profile.InstalledSkills[i]
+		if !r.Names(sk) {
+			continue
+		}
+		if !r.Allows(sk.Version) {
+			mismatched = append(mismatched, sk.Version)
+			continue
+		}
+		v, _ := versionutil.ParseSemantic(sk.Version)
+		if best == nil || (v != nil && (bestV == nil || bestV.LessThan(v))) {
+			best, bestV = sk, v
+		}
+	}
+
+	switch {
+	case best != nil:
+		return best, ""
+	case len(mismatched) > 0:
+		return nil, fmt.Sprintf("skill %q version %s does not satisfy %s",
+			r.Skill, strings.Join(mismatched, ", "), r.Constraints())
+	default:
+		return nil, fmt.Sprintf("missing required skill %q", r.Skill)
+	}
+}
+
+// olderSkillPenalty is taken off the score of a required skill when the node
+// runs an older compatible version than the newest one among the eligible
+// candidates.
+const olderSkillPenalty = 0.25
+
+// skillScoring holds what scoring the skills of a node needs to know about
+// the request and the eligible candidates.
+type skillScoring struct {
+	reqs []*SkillRequirement
+
+	// newest is the newest compatible version of every requirement.
+	newest []*versionutil.Version
+
+	// invalid is set when the requirements do not parse.
+	invalid bool
+}
+
+func newSkillScoring(req *ScheduleRequest, candidates []GolemProfile) *skillScoring {
+	reqs, err := ParseSkillRequirements(req.RequiredSkills)
+	if err != nil {
+		return &skillScoring{invalid: true}
+	}
+
+	return &skillScoring{reqs: reqs, newest: newestCompatible(reqs, candidates)}
+}
+
+// newestCompatible returns, for every requirement, the newest version that
+// satisfies it among the candidates, nil when none has a parsable one.
+func newestCompatible(reqs []*SkillRequirement, candidates []GolemProfile) []*versionutil.Version {
+	newest := make([]*versionutil.Version, len(reqs))
+	for i, r := range reqs {
+		for j := range candidates {
+			sk, _ := r.Match(&candidates[j])
+			if sk == nil {
+				continue
+			}
+			if v, err := versionutil.ParseSemantic(sk.Version); err == nil && (newest[i] == nil || newest[i].LessThan(v)) {
+				newest[i] = v
+			}
+		}
+	}
+
+	return newest
+}
@@ -0,0 +1,125 @@
+package scheduler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+)
+
+func TestParseSkillRequirement(t *testing.T) {
+	for _, tc := range []struct {
+		in, skill, constraints, wantErr string
+	}{
+		{in: "web-search", skill: "web-search"},
+		{in: "  web-search  ", skill: "web-search"},
+		{in: "web-search>=1.2,<2", skill: "web-search", constraints: ">=1.2.0,<2.0.0"},
+		{in: "web-search >= 1.2 , < 2", skill: "web-search", constraints: ">=1.2.0,<2.0.0"},
+		{in: "web-search@1.2.0", skill: "web-search", constraints: "=1.2.0"},
+		{in: "web-search==1", skill: "web-search", constraints: "==1.0.0"},
+		{in: "web-search!=1.3.0", skill: "web-search", constraints: "!=1.3.0"},
+		{in: "web-search>1.2,<=1.4", skill: "web-search", constraints: ">1.2.0,<=1.4.0"},
+		{in: "web-search>=2.0.0-rc.1", skill: "web-search", constraints: ">=2.0.0-rc.1"},
+		{in: "", wantErr: "empty skill requirement"},
+		{in: ">=1.2", wantErr: "does not name a skill"},
+		{in: "web-search~1.2", wantErr: "unsupported operator ~"},
+		{in: "web-search^1.2", wantErr: "unsupported operator ^"},
+		{in: "web-search>=1.2,2", wantErr: "must start with one of"},
+		{in: "web-search>=latest", wantErr: "web-search>=latest"},
+		{in: "web-search>=1.2,", wantErr: "must start with one of"},
+	} {
+		r, err := ParseSkillRequirement(tc.in)
+		if tc.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("ParseSkillRequirement(%q) error = %v, want it to contain %q", tc.in, err, tc.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseSkillRequirement(%q): %v", tc.in, err)
+			continue
+		}
+		if r.Skill != tc.skill || r.Constraints() != tc.constraints {
+			t.Errorf("ParseSkillRequirement(%q) = %q %q, want %q %q",
+				tc.in, r.Skill, r.Constraints(), tc.skill, tc.constraints)
+		}
+	}
+}
+
+func TestSkillRequirementAllows(t *testing.T) {
+	for _, tc := range []struct {
+		req     string
+		version string
+		want    bool
+	}{
+		{"web-search", "1.2.0", true},
+		{"web-search", "not-a-version", true},
+		{"web-search", "2.0.0-rc.1", true},
+		{"web-search>=1.2,<2", "1.2.0", true},
+		{"web-search>=1.2,<2", "1.9.3", true},
+		{"web-search>=1.2,<2", "1.1.9", false},
+		{"web-search>=1.2,<2", "2.0.0", false},
+		{"web-search>=1.2,<2", "not-a-version", false},
+		{"web-search>=1.2,<2", "2.0.0-rc.1", false},
+		{"web-search>=1.2,<2", "1.5.0-beta.1", false},
+		{"web-search>=1.2,<2", "1.5.0+build.7", true},
+		{"web-search>=2.0.0-rc.0", "2.0.0-rc.1", true},
+		{"web-search>=2.0.0-rc.0", "2.0.0", true},
+		{"web-search>=2.0.0-rc.0", "2.1.0-rc.1", false},
+		{"web-search>=2.0.0-rc.2", "2.0.0-rc.1", false},
+		{"web-search@1.2.0", "1.2.0", true},
+		{"web-search@1.2.0", "1.2.1", false},
+		{"web-search!=1.3.0", "1.3.0", false},
+		{"web-search!=1.3.0", "1.3.1", true},
+		{"web-search>1.2,<=1.4", "1.2.0", false},
+		{"web-search>1.2,<=1.4", "1.4.0", true},
+	} {
+		r, err := ParseSkillRequirement(tc.req)
+		if err != nil {
+			t.Fatalf("ParseSkillRequirement(%q): %v", tc.req, err)
+		}
+		if got := r.Allows(tc.version); got != tc.want {
+			t.Errorf("%s Allows(%q) = %v, want %v", tc.req, tc.version, got, tc.want)
+		}
+	}
+}
+
+func TestSkillRequirementMatch(t *testing.T) {
+	skills := []SkillInfo{
+		{ID: "skill-1", Name: "web-search", Version: "1.2.0"},
+		{ID: "skill-1", Name: "web-search", Version: "1.4.0"},
+		{ID: "skill-1", Name: "web-search", Version: "2.0.0-rc.1"},
+		{ID: "skill-2", Name: "summarize", Version: "0.3.0"},
+	}
+	profile := testProfile("node-a", protocol.NodeStatusOnline, skills...)
+
+	for _, tc := range []struct {
+		req         string
+		wantVersion string
+		wantReason  string
+	}{
+		{req: "web-search", wantVersion: "2.0.0-rc.1"},
+		{req: "skill-1", wantVersion: "2.0.0-rc.1"},
+		{req: "web-search>=1.2,<2", wantVersion: "1.4.0"},
+		{req: "web-search<1.3", wantVersion: "1.2.0"},
+		{req: "web-search>=2.0.0-rc.0", wantVersion: "2.0.0-rc.1"},
+		{req: "summarize@0.3", wantVersion: "0.3.0"},
+		{req: "web-search>=3", wantReason: `skill "web-search" version 1.2.0, 1.4.0, 2.0.0-rc.1 does not satisfy >=3.0.0`},
+		{req: "translate", wantReason: `missing required skill "translate"`},
+	} {
+		r, err := ParseSkillRequirement(tc.req)
+		if err != nil {
+			t.Fatalf("ParseSkillRequirement(%q): %v", tc.req, err)
+		}
+		sk, reason := r.Match(&profile)
+		if tc.wantReason != "" {
+			if sk != nil || reason != tc.wantReason {
+				t.Errorf("%s Match = %+v %q, want none %q", tc.req, sk, reason, tc.wantReason)
+			}
+			continue
+		}
+		if sk == nil || sk.Version != tc.wantVersion || reason != "" {
+			t.Errorf("%s Match = %+v %q, want version %s", tc.req, sk, reason, tc.wantVersion)
+		}
+	}
+}
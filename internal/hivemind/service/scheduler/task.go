@@ -2,6 +2,8 @@ package scheduler
 
 import (
 	"time"
+
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
 )
 
 // ScheduleMode defines how a Golem node is selected for task execution.
@@ -36,12 +38,18 @@ type ScheduleRequest struct {
 	// In DirectMode, this is used for validation; in AIMode, for filtering candidates.
 	RequiredCapabilities []string
 
-	// RequiredSkills lists the skills that must be installed on the target Golem.
+	// RequiredSkills lists the skills that must be installed on the target Golem,
+	// each optionally constrained to a version range, see SkillRequirement.
 	RequiredSkills []string
 
 	// RequiredFeatures lists the features that the target Golem must support.
 	RequiredFeatures []string
 
+	// InstallMissingSkills asks the scheduler, when no node has the required
+	// skills, to have a node that meets every other constraint install them
+	// from the skill registry and to dispatch the task to it afterwards.
+	InstallMissingSkills bool
+
 	// PreferredTags are soft preferences for node selection (e.g., {"region": "us-west"}).
 	// Matching tags increase a node's score but are not mandatory.
 	PreferredTags map[string]string
@@ -191,6 +199,14 @@ type GolemProfile struct {
 	// HealthScore is a composite health indicator (0.0 = dead, 1.0 = perfect).
 	HealthScore float64
 
+	// Cordoned marks the node as unschedulable. Tasks already running on it are
+	// not affected, but no selector will pick it for new tasks.
+	Cordoned bool
+
+	// Draining marks a cordoned node that is waiting for its running tasks to
+	// finish before it is deregistered.
+	Draining bool
+
 	// LastUpdated records when this profile was last refreshed.
 	LastUpdated time.Time
 }
@@ -264,6 +280,10 @@ const (
 
 	// EventTypeRescheduled is emitted when a task is re-queued after a node failure.
 	EventTypeRescheduled TaskEventType = "rescheduled"
+
+	// EventTypeSkillInstalling is emitted when a node is asked to install the
+	// missing skills of a task before it is dispatched there.
+	EventTypeSkillInstalling TaskEventType = "skill_installing"
 )
 
 // TaskEventListener receives notifications about task lifecycle transitions.
@@ -319,12 +339,20 @@ func (b *ScheduleRequestBuilder) WithRequiredCapabilities(caps ...string) *Sched
 	return b
 }
 
-// WithRequiredSkills sets the skills that must be installed on the target Golem.
+// WithRequiredSkills sets the skills that must be installed on the target Golem,
+// like: web-search>=1.2,<2.
 func (b *ScheduleRequestBuilder) WithRequiredSkills(skills ...string) *ScheduleRequestBuilder {
 	b.request.RequiredSkills = skills
 	return b
 }
 
+// WithInstallMissingSkills lets the scheduler install the required skills on a
+// node that lacks them.
+func (b *ScheduleRequestBuilder) WithInstallMissingSkills() *ScheduleRequestBuilder {
+	b.request.InstallMissingSkills = true
+	return b
+}
+
 // WithRequiredFeatures sets the features that the target Golem must support.
 func (b *ScheduleRequestBuilder) WithRequiredFeatures(features ...string) *ScheduleRequestBuilder {
 	b.request.RequiredFeatures = features
@@ -0,0 +1,391 @@
+// Package skillregistry hosts the skill packages the hivemind installs on
+// golems that lack the skills a task requires.
+package skillregistry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kiosk404/eidolon/internal/hivemind/service/scheduler"
+	"github.com/kiosk404/eidolon/internal/pkg/skill"
+	"github.com/kiosk404/eidolon/internal/pkg/workspace"
+	"github.com/kiosk404/eidolon/pkg/logger"
+	versionutil "github.com/kiosk404/eidolon/pkg/version/util"
+)
+
+const logModule = "skillregistry"
+
+var (
+	// ErrSkillNotFound is returned when a skill or one of its versions is not in the registry.
+	ErrSkillNotFound = errors.New("skill not found")
+
+	// ErrVersionExists is returned when a version is uploaded again with another content.
+	ErrVersionExists = errors.New("skill version already exists")
+
+	// ErrInvalidPackage is returned when an upload is not a valid skill package.
+	ErrInvalidPackage = errors.New("invalid skill package")
+)
+
+// Package is a version of a skill stored in the registry.
+type Package struct {
+	skill.Manifest
+
+	// Digest is the digest of the package archive, like: sha256:9f86d0...
+	Digest string `json:"digest"`
+
+	// Size is the size of the archive in bytes.
+	Size int64 `json:"size"`
+
+	UploadedAt time.Time `json:"uploaded_at"`
+}
+
+// Registry stores skill packages on the local disk, laid out as:
+//
+//	<dir>/blobs/sha256/<hex>             the package archives, by digest
+//	<dir>/packages/<id>/<version>.json   the manifest and digest of every version
+//
+// Archives are content addressed, so versions uploaded twice share a blob.
+// The index is loaded in memory when the Registry is created.
+type Registry struct {
+	dir     string
+	maxSize int64
+
+	mu       sync.RWMutex
+	packages map[string]map[string]*Package // id -> version -> package
+}
+
+// NewRegistry creates a Registry of the packages in dir, creating it if
+// needed. Uploads larger than maxSize bytes are refused.
+func NewRegistry(dir string, maxSize int64) (*Registry, error) {
+	r := &Registry{
+		dir:      dir,
+		maxSize:  maxSize,
+		packages: make(map[string]map[string]*Package),
+	}
+	for _, d := range []string{r.blobDir(), r.indexDir(), r.tmpDir()} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			return nil, err
+		}
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// load reads the index. Entries that cannot be read or whose blob is missing
+// are logged and skipped.
+func (r *Registry) load() error {
+	paths, err := filepath.Glob(filepath.Join(r.indexDir(), "*", "*.json"))
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		pkg := &Package{}
+		if err := json.Unmarshal(data, pkg); err != nil {
+			logger.WarnX(logModule, "skipping skill index entry %s: %s", path, err.Error())
+			continue
+		}
+		if _, err := os.Stat(r.blobPath(pkg.Digest)); err != nil {
+			logger.WarnX(logModule, "skipping skill %s@%s: %s", pkg.ID, pkg.Version, err.Error())
+			continue
+		}
+		r.add(pkg)
+	}
+	logger.InfoX(logModule, "%d skills in registry %s", len(r.packages), r.dir)
+
+	return nil
+}
+
+// Upload stores the package archive read from rd, a tar file optionally
+// compressed with gzip. Uploading a version again with the same content
+// returns the stored package and false.
+func (r *Registry) Upload(_ context.Context, rd io.Reader) (*Package, bool, error) {
+	f, err := os.CreateTemp(r.tmpDir(), "upload-")
+	if err != nil {
+		return nil, false, err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(f, h), io.LimitReader(rd, r.maxSize+1))
+	if err != nil {
+		return nil, false, err
+	}
+	if size > r.maxSize {
+		return nil, false, fmt.Errorf("%w: larger than %d bytes", ErrInvalidPackage, r.maxSize)
+	}
+	digest := skill.DigestPrefix + hex.EncodeToString(h.Sum(nil))
+
+	manifest, err := r.inspect(f)
+	if err != nil {
+		return nil, false, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if old, ok := r.packages[manifest.ID][manifest.Version]; ok {
+		if old.Digest != digest {
+			return nil, false, fmt.Errorf("skill %s@%s: %w", manifest.ID, manifest.Version, ErrVersionExists)
+		}
+		return clonePackage(old), false, nil
+	}
+
+	if err := f.Close(); err != nil {
+		return nil, false, err
+	}
+	blob := r.blobPath(digest)
+	if _, err := os.Stat(blob); errors.Is(err, fs.ErrNotExist) {
+		if err := os.Rename(f.Name(), blob); err != nil {
+			return nil, false, err
+		}
+	}
+
+	pkg := &Package{Manifest: *manifest, Digest: digest, Size: size, UploadedAt: time.Now().UTC()}
+	data, err := json.MarshalIndent(pkg, "", "  ")
+	if err != nil {
+		return nil, false, err
+	}
+	if err := workspace.WriteFile(r.indexPath(pkg.ID, pkg.Version), data, 0o644); err != nil {
+		return nil, false, err
+	}
+	r.add(pkg)
+	logger.InfoX(logModule, "skill %s@%s uploaded (%s)", pkg.ID, pkg.Version, digest)
+
+	return clonePackage(pkg), true, nil
+}
+
+// inspect unpacks the archive in f to a temporary directory and loads the
+// package to make sure golems will be able to install it.
+func (r *Registry) inspect(f *os.File) (*skill.Manifest, error) {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	dir, err := os.MkdirTemp(r.tmpDir(), "inspect-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	if err := skill.Extract(f, dir); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPackage, err.Error())
+	}
+	root, err := skill.FindRoot(dir)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPackage, err.Error())
+	}
+	pkg, err := skill.Load(root)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPackage, strings.ReplaceAll(err.Error(), root+"/", ""))
+	}
+
+	return &pkg.Manifest, nil
+}
+
+// List returns every version of every skill, sorted by ID then newest first.
+func (r *Registry) List(_ context.Context) ([]*Package, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var list []*Package
+	for _, versions := range r.packages {
+		for _, pkg := range versions {
+			list = append(list, clonePackage(pkg))
+		}
+	}
+	sortPackages(list)
+
+	return list, nil
+}
+
+// Versions returns the versions of skill id, newest first.
+func (r *Registry) Versions(_ context.Context, id string) ([]*Package, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	versions, ok := r.packages[id]
+	if !ok {
+		return nil, fmt.Errorf("skill %q: %w", id, ErrSkillNotFound)
+	}
+	list := make([]*Package, 0, len(versions))
+	for _, pkg := range versions {
+		list = append(list, clonePackage(pkg))
+	}
+	sortPackages(list)
+
+	return list, nil
+}
+
+// Get returns version of skill id.
+func (r *Registry) Get(_ context.Context, id, version string) (*Package, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	pkg, ok := r.packages[id][version]
+	if !ok {
+		return nil, fmt.Errorf("skill %s@%s: %w", id, version, ErrSkillNotFound)
+	}
+
+	return clonePackage(pkg), nil
+}
+
+// Open returns version of skill id and a reader of its archive, which the
+// caller must close.
+func (r *Registry) Open(ctx context.Context, id, version string) (*Package, io.ReadCloser, error) {
+	pkg, err := r.Get(ctx, id, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	f, err := os.Open(r.blobPath(pkg.Digest))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pkg, f, nil
+}
+
+// Delete removes version of skill id. Its archive is removed unless another
+// version shares it.
+func (r *Registry) Delete(_ context.Context, id, version string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pkg, ok := r.packages[id][version]
+	if !ok {
+		return fmt.Errorf("skill %s@%s: %w", id, version, ErrSkillNotFound)
+	}
+	if err := os.Remove(r.indexPath(id, version)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	delete(r.packages[id], version)
+	if len(r.packages[id]) == 0 {
+		delete(r.packages, id)
+		_ = os.Remove(filepath.Join(r.indexDir(), id))
+	}
+
+	if !r.referenced(pkg.Digest) {
+		if err := os.Remove(r.blobPath(pkg.Digest)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	logger.InfoX(logModule, "skill %s@%s deleted", id, version)
+
+	return nil
+}
+
+// Resolve returns the newest version that satisfies req.
+func (r *Registry) Resolve(_ context.Context, req *scheduler.SkillRequirement) (*Package, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var (
+		best  *Package
+		bestV *versionutil.Version
+	)
+	for _, versions := range r.packages {
+		for _, pkg := range versions {
+			if pkg.ID != req.Skill && pkg.Name != req.Skill || !req.Allows(pkg.Version) {
+				continue
+			}
+			v, err := versionutil.ParseSemantic(pkg.Version)
+			if err != nil {
+				continue
+			}
+			if best == nil || bestV.LessThan(v) {
+				best, bestV = pkg, v
+			}
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("no version of skill %q satisfies %q: %w", req.Skill, req.String(), ErrSkillNotFound)
+	}
+
+	return clonePackage(best), nil
+}
+
+// add must be called with r.mu held, or before r is shared.
+func (r *Registry) add(pkg *Package) {
+	versions, ok := r.packages[pkg.ID]
+	if !ok {
+		versions = make(map[string]*Package)
+		r.packages[pkg.ID] = versions
+	}
+	versions[pkg.Version] = pkg
+}
+
+// referenced reports whether a package has the given digest; r.mu must be held.
+func (r *Registry) referenced(digest string) bool {
+	for _, versions := range r.packages {
+		for _, pkg := range versions {
+			if pkg.Digest == digest {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (r *Registry) blobDir() string {
+	return filepath.Join(r.dir, "blobs", "sha256")
+}
+
+func (r *Registry) indexDir() string {
+	return filepath.Join(r.dir, "packages")
+}
+
+func (r *Registry) tmpDir() string {
+	return filepath.Join(r.dir, "tmp")
+}
+
+func (r *Registry) blobPath(digest string) string {
+	return filepath.Join(r.blobDir(), strings.TrimPrefix(digest, skill.DigestPrefix))
+}
+
+func (r *Registry) indexPath(id, version string) string {
+	return filepath.Join(r.indexDir(), id, version+".json")
+}
+
+func clonePackage(pkg *Package) *Package {
+	out := *pkg
+	out.Capabilities = append([]string(nil), pkg.Capabilities...)
+	out.RequiredFeatures = append([]string(nil), pkg.RequiredFeatures...)
+
+	return &out
+}
+
+// sortPackages sorts packages by ID, then from the newest to the oldest.
+func sortPackages(list []*Package) {
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].ID != list[j].ID {
+			return list[i].ID < list[j].ID
+		}
+		a, errA := versionutil.ParseSemantic(list[i].Version)
+		b, errB := versionutil.ParseSemantic(list[j].Version)
+		if errA != nil || errB != nil {
+			return list[i].Version > list[j].Version
+		}
+
+		return b.LessThan(a)
+	})
+}
@@ -0,0 +1,38 @@
+package skillregistry
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/kiosk404/eidolon/internal/hivemind/service/scheduler"
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+)
+
+var _ scheduler.SkillResolver = &Registry{}
+
+// ResolveSkills returns the newest package of the registry that satisfies
+// every requirement, so that the scheduler can have them installed.
+func (r *Registry) ResolveSkills(ctx context.Context, reqs []*scheduler.SkillRequirement) ([]protocol.SkillPackage, error) {
+	pkgs := make([]protocol.SkillPackage, 0, len(reqs))
+	for _, req := range reqs {
+		pkg, err := r.Resolve(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		pkgs = append(pkgs, protocol.SkillPackage{
+			ID:          pkg.ID,
+			Version:     pkg.Version,
+			Digest:      pkg.Digest,
+			Size:        pkg.Size,
+			Path:        PackagePath(pkg.ID, pkg.Version),
+			Constraints: req.Constraints(),
+		})
+	}
+
+	return pkgs, nil
+}
+
+// PackagePath returns the path of the archive of a package on the hivemind API.
+func PackagePath(id, version string) string {
+	return "/api/v1/skills/" + url.PathEscape(id) + "/versions/" + url.PathEscape(version) + "/package"
+}
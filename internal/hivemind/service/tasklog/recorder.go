@@ -0,0 +1,246 @@
+// Package tasklog keeps the recent lifecycle events of every task so that
+// clients can read them back or follow them live.
+package tasklog
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kiosk404/eidolon/internal/hivemind/service/scheduler"
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+)
+
+// RecorderConfig holds the limits of a Recorder.
+type RecorderConfig struct {
+	// MaxEventsPerTask bounds the history of a single task; the oldest events are dropped first.
+	MaxEventsPerTask int
+
+	// MaxTasks bounds the number of tasks with a history; the oldest tasks are forgotten first.
+	MaxTasks int
+
+	// WatchBuffer is the number of events buffered for a watcher. A watcher that
+	// falls further behind is disconnected.
+	WatchBuffer int
+}
+
+// DefaultRecorderConfig returns a RecorderConfig with sensible defaults.
+func DefaultRecorderConfig() RecorderConfig {
+	return RecorderConfig{
+		MaxEventsPerTask: 256,
+		MaxTasks:         1024,
+		WatchBuffer:      64,
+	}
+}
+
+// Event is a recorded task lifecycle event.
+type Event struct {
+	// Seq numbers the events of a task from 1.
+	Seq int64
+
+	Type      scheduler.TaskEventType
+	TaskID    string
+	Status    protocol.TaskStatus
+	NodeID    string
+	Reason    string
+	Progress  *protocol.TaskProgress
+	Result    *protocol.TaskResult
+	Error     string
+	Timestamp time.Time
+}
+
+// IsTerminal reports whether no event of the task follows e.
+func (e *Event) IsTerminal() bool {
+	switch e.Type {
+	case scheduler.EventTypeCompleted, scheduler.EventTypeFailed,
+		scheduler.EventTypeCancelled, scheduler.EventTypeTimedOut:
+		return true
+	}
+
+	return false
+}
+
+type taskLog struct {
+	events   []*Event
+	seq      int64
+	done     bool
+	watchers map[*watcher]struct{}
+}
+
+type watcher struct {
+	ch chan *Event
+}
+
+// Recorder is a scheduler.TaskEventListener that keeps a bounded history of
+// events per task and fans new events out to watchers.
+type Recorder struct {
+	config RecorderConfig
+
+	mu    sync.Mutex
+	logs  map[string]*taskLog
+	order []string
+}
+
+var _ scheduler.TaskEventListener = &Recorder{}
+
+// NewRecorder creates a Recorder.
+func NewRecorder(config RecorderConfig) *Recorder {
+	defaults := DefaultRecorderConfig()
+	if config.MaxEventsPerTask <= 0 {
+		config.MaxEventsPerTask = defaults.MaxEventsPerTask
+	}
+	if config.MaxTasks <= 0 {
+		config.MaxTasks = defaults.MaxTasks
+	}
+	if config.WatchBuffer <= 0 {
+		config.WatchBuffer = defaults.WatchBuffer
+	}
+
+	return &Recorder{
+		config: config,
+		logs:   make(map[string]*taskLog),
+	}
+}
+
+// OnEvent records the event and passes it on to the watchers of its task.
+func (r *Recorder) OnEvent(event *scheduler.TaskEvent) {
+	e := newEvent(event)
+	if e.TaskID == "" {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	l := r.logFor(e.TaskID)
+	l.seq++
+	e.Seq = l.seq
+	l.events = append(l.events, e)
+	if n := len(l.events) - r.config.MaxEventsPerTask; n > 0 {
+		l.events = append([]*Event(nil), l.events[n:]...)
+	}
+
+	for w := range l.watchers {
+		select {
+		case w.ch <- e:
+		default:
+			// The watcher is too slow, it reads the history again when it reconnects.
+			r.removeWatcher(l, w)
+		}
+	}
+
+	if e.IsTerminal() {
+		l.done = true
+		for w := range l.watchers {
+			r.removeWatcher(l, w)
+		}
+	}
+}
+
+// Events returns the recorded events of a task, oldest first.
+func (r *Recorder) Events(taskID string) []*Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	l, ok := r.logs[taskID]
+	if !ok {
+		return nil
+	}
+
+	return append([]*Event(nil), l.events...)
+}
+
+// Watch returns the recorded events of a task and a channel that receives the
+// events recorded afterwards. The channel is closed after the terminal event of
+// the task, when the watcher falls behind or when stop is called.
+func (r *Recorder) Watch(taskID string) (history []*Event, events <-chan *Event, stop func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	l := r.logFor(taskID)
+	history = append([]*Event(nil), l.events...)
+
+	w := &watcher{ch: make(chan *Event, r.config.WatchBuffer)}
+	if l.done {
+		close(w.ch)
+		return history, w.ch, func() {}
+	}
+	l.watchers[w] = struct{}{}
+
+	var once sync.Once
+	stop = func() {
+		once.Do(func() {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.removeWatcher(l, w)
+		})
+	}
+
+	return history, w.ch, stop
+}
+
+// logFor returns the log of a task, creating it and forgetting the oldest task
+// without watchers if there are too many. It must be called with mu held.
+func (r *Recorder) logFor(taskID string) *taskLog {
+	if l, ok := r.logs[taskID]; ok {
+		return l
+	}
+
+	if len(r.logs) >= r.config.MaxTasks {
+		for i, id := range r.order {
+			if len(r.logs[id].watchers) == 0 {
+				delete(r.logs, id)
+				r.order = append(r.order[:i:i], r.order[i+1:]...)
+				break
+			}
+		}
+	}
+
+	l := &taskLog{watchers: make(map[*watcher]struct{})}
+	r.logs[taskID] = l
+	r.order = append(r.order, taskID)
+
+	return l
+}
+
+// removeWatcher must be called with mu held.
+func (r *Recorder) removeWatcher(l *taskLog, w *watcher) {
+	if _, ok := l.watchers[w]; !ok {
+		return
+	}
+	delete(l.watchers, w)
+	close(w.ch)
+}
+
+func newEvent(event *scheduler.TaskEvent) *Event {
+	e := &Event{
+		Type:      event.Type,
+		NodeID:    event.NodeID,
+		Progress:  event.Progress,
+		Result:    event.Result,
+		Timestamp: event.Timestamp,
+	}
+	if t := event.Task; t != nil {
+		e.TaskID, e.Status = t.ID, t.Status
+		if e.NodeID == "" {
+			e.NodeID = t.AssignedNodeID
+		}
+	}
+	switch {
+	case e.TaskID != "":
+	case event.Progress != nil:
+		e.TaskID = event.Progress.TaskID
+	case event.Result != nil:
+		e.TaskID = event.Result.TaskID
+	}
+	if event.Decision != nil {
+		e.Reason = event.Decision.Reason
+	}
+	if event.Error != nil {
+		e.Error = event.Error.Error()
+	}
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now()
+	}
+
+	return e
+}
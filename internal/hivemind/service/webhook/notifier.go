@@ -0,0 +1,281 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/kiosk404/eidolon/internal/hivemind/service/scheduler"
+	"github.com/kiosk404/eidolon/pkg/logger"
+	"github.com/kiosk404/eidolon/pkg/utils/idutil"
+)
+
+const logModule = "webhook"
+
+// NotifierConfig holds configuration for webhook delivery.
+type NotifierConfig struct {
+	// Workers is the number of concurrent delivery goroutines.
+	Workers int
+
+	// QueueSize bounds the number of pending deliveries.
+	QueueSize int
+
+	// MaxAttempts is the total number of attempts per delivery, including the first one.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry; it doubles on every retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between retries.
+	MaxBackoff time.Duration
+
+	// RequestTimeout bounds a single HTTP request.
+	RequestTimeout time.Duration
+}
+
+// DefaultNotifierConfig returns a NotifierConfig with sensible defaults.
+func DefaultNotifierConfig() NotifierConfig {
+	return NotifierConfig{
+		Workers:        4,
+		QueueSize:      1024,
+		MaxAttempts:    5,
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Minute,
+		RequestTimeout: 10 * time.Second,
+	}
+}
+
+// job is a single event queued for delivery to a single webhook.
+type job struct {
+	webhook *Webhook
+	eventID string
+	event   scheduler.TaskEventType
+	body    []byte
+}
+
+// Notifier is a scheduler.TaskEventListener that fans task lifecycle events
+// out to every subscribed webhook, signing each request and retrying with
+// exponential backoff.
+type Notifier struct {
+	config NotifierConfig
+	store  Store
+	client *http.Client
+
+	jobs     chan *job
+	wg       sync.WaitGroup
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+var _ scheduler.TaskEventListener = &Notifier{}
+
+// NewNotifier creates a Notifier that reads webhooks from store.
+func NewNotifier(config NotifierConfig, store Store) *Notifier {
+	defaults := DefaultNotifierConfig()
+	if config.Workers <= 0 {
+		config.Workers = defaults.Workers
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = defaults.QueueSize
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaults.MaxAttempts
+	}
+	if config.InitialBackoff <= 0 {
+		config.InitialBackoff = defaults.InitialBackoff
+	}
+	if config.MaxBackoff < config.InitialBackoff {
+		config.MaxBackoff = config.InitialBackoff
+	}
+	if config.RequestTimeout <= 0 {
+		config.RequestTimeout = defaults.RequestTimeout
+	}
+
+	return &Notifier{
+		config: config,
+		store:  store,
+		client: &http.Client{Timeout: config.RequestTimeout},
+		jobs:   make(chan *job, config.QueueSize),
+		stopCh: make(chan struct{}),
+	}
+}
+
+// Start launches the delivery workers.
+func (n *Notifier) Start() {
+	for i := 0; i < n.config.Workers; i++ {
+		n.wg.Add(1)
+		go n.worker()
+	}
+}
+
+// Stop stops accepting events and waits for in-flight deliveries to finish.
+// Pending retries are abandoned.
+func (n *Notifier) Stop() {
+	n.stopOnce.Do(func() {
+		close(n.stopCh)
+	})
+	n.wg.Wait()
+}
+
+// OnEvent queues the event for every webhook subscribed to its type.
+func (n *Notifier) OnEvent(event *scheduler.TaskEvent) {
+	select {
+	case <-n.stopCh:
+		return
+	default:
+	}
+
+	webhooks, err := n.store.List(context.Background())
+	if err != nil {
+		logger.ErrorX(logModule, "list webhooks failed: %s", err.Error())
+		return
+	}
+
+	var payload *EventPayload
+	var body []byte
+	for _, wh := range webhooks {
+		if !wh.Accepts(event.Type) {
+			continue
+		}
+		if payload == nil {
+			payload = NewEventPayload(event)
+			if body, err = json.Marshal(payload); err != nil {
+				logger.ErrorX(logModule, "marshal event %s failed: %s", event.Type, err.Error())
+				return
+			}
+		}
+
+		j := &job{webhook: wh, eventID: payload.ID, event: event.Type, body: body}
+		select {
+		case n.jobs <- j:
+		default:
+			logger.WarnX(logModule, "delivery queue is full, dropping event %s for webhook %s", event.Type, wh.ID)
+			n.record(j, 0, 0, 0, fmt.Errorf("delivery queue is full"))
+		}
+	}
+}
+
+// NewEventPayload converts a scheduler event into its JSON representation.
+func NewEventPayload(event *scheduler.TaskEvent) *EventPayload {
+	p := &EventPayload{
+		ID:        idutil.NewID("evt"),
+		Type:      event.Type,
+		Task:      event.Task,
+		NodeID:    event.NodeID,
+		Progress:  event.Progress,
+		Result:    event.Result,
+		Timestamp: event.Timestamp,
+	}
+	if event.Decision != nil {
+		p.Reason = event.Decision.Reason
+	}
+	if event.Error != nil {
+		p.Error = event.Error.Error()
+	}
+	if p.Timestamp.IsZero() {
+		p.Timestamp = time.Now()
+	}
+
+	return p
+}
+
+func (n *Notifier) worker() {
+	defer n.wg.Done()
+
+	for {
+		select {
+		case <-n.stopCh:
+			return
+		case j := <-n.jobs:
+			n.deliver(j)
+		}
+	}
+}
+
+// deliver posts the job, retrying with exponential backoff until it succeeds,
+// attempts are exhausted or the notifier stops.
+func (n *Notifier) deliver(j *job) {
+	backoff := n.config.InitialBackoff
+	for attempt := 1; attempt <= n.config.MaxAttempts; attempt++ {
+		start := time.Now()
+		status, err := n.post(j)
+		n.record(j, attempt, status, time.Since(start), err)
+		if err == nil {
+			return
+		}
+
+		logger.WarnX(logModule, "deliver event %s to webhook %s failed (attempt %d/%d): %s",
+			j.eventID, j.webhook.ID, attempt, n.config.MaxAttempts, err.Error())
+		if attempt == n.config.MaxAttempts {
+			return
+		}
+
+		select {
+		case <-n.stopCh:
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > n.config.MaxBackoff {
+			backoff = n.config.MaxBackoff
+		}
+	}
+}
+
+func (n *Notifier) post(j *job) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, j.webhook.URL, bytes.NewReader(j.body))
+	if err != nil {
+		return 0, err
+	}
+
+	for k, v := range j.webhook.Headers {
+		req.Header.Set(k, v)
+	}
+	ts := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "eidolon-hivemind-webhook")
+	req.Header.Set(HeaderEvent, string(j.event))
+	req.Header.Set(HeaderDelivery, j.eventID)
+	req.Header.Set(HeaderTimestamp, fmt.Sprintf("%d", ts))
+	if j.webhook.Secret != "" {
+		req.Header.Set(HeaderSignature, Sign(j.webhook.Secret, ts, j.body))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (n *Notifier) record(j *job, attempt, status int, duration time.Duration, err error) {
+	d := &Delivery{
+		ID:         idutil.NewID("dlv"),
+		WebhookID:  j.webhook.ID,
+		EventID:    j.eventID,
+		EventType:  j.event,
+		Attempt:    attempt,
+		StatusCode: status,
+		Success:    err == nil,
+		Duration:   duration,
+		CreatedAt:  time.Now(),
+	}
+	if err != nil {
+		d.Error = err.Error()
+	}
+
+	// The webhook may have been deleted while the delivery was in flight.
+	_ = n.store.AddDelivery(context.Background(), d)
+}
@@ -0,0 +1,300 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kiosk404/eidolon/internal/hivemind/service/scheduler"
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+)
+
+// received is a request received by a receiver.
+type received struct {
+	header http.Header
+	body   []byte
+	at     time.Time
+}
+
+// receiver is a webhook endpoint answering with the scripted status codes,
+// then with 200.
+type receiver struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	statuses []int
+	requests []received
+}
+
+func newReceiver(t *testing.T, statuses ...int) *receiver {
+	t.Helper()
+
+	r := &receiver{statuses: statuses}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+
+		r.mu.Lock()
+		r.requests = append(r.requests, received{header: req.Header.Clone(), body: body, at: time.Now()})
+		status := http.StatusOK
+		if len(r.statuses) > 0 {
+			status, r.statuses = r.statuses[0], r.statuses[1:]
+		}
+		r.mu.Unlock()
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(r.Close)
+
+	return r
+}
+
+func (r *receiver) received() []received {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]received(nil), r.requests...)
+}
+
+// newTestNotifier starts a Notifier with fast retries.
+func newTestNotifier(t *testing.T, store Store, maxAttempts int) *Notifier {
+	t.Helper()
+
+	n := NewNotifier(NotifierConfig{
+		Workers:        1,
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: 20 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		RequestTimeout: time.Second,
+	}, store)
+	n.Start()
+	t.Cleanup(n.Stop)
+
+	return n
+}
+
+func addWebhook(t *testing.T, store Store, wh *Webhook) {
+	t.Helper()
+
+	if err := store.Create(context.Background(), wh); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// waitDeliveries waits until webhookID has n recorded deliveries and returns
+// them, newest first.
+func waitDeliveries(t *testing.T, store Store, webhookID string, n int) []*Delivery {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		list, err := store.ListDeliveries(context.Background(), webhookID, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(list) >= n {
+			return list
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("webhook %s has %d deliveries, want %d", webhookID, len(list), n)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func testEvent(t scheduler.TaskEventType) *scheduler.TaskEvent {
+	return &scheduler.TaskEvent{
+		Type:      t,
+		Task:      &protocol.Task{ID: "task-1", Name: "build"},
+		NodeID:    "node-1",
+		Timestamp: time.Unix(1700000000, 0),
+	}
+}
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"id":"evt-1"}`)
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write([]byte("1700000000." + string(body)))
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	if got := Sign("s3cret", 1700000000, body); got != want {
+		t.Fatalf("Sign() = %s, want %s", got, want)
+	}
+	if !Verify("s3cret", 1700000000, body, want) {
+		t.Fatal("Verify() rejected a valid signature")
+	}
+	if Verify("s3cret", 1700000001, body, want) {
+		t.Fatal("Verify() accepted a signature of another timestamp")
+	}
+	if Verify("other", 1700000000, body, want) {
+		t.Fatal("Verify() accepted a signature of another secret")
+	}
+	if Verify("s3cret", 1700000000, body, want[len("sha256="):]) {
+		t.Fatal("Verify() accepted a signature without its prefix")
+	}
+}
+
+func TestNotifierSignsRequests(t *testing.T) {
+	recv := newReceiver(t)
+	store := NewMemoryStore()
+	addWebhook(t, store, &Webhook{
+		ID:      "wh-1",
+		URL:     recv.URL,
+		Secret:  "s3cret",
+		Headers: map[string]string{"X-Team": "infra"},
+		Enabled: true,
+	})
+	n := newTestNotifier(t, store, 1)
+
+	n.OnEvent(testEvent(scheduler.EventTypeCompleted))
+	deliveries := waitDeliveries(t, store, "wh-1", 1)
+
+	reqs := recv.received()
+	if len(reqs) != 1 {
+		t.Fatalf("received %d requests, want 1", len(reqs))
+	}
+	req := reqs[0]
+	ts, err := strconv.ParseInt(req.header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		t.Fatalf("bad %s header: %v", HeaderTimestamp, err)
+	}
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write([]byte(strconv.FormatInt(ts, 10) + "."))
+	mac.Write(req.body)
+	if got, want := req.header.Get(HeaderSignature), "sha256="+hex.EncodeToString(mac.Sum(nil)); got != want {
+		t.Fatalf("%s = %s, want %s", HeaderSignature, got, want)
+	}
+	if got := req.header.Get(HeaderEvent); got != string(scheduler.EventTypeCompleted) {
+		t.Fatalf("%s = %s", HeaderEvent, got)
+	}
+	if got := req.header.Get("X-Team"); got != "infra" {
+		t.Fatalf("X-Team = %q, want infra", got)
+	}
+
+	var payload EventPayload
+	if err := json.Unmarshal(req.body, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload.Type != scheduler.EventTypeCompleted || payload.Task.ID != "task-1" || payload.NodeID != "node-1" {
+		t.Fatalf("unexpected payload %+v", payload)
+	}
+	if got := req.header.Get(HeaderDelivery); got != payload.ID || deliveries[0].EventID != payload.ID {
+		t.Fatalf("delivery header %s and record %s, want event %s", got, deliveries[0].EventID, payload.ID)
+	}
+	if d := deliveries[0]; !d.Success || d.Attempt != 1 || d.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected delivery %+v", d)
+	}
+}
+
+func TestNotifierFiltersEvents(t *testing.T) {
+	recv := newReceiver(t)
+	disabled := newReceiver(t)
+	store := NewMemoryStore()
+	addWebhook(t, store, &Webhook{
+		ID:      "completed",
+		URL:     recv.URL,
+		Enabled: true,
+		Events:  []scheduler.TaskEventType{scheduler.EventTypeCompleted},
+	})
+	addWebhook(t, store, &Webhook{ID: "disabled", URL: disabled.URL})
+	n := newTestNotifier(t, store, 1)
+
+	n.OnEvent(testEvent(scheduler.EventTypeSubmitted))
+	n.OnEvent(testEvent(scheduler.EventTypeAssigned))
+	n.OnEvent(testEvent(scheduler.EventTypeCompleted))
+	waitDeliveries(t, store, "completed", 1)
+	time.Sleep(50 * time.Millisecond)
+
+	reqs := recv.received()
+	if len(reqs) != 1 || reqs[0].header.Get(HeaderEvent) != string(scheduler.EventTypeCompleted) {
+		t.Fatalf("received %d requests, want the completed event only", len(reqs))
+	}
+	if got := len(disabled.received()); got != 0 {
+		t.Fatalf("disabled webhook received %d requests", got)
+	}
+	if list, _ := store.ListDeliveries(context.Background(), "disabled", 0); len(list) != 0 {
+		t.Fatalf("disabled webhook has %d deliveries", len(list))
+	}
+}
+
+func TestNotifierRetriesWithBackoff(t *testing.T) {
+	recv := newReceiver(t, http.StatusServiceUnavailable, http.StatusInternalServerError)
+	store := NewMemoryStore()
+	addWebhook(t, store, &Webhook{ID: "wh-1", URL: recv.URL, Enabled: true})
+	n := newTestNotifier(t, store, 5)
+
+	n.OnEvent(testEvent(scheduler.EventTypeFailed))
+	deliveries := waitDeliveries(t, store, "wh-1", 3)
+
+	reqs := recv.received()
+	if len(reqs) != 3 {
+		t.Fatalf("received %d requests, want 3", len(reqs))
+	}
+	// The backoff starts at 20ms and doubles.
+	if gap := reqs[1].at.Sub(reqs[0].at); gap < 20*time.Millisecond {
+		t.Fatalf("first retry after %s, want at least 20ms", gap)
+	}
+	if gap := reqs[2].at.Sub(reqs[1].at); gap < 40*time.Millisecond {
+		t.Fatalf("second retry after %s, want at least 40ms", gap)
+	}
+	if reqs[0].header.Get(HeaderDelivery) != reqs[2].header.Get(HeaderDelivery) {
+		t.Fatal("retries do not carry the same delivery ID")
+	}
+
+	want := []struct {
+		attempt int
+		status  int
+		success bool
+	}{
+		{3, http.StatusOK, true},
+		{2, http.StatusInternalServerError, false},
+		{1, http.StatusServiceUnavailable, false},
+	}
+	for i, w := range want {
+		d := deliveries[i]
+		if d.Attempt != w.attempt || d.StatusCode != w.status || d.Success != w.success {
+			t.Fatalf("delivery %d = %+v, want attempt %d status %d success %t", i, d, w.attempt, w.status, w.success)
+		}
+		if !w.success && d.Error == "" {
+			t.Fatalf("failed delivery %d has no error", i)
+		}
+	}
+}
+
+func TestNotifierGivesUp(t *testing.T) {
+	recv := newReceiver(t, 500, 500, 500, 500, 500, 500)
+	store := NewMemoryStore()
+	addWebhook(t, store, &Webhook{ID: "wh-1", URL: recv.URL, Enabled: true})
+	n := newTestNotifier(t, store, 3)
+
+	n.OnEvent(testEvent(scheduler.EventTypeTimedOut))
+	deliveries := waitDeliveries(t, store, "wh-1", 3)
+	// Leave time for a fourth attempt that must not happen.
+	time.Sleep(200 * time.Millisecond)
+
+	if got := len(recv.received()); got != 3 {
+		t.Fatalf("received %d requests, want 3", got)
+	}
+	deliveries, _ = store.ListDeliveries(context.Background(), "wh-1", 0)
+	if len(deliveries) != 3 {
+		t.Fatalf("recorded %d deliveries, want 3", len(deliveries))
+	}
+	for _, d := range deliveries {
+		if d.Success || d.StatusCode != http.StatusInternalServerError {
+			t.Fatalf("unexpected delivery %+v", d)
+		}
+	}
+
+	// The history can be limited to the latest attempts.
+	if list, _ := store.ListDeliveries(context.Background(), "wh-1", 1); len(list) != 1 || list[0].Attempt != 3 {
+		t.Fatalf("ListDeliveries(limit 1) = %+v, want the last attempt", list)
+	}
+}
@@ -0,0 +1,45 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+const (
+	// HeaderEvent carries the task event type.
+	HeaderEvent = "X-Eidolon-Event"
+
+	// HeaderDelivery carries the unique delivery ID.
+	HeaderDelivery = "X-Eidolon-Delivery"
+
+	// HeaderTimestamp carries the unix timestamp the signature was computed at.
+	HeaderTimestamp = "X-Eidolon-Timestamp"
+
+	// HeaderSignature carries the HMAC-SHA256 signature, like: sha256=<hex>.
+	HeaderSignature = "X-Eidolon-Signature"
+
+	signaturePrefix = "sha256="
+)
+
+// Sign computes the signature of body sent at timestamp. The signed content is
+// "<timestamp>.<body>" so receivers can reject replayed requests.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature matches body sent at timestamp.
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
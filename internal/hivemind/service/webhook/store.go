@@ -0,0 +1,161 @@
+package webhook
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/kiosk404/eidolon/internal/pkg/code"
+	"github.com/kiosk404/eidolon/pkg/errorx"
+)
+
+// defaultMaxDeliveries is the number of delivery records kept per webhook.
+const defaultMaxDeliveries = 100
+
+// Store persists webhooks and their delivery history.
+type Store interface {
+	Create(ctx context.Context, wh *Webhook) error
+	Get(ctx context.Context, id string) (*Webhook, error)
+	List(ctx context.Context) ([]*Webhook, error)
+	Update(ctx context.Context, wh *Webhook) error
+	Delete(ctx context.Context, id string) error
+
+	// AddDelivery appends a delivery attempt to the webhook's history.
+	AddDelivery(ctx context.Context, d *Delivery) error
+
+	// ListDeliveries returns the most recent delivery attempts, newest first.
+	ListDeliveries(ctx context.Context, webhookID string, limit int) ([]*Delivery, error)
+}
+
+type memoryStore struct {
+	mu            sync.RWMutex
+	webhooks      map[string]*Webhook
+	deliveries    map[string][]*Delivery
+	maxDeliveries int
+}
+
+var _ Store = &memoryStore{}
+
+// NewMemoryStore creates an in-memory Store.
+func NewMemoryStore() Store {
+	return &memoryStore{
+		webhooks:      make(map[string]*Webhook),
+		deliveries:    make(map[string][]*Delivery),
+		maxDeliveries: defaultMaxDeliveries,
+	}
+}
+
+func (s *memoryStore) Create(_ context.Context, wh *Webhook) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.webhooks[wh.ID]; ok {
+		return errorx.WithCode(code.ErrWebhookAlreadyExist, "webhook %q already exist", wh.ID)
+	}
+	s.webhooks[wh.ID] = cloneWebhook(wh)
+
+	return nil
+}
+
+func (s *memoryStore) Get(_ context.Context, id string) (*Webhook, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	wh, ok := s.webhooks[id]
+	if !ok {
+		return nil, errorx.WithCode(code.ErrWebhookNotFound, "webhook %q not found", id)
+	}
+
+	return cloneWebhook(wh), nil
+}
+
+func (s *memoryStore) List(_ context.Context) ([]*Webhook, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]*Webhook, 0, len(s.webhooks))
+	for _, wh := range s.webhooks {
+		list = append(list, cloneWebhook(wh))
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CreatedAt.Before(list[j].CreatedAt)
+	})
+
+	return list, nil
+}
+
+func (s *memoryStore) Update(_ context.Context, wh *Webhook) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.webhooks[wh.ID]; !ok {
+		return errorx.WithCode(code.ErrWebhookNotFound, "webhook %q not found", wh.ID)
+	}
+	s.webhooks[wh.ID] = cloneWebhook(wh)
+
+	return nil
+}
+
+func (s *memoryStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.webhooks[id]; !ok {
+		return errorx.WithCode(code.ErrWebhookNotFound, "webhook %q not found", id)
+	}
+	delete(s.webhooks, id)
+	delete(s.deliveries, id)
+
+	return nil
+}
+
+func (s *memoryStore) AddDelivery(_ context.Context, d *Delivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.webhooks[d.WebhookID]; !ok {
+		return errorx.WithCode(code.ErrWebhookNotFound, "webhook %q not found", d.WebhookID)
+	}
+
+	list := append(s.deliveries[d.WebhookID], d)
+	if len(list) > s.maxDeliveries {
+		list = list[len(list)-s.maxDeliveries:]
+	}
+	s.deliveries[d.WebhookID] = list
+
+	return nil
+}
+
+func (s *memoryStore) ListDeliveries(_ context.Context, webhookID string, limit int) ([]*Delivery, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.webhooks[webhookID]; !ok {
+		return nil, errorx.WithCode(code.ErrWebhookNotFound, "webhook %q not found", webhookID)
+	}
+
+	list := s.deliveries[webhookID]
+	if limit <= 0 || limit > len(list) {
+		limit = len(list)
+	}
+	result := make([]*Delivery, 0, limit)
+	for i := len(list) - 1; i >= 0 && len(result) < limit; i-- {
+		d := *list[i]
+		result = append(result, &d)
+	}
+
+	return result, nil
+}
+
+func cloneWebhook(wh *Webhook) *Webhook {
+	c := *wh
+	c.Events = append(c.Events[:0:0], wh.Events...)
+	if wh.Headers != nil {
+		c.Headers = make(map[string]string, len(wh.Headers))
+		for k, v := range wh.Headers {
+			c.Headers[k] = v
+		}
+	}
+
+	return &c
+}
@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/kiosk404/eidolon/internal/hivemind/service/scheduler"
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+)
+
+// Webhook is an HTTP endpoint that receives task lifecycle notifications.
+type Webhook struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	URL         string            `json:"url"`
+	Secret      string            `json:"secret,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	Enabled     bool              `json:"enabled"`
+
+	// Events filters which lifecycle events are delivered. Empty means all events.
+	Events []scheduler.TaskEventType `json:"events,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Accepts reports whether the webhook is subscribed to the given event type.
+func (w *Webhook) Accepts(t scheduler.TaskEventType) bool {
+	if !w.Enabled {
+		return false
+	}
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Delivery records a single attempt to deliver an event to a webhook.
+type Delivery struct {
+	ID         string                  `json:"id"`
+	WebhookID  string                  `json:"webhook_id"`
+	EventID    string                  `json:"event_id"`
+	EventType  scheduler.TaskEventType `json:"event_type"`
+	Attempt    int                     `json:"attempt"`
+	StatusCode int                     `json:"status_code,omitempty"`
+	Success    bool                    `json:"success"`
+	Error      string                  `json:"error,omitempty"`
+	Duration   time.Duration           `json:"duration"`
+	CreatedAt  time.Time               `json:"created_at"`
+}
+
+// EventPayload is the JSON body posted to webhook endpoints.
+type EventPayload struct {
+	ID        string                  `json:"id"`
+	Type      scheduler.TaskEventType `json:"type"`
+	Task      *protocol.Task          `json:"task,omitempty"`
+	NodeID    string                  `json:"node_id,omitempty"`
+	Reason    string                  `json:"reason,omitempty"`
+	Progress  *protocol.TaskProgress  `json:"progress,omitempty"`
+	Result    *protocol.TaskResult    `json:"result,omitempty"`
+	Error     string                  `json:"error,omitempty"`
+	Timestamp time.Time               `json:"timestamp"`
+}
+
+// KnownEventTypes lists every event type a webhook may subscribe to.
+var KnownEventTypes = []scheduler.TaskEventType{
+	scheduler.EventTypeSubmitted,
+	scheduler.EventTypeAssigned,
+	scheduler.EventTypeProgress,
+	scheduler.EventTypeCompleted,
+	scheduler.EventTypeFailed,
+	scheduler.EventTypeCancelled,
+	scheduler.EventTypeTimedOut,
+	scheduler.EventTypeRescheduled,
+	scheduler.EventTypeSkillInstalling,
+}
+
+// IsKnownEventType reports whether t is a valid task event type.
+func IsKnownEventType(t scheduler.TaskEventType) bool {
+	for _, k := range KnownEventTypes {
+		if k == t {
+			return true
+		}
+	}
+
+	return false
+}
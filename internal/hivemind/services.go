@@ -0,0 +1,146 @@
+package hivemind
+
+import (
+	"context"
+
+	"github.com/kiosk404/eidolon/internal/hivemind/config"
+	"github.com/kiosk404/eidolon/internal/hivemind/metrics"
+	"github.com/kiosk404/eidolon/internal/hivemind/service/bootstrap"
+	llmrepository "github.com/kiosk404/eidolon/internal/hivemind/service/llm/domain/repository"
+	llmservice "github.com/kiosk404/eidolon/internal/hivemind/service/llm/domain/service"
+	"github.com/kiosk404/eidolon/internal/hivemind/service/registry"
+	"github.com/kiosk404/eidolon/internal/hivemind/service/scheduler"
+	"github.com/kiosk404/eidolon/internal/hivemind/service/skillregistry"
+	"github.com/kiosk404/eidolon/internal/hivemind/service/tasklog"
+	"github.com/kiosk404/eidolon/internal/hivemind/service/webhook"
+	pkgmetrics "github.com/kiosk404/eidolon/internal/pkg/metrics"
+	"github.com/kiosk404/eidolon/pkg/logger"
+	"github.com/kiosk404/eidolon/pkg/utils/certutil"
+)
+
+// services bundles the domain services shared by the HTTP and gRPC servers.
+type services struct {
+	registry  registry.Registry
+	outbox    *registry.Outbox
+	scheduler scheduler.Scheduler
+	drainer   *registry.Drainer
+	tasklog   *tasklog.Recorder
+	webhooks  webhook.Store
+	notifier  *webhook.Notifier
+	bootstrap *bootstrap.Service
+
+	// skills is nil when the skill registry is not enabled.
+	skills *skillregistry.Registry
+
+	// models manages the models, with the defaults of modelMeta.
+	models llmservice.ModelManager
+
+	// modelMeta is nil when no model meta catalog is configured.
+	modelMeta     *llmservice.ModelMetaLoader
+	stopModelMeta context.CancelFunc
+}
+
+func newServices(cfg *config.Config) (*services, error) {
+	reg := registry.NewMemoryRegistry()
+	outbox := registry.NewOutbox(reg)
+
+	schedulerConfig := scheduler.DefaultSchedulerConfig()
+	var skills *skillregistry.Registry
+	if opts := cfg.SkillRegistry; opts.Enabled() {
+		var err error
+		if skills, err = skillregistry.NewRegistry(opts.Dir, opts.MaxPackageSize); err != nil {
+			return nil, err
+		}
+		schedulerConfig.SkillResolver = skills
+		schedulerConfig.SkillInstallTimeout = opts.InstallTimeout
+	}
+
+	modelMeta, err := llmservice.NewModelMetaLoader(cfg.Model)
+	if err != nil {
+		return nil, err
+	}
+	modelRepo, err := llmrepository.NewFileRepository(cfg.Model.ModelsFile)
+	if err != nil {
+		return nil, err
+	}
+	var metaSource llmservice.ModelMetaSource
+	if modelMeta != nil {
+		metaSource = modelMeta
+	}
+	models := llmservice.NewModelManager(modelRepo, metaSource)
+
+	completed, err := schedulerConfig.Complete(reg, outbox)
+	if err != nil {
+		return nil, err
+	}
+	sched := completed.New()
+	drainer := registry.NewDrainer(reg, sched)
+
+	webhooks := webhook.NewMemoryStore()
+	notifier := webhook.NewNotifier(webhook.DefaultNotifierConfig(), webhooks)
+	sched.Subscribe(notifier)
+
+	recorder := tasklog.NewRecorder(tasklog.DefaultRecorderConfig())
+	sched.Subscribe(recorder)
+
+	var ca *certutil.CA
+	if tlsOpts := cfg.SecureServing.ServerCert; tlsOpts.ClientCAKeyFile != "" {
+		if ca, err = certutil.LoadCA(tlsOpts.ClientCAFile, tlsOpts.ClientCAKeyFile); err != nil {
+			return nil, err
+		}
+	} else if cfg.SecureServing.Enabled() {
+		logger.Warn("No client CA key configured, golems cannot join")
+	}
+	bootstrapSvc := bootstrap.NewService(bootstrap.NewMemoryStore(), reg, ca)
+
+	if cfg.FeatureOptions.EnableMetrics {
+		collector := metrics.NewCollector(sched, reg)
+		if err := pkgmetrics.Registry.Register(collector); err != nil {
+			return nil, err
+		}
+		sched.Subscribe(collector)
+	}
+
+	return &services{
+		registry:  reg,
+		outbox:    outbox,
+		scheduler: sched,
+		drainer:   drainer,
+		tasklog:   recorder,
+		webhooks:  webhooks,
+		notifier:  notifier,
+		bootstrap: bootstrapSvc,
+		skills:    skills,
+		models:    models,
+		modelMeta: modelMeta,
+	}, nil
+}
+
+// start launches the background loops of every service.
+func (s *services) start(ctx context.Context) error {
+	s.notifier.Start()
+
+	if s.modelMeta != nil {
+		var watchCtx context.Context
+		watchCtx, s.stopModelMeta = context.WithCancel(context.Background())
+		go func() {
+			if err := s.modelMeta.Run(watchCtx); err != nil {
+				logger.Error("Watch model meta failed, it will not be reloaded: %s", err.Error())
+			}
+		}()
+	}
+
+	return s.scheduler.Start(ctx)
+}
+
+// stop shuts the services down in reverse start order.
+func (s *services) stop(ctx context.Context) error {
+	s.drainer.Stop()
+	err := s.scheduler.Stop(ctx)
+	s.notifier.Stop()
+	if s.stopModelMeta != nil {
+		s.stopModelMeta()
+	}
+
+	return err
+}
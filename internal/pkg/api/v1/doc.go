@@ -0,0 +1,3 @@
+// Package v1 defines the request and response bodies of the hivemind v1 REST API.
+// It is shared by the hivemind controllers and the eidoctl client.
+package v1
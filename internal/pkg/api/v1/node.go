@@ -0,0 +1,52 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+)
+
+// Node is a Golem node as returned by the API.
+type Node struct {
+	protocol.NodeInfo `json:",inline" yaml:",inline"`
+
+	Load              protocol.NodeLoadInfo `json:"load"                         yaml:"load"`
+	InstalledSkills   []Skill               `json:"installed_skills,omitempty"   yaml:"installedSkills,omitempty"`
+	SupportedFeatures []string              `json:"supported_features,omitempty" yaml:"supportedFeatures,omitempty"`
+	Tags              map[string]string     `json:"tags,omitempty"               yaml:"tags,omitempty"`
+	HealthScore       float64               `json:"health_score"                 yaml:"healthScore"`
+	Cordoned          bool                  `json:"cordoned"                     yaml:"cordoned"`
+	Draining          bool                  `json:"draining"                     yaml:"draining"`
+	LastHeartbeat     time.Time             `json:"last_heartbeat"               yaml:"lastHeartbeat"`
+	LastUpdated       time.Time             `json:"last_updated"                 yaml:"lastUpdated"`
+}
+
+// Skill is a skill installed on a Golem node.
+type Skill struct {
+	ID           string   `json:"id"                     yaml:"id"`
+	Name         string   `json:"name"                   yaml:"name"`
+	Version      string   `json:"version"                yaml:"version"`
+	Capabilities []string `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
+}
+
+// NodeList is the response of GET /api/v1/nodes.
+type NodeList struct {
+	ListMeta `json:",inline" yaml:",inline"`
+
+	Items []*Node `json:"items" yaml:"items"`
+}
+
+// UpdateNodeTagsRequest is the body of PUT /api/v1/nodes/:id/tags. The tags
+// replace the existing ones; an empty map removes them all.
+type UpdateNodeTagsRequest struct {
+	Tags map[string]string `json:"tags" yaml:"tags"`
+}
+
+// DrainNodeRequest is the body of POST /api/v1/nodes/:id/drain.
+type DrainNodeRequest struct {
+	// Timeout is a Go duration string bounding the wait for running tasks; empty means no limit.
+	Timeout string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+
+	// Force cancels the tasks still running when the timeout expires.
+	Force bool `json:"force,omitempty" yaml:"force,omitempty"`
+}
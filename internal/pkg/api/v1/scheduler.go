@@ -0,0 +1,51 @@
+package v1
+
+import (
+	"time"
+)
+
+// SchedulerStats is the response of GET /api/v1/scheduler/stats.
+type SchedulerStats struct {
+	TotalSubmitted       int64                `json:"total_submitted"        yaml:"totalSubmitted"`
+	TotalCompleted       int64                `json:"total_completed"        yaml:"totalCompleted"`
+	TotalFailed          int64                `json:"total_failed"           yaml:"totalFailed"`
+	TotalCancelled       int64                `json:"total_cancelled"        yaml:"totalCancelled"`
+	TotalTimedOut        int64                `json:"total_timed_out"        yaml:"totalTimedOut"`
+	CurrentQueued        int                  `json:"current_queued"         yaml:"currentQueued"`
+	CurrentRunning       int                  `json:"current_running"        yaml:"currentRunning"`
+	AverageLatency       time.Duration        `json:"average_latency"        yaml:"averageLatency"`
+	AverageExecutionTime time.Duration        `json:"average_execution_time" yaml:"averageExecutionTime"`
+	Nodes                []NodeSchedulerStats `json:"nodes"                  yaml:"nodes"`
+	CollectedAt          time.Time            `json:"collected_at"           yaml:"collectedAt"`
+
+	// SkillInstalls counts the nodes that installed the missing skills of a
+	// task, AverageSkillInstallTime is the time they took on average.
+	SkillInstalls           int64         `json:"skill_installs"             yaml:"skillInstalls"`
+	SkillInstallFailures    int64         `json:"skill_install_failures"     yaml:"skillInstallFailures"`
+	AverageSkillInstallTime time.Duration `json:"average_skill_install_time" yaml:"averageSkillInstallTime"`
+}
+
+// NodeSchedulerStats holds the scheduling statistics of a single node.
+type NodeSchedulerStats struct {
+	NodeID               string        `json:"node_id"                yaml:"nodeID"`
+	TasksAssigned        int64         `json:"tasks_assigned"         yaml:"tasksAssigned"`
+	TasksCompleted       int64         `json:"tasks_completed"        yaml:"tasksCompleted"`
+	TasksFailed          int64         `json:"tasks_failed"           yaml:"tasksFailed"`
+	AverageExecutionTime time.Duration `json:"average_execution_time" yaml:"averageExecutionTime"`
+	LastAssignedAt       time.Time     `json:"last_assigned_at"       yaml:"lastAssignedAt"`
+	SkillInstalls        int64         `json:"skill_installs"         yaml:"skillInstalls"`
+	SkillInstallFailures int64         `json:"skill_install_failures" yaml:"skillInstallFailures"`
+}
+
+// SimulationResult is the response of POST /api/v1/scheduler/simulate.
+type SimulationResult struct {
+	// Decision is the node the request would be placed on; omitted when none qualifies.
+	Decision *ScheduleDecision `json:"decision,omitempty" yaml:"decision,omitempty"`
+
+	// Scores holds the evaluation of every node, including rejected and offline
+	// ones with the reason they were not selected.
+	Scores []NodeScore `json:"scores" yaml:"scores"`
+
+	// Error explains why no node was selected.
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
@@ -0,0 +1,25 @@
+package v1
+
+import (
+	"time"
+)
+
+// SkillPackage is a version of a skill in the skill registry of the hivemind.
+type SkillPackage struct {
+	ID               string    `json:"id"                          yaml:"id"`
+	Name             string    `json:"name"                        yaml:"name"`
+	Version          string    `json:"version"                     yaml:"version"`
+	Description      string    `json:"description,omitempty"       yaml:"description,omitempty"`
+	Capabilities     []string  `json:"capabilities,omitempty"      yaml:"capabilities,omitempty"`
+	RequiredFeatures []string  `json:"required_features,omitempty" yaml:"requiredFeatures,omitempty"`
+	Digest           string    `json:"digest"                      yaml:"digest"`
+	Size             int64     `json:"size"                        yaml:"size"`
+	UploadedAt       time.Time `json:"uploaded_at"                 yaml:"uploadedAt"`
+}
+
+// SkillPackageList is the response of GET /api/v1/skills and GET /api/v1/skills/:id.
+type SkillPackageList struct {
+	ListMeta `json:",inline" yaml:",inline"`
+
+	Items []*SkillPackage `json:"items" yaml:"items"`
+}
@@ -0,0 +1,141 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+)
+
+// Schedule modes accepted by SubmitTaskRequest.Mode.
+const (
+	ScheduleModeAI     = "ai"
+	ScheduleModeDirect = "direct"
+)
+
+// SubmitTaskRequest is the body of POST /api/v1/tasks.
+type SubmitTaskRequest struct {
+	Name     string                 `json:"name"               yaml:"name"`
+	Type     string                 `json:"type"               yaml:"type"`
+	Payload  map[string]interface{} `json:"payload,omitempty"  yaml:"payload,omitempty"`
+	Priority protocol.TaskPriority  `json:"priority,omitempty" yaml:"priority,omitempty"`
+	// Timeout is a Go duration string, like: 90s, 5m.
+	Timeout  string            `json:"timeout,omitempty"  yaml:"timeout,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+
+	// Mode is either "ai" (default) or "direct".
+	Mode string `json:"mode,omitempty" yaml:"mode,omitempty"`
+	// TargetNodeID is required when Mode is "direct".
+	TargetNodeID string `json:"target_node_id,omitempty" yaml:"targetNodeID,omitempty"`
+
+	RequiredCapabilities []string              `json:"required_capabilities,omitempty" yaml:"requiredCapabilities,omitempty"`
+	RequiredSkills       []string              `json:"required_skills,omitempty"       yaml:"requiredSkills,omitempty"`
+	RequiredFeatures     []string              `json:"required_features,omitempty"     yaml:"requiredFeatures,omitempty"`
+	PreferredTags        map[string]string     `json:"preferred_tags,omitempty"        yaml:"preferredTags,omitempty"`
+	Resources            *ResourceRequirements `json:"resources,omitempty"             yaml:"resources,omitempty"`
+	Hints                *ScheduleHints        `json:"hints,omitempty"                 yaml:"hints,omitempty"`
+
+	// InstallMissingSkills lets the hivemind install the required skills from
+	// its skill registry on a node that meets every other constraint.
+	InstallMissingSkills bool `json:"install_missing_skills,omitempty" yaml:"installMissingSkills,omitempty"`
+}
+
+// ResourceRequirements mirrors scheduler.ResourceRequirements. Zero values mean no constraint.
+type ResourceRequirements struct {
+	MinCPUCores      int     `json:"min_cpu_cores,omitempty"      yaml:"minCPUCores,omitempty"`
+	MinMemoryMB      int64   `json:"min_memory_mb,omitempty"      yaml:"minMemoryMB,omitempty"`
+	MinDiskFreeMB    int64   `json:"min_disk_free_mb,omitempty"   yaml:"minDiskFreeMB,omitempty"`
+	MaxCPUPercent    float64 `json:"max_cpu_percent,omitempty"    yaml:"maxCPUPercent,omitempty"`
+	MaxMemoryPercent float64 `json:"max_memory_percent,omitempty" yaml:"maxMemoryPercent,omitempty"`
+	MaxActiveTasks   int     `json:"max_active_tasks,omitempty"   yaml:"maxActiveTasks,omitempty"`
+}
+
+// ScheduleHints mirrors scheduler.ScheduleHints.
+type ScheduleHints struct {
+	Description         string            `json:"description,omitempty"           yaml:"description,omitempty"`
+	PreferLowLatency    bool              `json:"prefer_low_latency,omitempty"    yaml:"preferLowLatency,omitempty"`
+	PreferHighResources bool              `json:"prefer_high_resources,omitempty" yaml:"preferHighResources,omitempty"`
+	Affinity            string            `json:"affinity,omitempty"              yaml:"affinity,omitempty"`
+	AntiAffinity        []string          `json:"anti_affinity,omitempty"         yaml:"antiAffinity,omitempty"`
+	CustomContext       map[string]string `json:"custom_context,omitempty"        yaml:"customContext,omitempty"`
+}
+
+// Task is a scheduled task as returned by the API.
+type Task struct {
+	protocol.Task `json:",inline" yaml:",inline"`
+
+	// Mode is the scheduling mode the task was submitted with.
+	Mode string `json:"mode" yaml:"mode"`
+
+	// Retries is the number of times the task has been rescheduled.
+	Retries int `json:"retries" yaml:"retries"`
+
+	// Decision is the latest scheduling decision; omitted while the task is queued.
+	Decision *ScheduleDecision `json:"decision,omitempty" yaml:"decision,omitempty"`
+}
+
+// TaskList is the response of GET /api/v1/tasks.
+type TaskList struct {
+	ListMeta `json:",inline" yaml:",inline"`
+
+	Items []*Task `json:"items" yaml:"items"`
+}
+
+// ScheduleDecision describes why a task was placed on a node.
+type ScheduleDecision struct {
+	RequestID      string        `json:"request_id"              yaml:"requestID"`
+	Mode           string        `json:"mode"                    yaml:"mode"`
+	SelectedNodeID string        `json:"selected_node_id"        yaml:"selectedNodeID"`
+	Reason         string        `json:"reason"                  yaml:"reason"`
+	Scores         []NodeScore   `json:"scores,omitempty"        yaml:"scores,omitempty"`
+	CandidateCount int           `json:"candidate_count"         yaml:"candidateCount"`
+	EligibleCount  int           `json:"eligible_count"          yaml:"eligibleCount"`
+	DecidedAt      time.Time     `json:"decided_at"              yaml:"decidedAt"`
+	Latency        time.Duration `json:"latency"                 yaml:"latency"`
+}
+
+// NodeScore is the scoring breakdown of one candidate node.
+type NodeScore struct {
+	NodeID          string  `json:"node_id"                 yaml:"nodeID"`
+	TotalScore      float64 `json:"total_score"             yaml:"totalScore"`
+	CapabilityScore float64 `json:"capability_score"        yaml:"capabilityScore"`
+	SkillScore      float64 `json:"skill_score"             yaml:"skillScore"`
+	ResourceScore   float64 `json:"resource_score"          yaml:"resourceScore"`
+	LoadScore       float64 `json:"load_score"              yaml:"loadScore"`
+	TagScore        float64 `json:"tag_score"               yaml:"tagScore"`
+	AffinityScore   float64 `json:"affinity_score"          yaml:"affinityScore"`
+	Eligible        bool    `json:"eligible"                yaml:"eligible"`
+	RejectReason    string  `json:"reject_reason,omitempty" yaml:"rejectReason,omitempty"`
+}
+
+// Types of TaskEvent.
+const (
+	TaskEventSubmitted       = "submitted"
+	TaskEventAssigned        = "assigned"
+	TaskEventProgress        = "progress"
+	TaskEventCompleted       = "completed"
+	TaskEventFailed          = "failed"
+	TaskEventCancelled       = "cancelled"
+	TaskEventTimedOut        = "timed_out"
+	TaskEventRescheduled     = "rescheduled"
+	TaskEventSkillInstalling = "skill_installing"
+)
+
+// TaskEvent is a lifecycle event of a task, as returned by GET /api/v1/tasks/:id/events.
+type TaskEvent struct {
+	// Seq numbers the events of a task from 1, in the order they happened.
+	Seq       int64                  `json:"seq"                yaml:"seq"`
+	Type      string                 `json:"type"               yaml:"type"`
+	TaskID    string                 `json:"task_id"            yaml:"taskID"`
+	Status    protocol.TaskStatus    `json:"status,omitempty"   yaml:"status,omitempty"`
+	NodeID    string                 `json:"node_id,omitempty"  yaml:"nodeID,omitempty"`
+	Reason    string                 `json:"reason,omitempty"   yaml:"reason,omitempty"`
+	Progress  *protocol.TaskProgress `json:"progress,omitempty" yaml:"progress,omitempty"`
+	Result    *protocol.TaskResult   `json:"result,omitempty"   yaml:"result,omitempty"`
+	Error     string                 `json:"error,omitempty"    yaml:"error,omitempty"`
+	Timestamp time.Time              `json:"timestamp"          yaml:"timestamp"`
+}
+
+// TaskEventList is the response of GET /api/v1/tasks/:id/events.
+type TaskEventList struct {
+	Items []*TaskEvent `json:"items" yaml:"items"`
+}
@@ -0,0 +1,43 @@
+package v1
+
+import (
+	"time"
+)
+
+// CreateTokenRequest is the body of POST /api/v1/tokens.
+type CreateTokenRequest struct {
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+
+	// TTL is a Go duration string; empty means 24h and "0" means the token never expires.
+	TTL string `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+
+	// MaxUses is the number of nodes that may join with the token; empty means 1 and 0 means unlimited.
+	MaxUses *int `json:"max_uses,omitempty" yaml:"maxUses,omitempty"`
+
+	// Tags are attached to every node that joins with the token.
+	Tags map[string]string `json:"tags,omitempty" yaml:"tags,omitempty"`
+}
+
+// Token is a bootstrap token as returned by the API.
+type Token struct {
+	ID string `json:"id" yaml:"id"`
+
+	// Token is the full "<id>.<secret>" token, only returned when it is created.
+	Token string `json:"token,omitempty" yaml:"token,omitempty"`
+
+	Description string            `json:"description,omitempty"  yaml:"description,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"         yaml:"tags,omitempty"`
+	MaxUses     int               `json:"max_uses"               yaml:"maxUses"`
+	Uses        int               `json:"uses"                   yaml:"uses"`
+	Expired     bool              `json:"expired"                yaml:"expired"`
+	ExpiresAt   *time.Time        `json:"expires_at,omitempty"   yaml:"expiresAt,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"             yaml:"createdAt"`
+	LastUsedAt  *time.Time        `json:"last_used_at,omitempty" yaml:"lastUsedAt,omitempty"`
+}
+
+// TokenList is the response of GET /api/v1/tokens.
+type TokenList struct {
+	ListMeta `json:",inline" yaml:",inline"`
+
+	Items []*Token `json:"items" yaml:"items"`
+}
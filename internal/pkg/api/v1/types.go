@@ -0,0 +1,37 @@
+package v1
+
+// ListMeta describes list responses.
+type ListMeta struct {
+	// TotalCount is the number of matching items before pagination.
+	TotalCount int `json:"total_count"`
+}
+
+// ListOptions is the pagination query accepted by list endpoints.
+type ListOptions struct {
+	// Offset is the number of items to skip.
+	Offset int `json:"offset,omitempty" form:"offset"`
+
+	// Limit is the maximum number of items to return.
+	Limit int `json:"limit,omitempty" form:"limit"`
+}
+
+const (
+	// DefaultListLimit is used when a list request does not specify a limit.
+	DefaultListLimit = 20
+
+	// MaxListLimit caps the limit of a list request.
+	MaxListLimit = 500
+)
+
+// Complete fills in defaults and clamps the pagination values.
+func (o *ListOptions) Complete() {
+	if o.Offset < 0 {
+		o.Offset = 0
+	}
+	if o.Limit <= 0 {
+		o.Limit = DefaultListLimit
+	}
+	if o.Limit > MaxListLimit {
+		o.Limit = MaxListLimit
+	}
+}
@@ -0,0 +1,267 @@
+// Package hivemind is a typed client for the hivemind REST API.
+package hivemind
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+)
+
+// DefaultAddress is the hivemind REST endpoint used when none is configured.
+const DefaultAddress = "http://127.0.0.1:11789"
+
+// maxEventSize bounds a single line of a server-sent event stream.
+const maxEventSize = 4 << 20
+
+// Config holds the settings of a Client.
+type Config struct {
+	// Address is the base URL of the hivemind REST API, like: https://hivemind:11790.
+	Address string
+
+	// Timeout bounds every request; 0 means no limit.
+	Timeout time.Duration
+
+	// TLSConfig is used for https addresses.
+	TLSConfig *tls.Config
+}
+
+// Client talks to the hivemind REST API.
+type Client struct {
+	base   *url.URL
+	client *http.Client
+}
+
+// NewForConfig creates a Client.
+func NewForConfig(c *Config) (*Client, error) {
+	addr := c.Address
+	if addr == "" {
+		addr = DefaultAddress
+	}
+	if !strings.Contains(addr, "://") {
+		addr = "http://" + addr
+	}
+	base, err := url.Parse(strings.TrimSuffix(addr, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid hivemind address %q: %w", c.Address, err)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = c.TLSConfig
+
+	return &Client{
+		base:   base,
+		client: &http.Client{Timeout: c.Timeout, Transport: transport},
+	}, nil
+}
+
+// Tokens returns the client of the bootstrap token resource.
+func (c *Client) Tokens() TokenInterface {
+	return &tokens{client: c}
+}
+
+// Nodes returns the client of the node resource.
+func (c *Client) Nodes() NodeInterface {
+	return &nodes{client: c}
+}
+
+// Tasks returns the client of the task resource.
+func (c *Client) Tasks() TaskInterface {
+	return &tasks{client: c}
+}
+
+// Skills returns the client of the skill package resource.
+func (c *Client) Skills() SkillInterface {
+	return &skills{client: c}
+}
+
+// APIError is an error response of the hivemind.
+type APIError struct {
+	StatusCode int
+	core.ErrResponse
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s (code %d, http %d)", e.Message, e.Code, e.StatusCode)
+}
+
+// IsNotFound reports whether err is a 404 response.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// IsConflict reports whether err is a 409 response.
+func IsConflict(err error) bool {
+	var apiErr *APIError
+
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict
+}
+
+// do sends in as JSON body, if not nil, and decodes the response into out, if not nil.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, in, out any) error {
+	req, err := c.newRequest(ctx, method, path, query, in)
+	if err != nil {
+		return err
+	}
+
+	return c.send(req, out)
+}
+
+// send sends req and decodes the response into out, if not nil.
+func (c *Client) send(req *http.Request, out any) error {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newAPIError(resp.StatusCode, data)
+	}
+
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(data, out)
+}
+
+// stream reads the server-sent events of a GET request and calls fn with the
+// type and data of every event, until the server ends the stream, ctx is done
+// or fn returns an error.
+func (c *Client) stream(ctx context.Context, path string, query url.Values, fn func(event string, data []byte) error) error {
+	req, err := c.newRequest(ctx, http.MethodGet, path, query, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	// A stream lasts as long as the resource it follows, the request timeout
+	// of the client would cut it.
+	client := &http.Client{Transport: c.client.Transport}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+
+		return newAPIError(resp.StatusCode, data)
+	}
+
+	if err := readEvents(resp.Body, fn); err != nil && ctx.Err() == nil {
+		return err
+	}
+
+	return ctx.Err()
+}
+
+func (c *Client) newRequest(ctx context.Context, method, path string, query url.Values, in any) (*http.Request, error) {
+	u := *c.base
+	u.Path += path
+	u.RawQuery = query.Encode()
+
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return req, nil
+}
+
+func newAPIError(statusCode int, data []byte) *APIError {
+	apiErr := &APIError{StatusCode: statusCode}
+	if json.Unmarshal(data, &apiErr.ErrResponse) != nil || apiErr.Message == "" {
+		apiErr.Message = strings.TrimSpace(string(data))
+		if apiErr.Message == "" {
+			apiErr.Message = http.StatusText(statusCode)
+		}
+	}
+
+	return apiErr
+}
+
+// readEvents parses a text/event-stream body. Comments, ids and events without
+// data are skipped.
+func readEvents(r io.Reader, fn func(event string, data []byte) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEventSize)
+
+	var event string
+	var data []byte
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if len(data) > 0 {
+				if err := fn(event, data); err != nil {
+					return err
+				}
+			}
+			event, data = "", nil
+
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event = value
+		case "data":
+			if len(data) > 0 {
+				data = append(data, '\n')
+			}
+			data = append(data, value...)
+		}
+	}
+
+	return scanner.Err()
+}
+
+func listQuery(opts v1.ListOptions) url.Values {
+	q := url.Values{}
+	if opts.Offset > 0 {
+		q.Set("offset", fmt.Sprint(opts.Offset))
+	}
+	if opts.Limit > 0 {
+		q.Set("limit", fmt.Sprint(opts.Limit))
+	}
+
+	return q
+}
@@ -0,0 +1,115 @@
+package hivemind
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+)
+
+// NodeListOptions filters a node list.
+type NodeListOptions struct {
+	v1.ListOptions
+
+	Status   string
+	Cordoned *bool
+}
+
+// NodeInterface manages golem nodes.
+type NodeInterface interface {
+	List(ctx context.Context, opts NodeListOptions) (*v1.NodeList, error)
+	Get(ctx context.Context, id string) (*v1.Node, error)
+	UpdateTags(ctx context.Context, id string, tags map[string]string) (*v1.Node, error)
+	Cordon(ctx context.Context, id string) (*v1.Node, error)
+	Uncordon(ctx context.Context, id string) (*v1.Node, error)
+	Drain(ctx context.Context, id string, req *v1.DrainNodeRequest) (*v1.Node, error)
+
+	// Delete deregisters a node; force cancels the tasks still running on it.
+	Delete(ctx context.Context, id string, force bool) error
+}
+
+type nodes struct {
+	client *Client
+}
+
+func (n *nodes) List(ctx context.Context, opts NodeListOptions) (*v1.NodeList, error) {
+	query := listQuery(opts.ListOptions)
+	if opts.Status != "" {
+		query.Set("status", opts.Status)
+	}
+	if opts.Cordoned != nil {
+		query.Set("cordoned", strconv.FormatBool(*opts.Cordoned))
+	}
+
+	out := &v1.NodeList{}
+	if err := n.client.do(ctx, http.MethodGet, "/api/v1/nodes", query, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (n *nodes) Get(ctx context.Context, id string) (*v1.Node, error) {
+	out := &v1.Node{}
+	if err := n.client.do(ctx, http.MethodGet, nodePath(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (n *nodes) UpdateTags(ctx context.Context, id string, tags map[string]string) (*v1.Node, error) {
+	if tags == nil {
+		tags = map[string]string{}
+	}
+
+	out := &v1.Node{}
+	req := &v1.UpdateNodeTagsRequest{Tags: tags}
+	if err := n.client.do(ctx, http.MethodPut, nodePath(id)+"/tags", nil, req, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (n *nodes) Cordon(ctx context.Context, id string) (*v1.Node, error) {
+	out := &v1.Node{}
+	if err := n.client.do(ctx, http.MethodPost, nodePath(id)+"/cordon", nil, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (n *nodes) Uncordon(ctx context.Context, id string) (*v1.Node, error) {
+	out := &v1.Node{}
+	if err := n.client.do(ctx, http.MethodPost, nodePath(id)+"/uncordon", nil, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (n *nodes) Drain(ctx context.Context, id string, req *v1.DrainNodeRequest) (*v1.Node, error) {
+	out := &v1.Node{}
+	if err := n.client.do(ctx, http.MethodPost, nodePath(id)+"/drain", nil, req, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (n *nodes) Delete(ctx context.Context, id string, force bool) error {
+	query := url.Values{}
+	if force {
+		query.Set("force", "true")
+	}
+
+	return n.client.do(ctx, http.MethodDelete, nodePath(id), query, nil, nil)
+}
+
+func nodePath(id string) string {
+	return "/api/v1/nodes/" + url.PathEscape(id)
+}
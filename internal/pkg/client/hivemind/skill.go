@@ -0,0 +1,102 @@
+package hivemind
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+)
+
+// SkillInterface manages the skill packages of the skill registry.
+type SkillInterface interface {
+	// Upload stores a package archive, a tar file optionally compressed with gzip.
+	Upload(ctx context.Context, archive io.Reader) (*v1.SkillPackage, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1.SkillPackageList, error)
+	Versions(ctx context.Context, id string, opts v1.ListOptions) (*v1.SkillPackageList, error)
+	Get(ctx context.Context, id, version string) (*v1.SkillPackage, error)
+
+	// Download returns the archive of a package, which the caller must close.
+	Download(ctx context.Context, id, version string) (io.ReadCloser, error)
+	Delete(ctx context.Context, id, version string) error
+}
+
+type skills struct {
+	client *Client
+}
+
+func (s *skills) Upload(ctx context.Context, archive io.Reader) (*v1.SkillPackage, error) {
+	req, err := s.client.newRequest(ctx, http.MethodPost, "/api/v1/skills", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(archive)
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	out := &v1.SkillPackage{}
+	if err := s.client.send(req, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (s *skills) List(ctx context.Context, opts v1.ListOptions) (*v1.SkillPackageList, error) {
+	out := &v1.SkillPackageList{}
+	if err := s.client.do(ctx, http.MethodGet, "/api/v1/skills", listQuery(opts), nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (s *skills) Versions(ctx context.Context, id string, opts v1.ListOptions) (*v1.SkillPackageList, error) {
+	out := &v1.SkillPackageList{}
+	if err := s.client.do(ctx, http.MethodGet, "/api/v1/skills/"+url.PathEscape(id), listQuery(opts), nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (s *skills) Get(ctx context.Context, id, version string) (*v1.SkillPackage, error) {
+	out := &v1.SkillPackage{}
+	if err := s.client.do(ctx, http.MethodGet, versionPath(id, version), nil, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (s *skills) Download(ctx context.Context, id, version string) (io.ReadCloser, error) {
+	req, err := s.client.newRequest(ctx, http.MethodGet, versionPath(id, version)+"/package", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/octet-stream")
+
+	resp, err := s.client.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, newAPIError(resp.StatusCode, data)
+	}
+
+	return resp.Body, nil
+}
+
+func (s *skills) Delete(ctx context.Context, id, version string) error {
+	return s.client.do(ctx, http.MethodDelete, versionPath(id, version), nil, nil, nil)
+}
+
+func versionPath(id, version string) string {
+	return "/api/v1/skills/" + url.PathEscape(id) + "/versions/" + url.PathEscape(version)
+}
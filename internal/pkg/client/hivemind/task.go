@@ -0,0 +1,129 @@
+package hivemind
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+)
+
+// TaskListOptions filters a task list.
+type TaskListOptions struct {
+	v1.ListOptions
+
+	Status string
+	NodeID string
+	Mode   string
+}
+
+// TaskInterface manages tasks.
+type TaskInterface interface {
+	Create(ctx context.Context, req *v1.SubmitTaskRequest) (*v1.Task, error)
+	Get(ctx context.Context, id string) (*v1.Task, error)
+	List(ctx context.Context, opts TaskListOptions) (*v1.TaskList, error)
+	Cancel(ctx context.Context, id string) (*v1.Task, error)
+	Decision(ctx context.Context, id string) (*v1.ScheduleDecision, error)
+
+	// Events returns the recorded events of a task after the since-th one.
+	Events(ctx context.Context, id string, since int64) (*v1.TaskEventList, error)
+
+	// Watch calls fn with the recorded events of a task after the since-th one
+	// and then with every new event, until the task finishes, ctx is done or
+	// fn returns an error.
+	Watch(ctx context.Context, id string, since int64, fn func(*v1.TaskEvent) error) error
+}
+
+type tasks struct {
+	client *Client
+}
+
+func (t *tasks) Create(ctx context.Context, req *v1.SubmitTaskRequest) (*v1.Task, error) {
+	out := &v1.Task{}
+	if err := t.client.do(ctx, http.MethodPost, "/api/v1/tasks", nil, req, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (t *tasks) Get(ctx context.Context, id string) (*v1.Task, error) {
+	out := &v1.Task{}
+	if err := t.client.do(ctx, http.MethodGet, taskPath(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (t *tasks) List(ctx context.Context, opts TaskListOptions) (*v1.TaskList, error) {
+	query := listQuery(opts.ListOptions)
+	for key, value := range map[string]string{"status": opts.Status, "node": opts.NodeID, "mode": opts.Mode} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+
+	out := &v1.TaskList{}
+	if err := t.client.do(ctx, http.MethodGet, "/api/v1/tasks", query, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (t *tasks) Cancel(ctx context.Context, id string) (*v1.Task, error) {
+	out := &v1.Task{}
+	if err := t.client.do(ctx, http.MethodPost, taskPath(id)+"/cancel", nil, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (t *tasks) Decision(ctx context.Context, id string) (*v1.ScheduleDecision, error) {
+	out := &v1.ScheduleDecision{}
+	if err := t.client.do(ctx, http.MethodGet, taskPath(id)+"/decision", nil, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (t *tasks) Events(ctx context.Context, id string, since int64) (*v1.TaskEventList, error) {
+	out := &v1.TaskEventList{}
+	if err := t.client.do(ctx, http.MethodGet, taskPath(id)+"/events", eventsQuery(since, false), nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (t *tasks) Watch(ctx context.Context, id string, since int64, fn func(*v1.TaskEvent) error) error {
+	return t.client.stream(ctx, taskPath(id)+"/events", eventsQuery(since, true), func(_ string, data []byte) error {
+		event := &v1.TaskEvent{}
+		if err := json.Unmarshal(data, event); err != nil {
+			return fmt.Errorf("decode task event: %w", err)
+		}
+
+		return fn(event)
+	})
+}
+
+func taskPath(id string) string {
+	return "/api/v1/tasks/" + url.PathEscape(id)
+}
+
+func eventsQuery(since int64, watch bool) url.Values {
+	q := url.Values{}
+	if since > 0 {
+		q.Set("since", fmt.Sprint(since))
+	}
+	if watch {
+		q.Set("watch", "true")
+	}
+
+	return q
+}
@@ -0,0 +1,52 @@
+package hivemind
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	v1 "github.com/kiosk404/eidolon/internal/pkg/api/v1"
+)
+
+// TokenInterface manages bootstrap tokens.
+type TokenInterface interface {
+	Create(ctx context.Context, req *v1.CreateTokenRequest) (*v1.Token, error)
+	Get(ctx context.Context, id string) (*v1.Token, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1.TokenList, error)
+	Revoke(ctx context.Context, id string) error
+}
+
+type tokens struct {
+	client *Client
+}
+
+func (t *tokens) Create(ctx context.Context, req *v1.CreateTokenRequest) (*v1.Token, error) {
+	out := &v1.Token{}
+	if err := t.client.do(ctx, http.MethodPost, "/api/v1/tokens", nil, req, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (t *tokens) Get(ctx context.Context, id string) (*v1.Token, error) {
+	out := &v1.Token{}
+	if err := t.client.do(ctx, http.MethodGet, "/api/v1/tokens/"+url.PathEscape(id), nil, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (t *tokens) List(ctx context.Context, opts v1.ListOptions) (*v1.TokenList, error) {
+	out := &v1.TokenList{}
+	if err := t.client.do(ctx, http.MethodGet, "/api/v1/tokens", listQuery(opts), nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (t *tokens) Revoke(ctx context.Context, id string) error {
+	return t.client.do(ctx, http.MethodDelete, "/api/v1/tokens/"+url.PathEscape(id), nil, nil, nil)
+}
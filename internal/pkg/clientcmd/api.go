@@ -0,0 +1,166 @@
+// Package clientcmd reads and writes the client configuration of eidoctl, like
+// ~/.eidolon/config: the hiveminds it knows, the credentials to reach them
+// with, and the named contexts pairing the two.
+package clientcmd
+
+// Config is the client configuration file.
+type Config struct {
+	// CurrentContext is the context used when none is selected explicitly.
+	CurrentContext string `json:"current_context" yaml:"currentContext"`
+
+	Hiveminds []*NamedHivemind `json:"hiveminds" yaml:"hiveminds"`
+	Users     []*NamedAuthInfo `json:"users"     yaml:"users"`
+	Contexts  []*NamedContext  `json:"contexts"  yaml:"contexts"`
+
+	// path is the file the configuration was loaded from, relative paths in
+	// the configuration are relative to its directory.
+	path string
+}
+
+// Hivemind is how to reach a hivemind.
+type Hivemind struct {
+	// Server is the URL of the REST API, like: https://hivemind:11789.
+	Server string `json:"server,omitempty" yaml:"server,omitempty"`
+
+	// GRPCServer is the host:port of the gRPC endpoint nodes join through.
+	GRPCServer string `json:"grpc_server,omitempty" yaml:"grpcServer,omitempty"`
+
+	// CertificateAuthority is the path of the PEM bundle that verifies the
+	// hivemind certificate.
+	CertificateAuthority string `json:"certificate_authority,omitempty" yaml:"certificateAuthority,omitempty"`
+
+	// CertificateAuthorityData is the base64 encoded PEM bundle, it takes
+	// precedence over CertificateAuthority.
+	CertificateAuthorityData string `json:"certificate_authority_data,omitempty" yaml:"certificateAuthorityData,omitempty"`
+
+	// InsecureSkipTLSVerify disables the verification of the hivemind certificate.
+	InsecureSkipTLSVerify bool `json:"insecure_skip_tls_verify,omitempty" yaml:"insecureSkipTLSVerify,omitempty"`
+}
+
+// AuthInfo holds the credentials to present to a hivemind.
+type AuthInfo struct {
+	// ClientCertificate and ClientKey are the paths of the PEM encoded client
+	// certificate and key used for mutual TLS.
+	ClientCertificate string `json:"client_certificate,omitempty" yaml:"clientCertificate,omitempty"`
+	ClientKey         string `json:"client_key,omitempty"         yaml:"clientKey,omitempty"`
+}
+
+// Context pairs a hivemind with the credentials to use against it.
+type Context struct {
+	// Hivemind and User are the names of entries of the configuration.
+	Hivemind string `json:"hivemind"       yaml:"hivemind"`
+	User     string `json:"user,omitempty" yaml:"user,omitempty"`
+
+	// Space is the default space of the commands run in this context.
+	Space string `json:"space,omitempty" yaml:"space,omitempty"`
+}
+
+// NamedHivemind is a Hivemind with the name it is referenced by.
+type NamedHivemind struct {
+	Name     string    `json:"name"     yaml:"name"`
+	Hivemind *Hivemind `json:"hivemind" yaml:"hivemind"`
+}
+
+// NamedAuthInfo is an AuthInfo with the name it is referenced by.
+type NamedAuthInfo struct {
+	Name string    `json:"name" yaml:"name"`
+	User *AuthInfo `json:"user" yaml:"user"`
+}
+
+// NamedContext is a Context with the name it is referenced by.
+type NamedContext struct {
+	Name    string   `json:"name"    yaml:"name"`
+	Context *Context `json:"context" yaml:"context"`
+}
+
+// NewConfig returns an empty configuration.
+func NewConfig() *Config {
+	return &Config{
+		Hiveminds: []*NamedHivemind{},
+		Users:     []*NamedAuthInfo{},
+		Contexts:  []*NamedContext{},
+	}
+}
+
+// Path returns the file the configuration was loaded from.
+func (c *Config) Path() string {
+	return c.path
+}
+
+// Hivemind returns the hivemind called name, nil if there is none.
+func (c *Config) Hivemind(name string) *Hivemind {
+	for _, h := range c.Hiveminds {
+		if h.Name == name {
+			return h.Hivemind
+		}
+	}
+
+	return nil
+}
+
+// User returns the credentials called name, nil if there are none.
+func (c *Config) User(name string) *AuthInfo {
+	for _, u := range c.Users {
+		if u.Name == name {
+			return u.User
+		}
+	}
+
+	return nil
+}
+
+// Context returns the context called name, nil if there is none.
+func (c *Config) Context(name string) *Context {
+	for _, ctx := range c.Contexts {
+		if ctx.Name == name {
+			return ctx.Context
+		}
+	}
+
+	return nil
+}
+
+// SetHivemind adds or replaces the hivemind called name.
+func (c *Config) SetHivemind(name string, h *Hivemind) {
+	for _, named := range c.Hiveminds {
+		if named.Name == name {
+			named.Hivemind = h
+			return
+		}
+	}
+	c.Hiveminds = append(c.Hiveminds, &NamedHivemind{Name: name, Hivemind: h})
+}
+
+// SetUser adds or replaces the credentials called name.
+func (c *Config) SetUser(name string, u *AuthInfo) {
+	for _, named := range c.Users {
+		if named.Name == name {
+			named.User = u
+			return
+		}
+	}
+	c.Users = append(c.Users, &NamedAuthInfo{Name: name, User: u})
+}
+
+// SetContext adds or replaces the context called name.
+func (c *Config) SetContext(name string, ctx *Context) {
+	for _, named := range c.Contexts {
+		if named.Name == name {
+			named.Context = ctx
+			return
+		}
+	}
+	c.Contexts = append(c.Contexts, &NamedContext{Name: name, Context: ctx})
+}
+
+// DeleteContext removes the context called name and reports whether it existed.
+func (c *Config) DeleteContext(name string) bool {
+	for i, named := range c.Contexts {
+		if named.Name == name {
+			c.Contexts = append(c.Contexts[:i], c.Contexts[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
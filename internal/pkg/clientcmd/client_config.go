@@ -0,0 +1,182 @@
+package clientcmd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kiosk404/eidolon/internal/pkg/client/hivemind"
+)
+
+// DefaultGRPCServer is the gRPC endpoint used when none is configured.
+const DefaultGRPCServer = "127.0.0.1:11788"
+
+// Environment variables overriding the configuration file. Command line flags
+// take precedence over them.
+const (
+	ContextEnvVar      = "EIDOLON_CONTEXT"
+	HivemindAPIEnvVar  = "EIDOLON_HIVEMIND_API"
+	HivemindAddrEnvVar = "EIDOLON_HIVEMIND_ADDR"
+)
+
+// Overrides are the settings given on the command line or in the environment,
+// they take precedence over the configuration file.
+type Overrides struct {
+	// Context and User select entries of the configuration file instead of
+	// the current context and its user.
+	Context string
+	User    string
+
+	// Hivemind settings replace those of the selected hivemind when not empty.
+	Hivemind Hivemind
+}
+
+// ClientConfig is the merged connection settings a command runs with.
+type ClientConfig struct {
+	// Context is the name of the selected context, empty when the file has none.
+	Context string
+
+	Hivemind Hivemind
+	AuthInfo AuthInfo
+	Space    string
+}
+
+// NewClientConfig resolves the context selected by overrides, or the current
+// context of cfg, and applies the overrides to it. Settings that are still
+// empty get their defaults.
+func NewClientConfig(cfg *Config, overrides *Overrides) (*ClientConfig, error) {
+	cc := &ClientConfig{Context: cfg.CurrentContext}
+	if overrides.Context != "" {
+		cc.Context = overrides.Context
+	}
+
+	var ctx Context
+	if cc.Context != "" {
+		c := cfg.Context(cc.Context)
+		if c == nil {
+			return nil, fmt.Errorf("context %q does not exist in %s", cc.Context, cfg.path)
+		}
+		ctx = *c
+	}
+	if overrides.User != "" {
+		ctx.User = overrides.User
+	}
+	cc.Space = ctx.Space
+
+	if ctx.Hivemind != "" {
+		h := cfg.Hivemind(ctx.Hivemind)
+		if h == nil {
+			return nil, fmt.Errorf("hivemind %q of context %q does not exist in %s", ctx.Hivemind, cc.Context, cfg.path)
+		}
+		cc.Hivemind = *h
+		cc.Hivemind.CertificateAuthority = cfg.resolvePath(h.CertificateAuthority)
+	}
+	if ctx.User != "" {
+		u := cfg.User(ctx.User)
+		if u == nil {
+			return nil, fmt.Errorf("user %q does not exist in %s", ctx.User, cfg.path)
+		}
+		cc.AuthInfo = *u
+		cc.AuthInfo.ClientCertificate = cfg.resolvePath(u.ClientCertificate)
+		cc.AuthInfo.ClientKey = cfg.resolvePath(u.ClientKey)
+	}
+
+	o := overrides.Hivemind
+	if o.Server != "" {
+		cc.Hivemind.Server = o.Server
+	}
+	if o.GRPCServer != "" {
+		cc.Hivemind.GRPCServer = o.GRPCServer
+	}
+	if o.CertificateAuthority != "" {
+		cc.Hivemind.CertificateAuthority = o.CertificateAuthority
+		cc.Hivemind.CertificateAuthorityData = ""
+	}
+	if o.InsecureSkipTLSVerify {
+		cc.Hivemind.InsecureSkipTLSVerify = true
+	}
+
+	if cc.Hivemind.Server == "" {
+		cc.Hivemind.Server = hivemind.DefaultAddress
+	}
+	if cc.Hivemind.GRPCServer == "" {
+		cc.Hivemind.GRPCServer = DefaultGRPCServer
+	}
+
+	return cc, nil
+}
+
+// HivemindConfig returns the configuration of a client of the REST API.
+func (c *ClientConfig) HivemindConfig() (*hivemind.Config, error) {
+	tlsConfig, err := c.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return &hivemind.Config{
+		Address:   c.Hivemind.Server,
+		TLSConfig: tlsConfig,
+	}, nil
+}
+
+// TLSConfig returns the TLS settings to reach the hivemind with, nil when
+// neither a CA, client credentials nor insecure mode are configured, in which
+// case https addresses are verified against the system roots.
+func (c *ClientConfig) TLSConfig() (*tls.Config, error) {
+	h, u := c.Hivemind, c.AuthInfo
+	if h.CertificateAuthority == "" && h.CertificateAuthorityData == "" && !h.InsecureSkipTLSVerify &&
+		u.ClientCertificate == "" && u.ClientKey == "" {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: h.InsecureSkipTLSVerify} //nolint:gosec
+
+	var caPEM []byte
+	switch {
+	case h.CertificateAuthorityData != "":
+		data, err := base64.StdEncoding.DecodeString(h.CertificateAuthorityData)
+		if err != nil {
+			return nil, fmt.Errorf("decode certificate-authority-data: %w", err)
+		}
+		caPEM = data
+	case h.CertificateAuthority != "":
+		data, err := os.ReadFile(h.CertificateAuthority)
+		if err != nil {
+			return nil, err
+		}
+		caPEM = data
+	}
+	if caPEM != nil {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificates found in the certificate authority of the hivemind")
+		}
+		cfg.RootCAs = pool
+	}
+
+	if u.ClientCertificate != "" || u.ClientKey != "" {
+		if u.ClientCertificate == "" || u.ClientKey == "" {
+			return nil, fmt.Errorf("client-certificate and client-key must be set together")
+		}
+		cert, err := tls.LoadX509KeyPair(u.ClientCertificate, u.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// resolvePath makes a relative path of the configuration relative to the
+// directory of its file.
+func (c *Config) resolvePath(path string) string {
+	if path == "" || filepath.IsAbs(path) || c.path == "" {
+		return path
+	}
+
+	return filepath.Join(filepath.Dir(c.path), path)
+}
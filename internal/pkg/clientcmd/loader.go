@@ -0,0 +1,117 @@
+package clientcmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/kiosk404/eidolon/internal/pkg/workspace"
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	// RecommendedFileName is the name of the configuration file in the eidolon
+	// home directory.
+	RecommendedFileName = "config"
+
+	// RecommendedConfigPathEnvVar overrides the path of the configuration file.
+	RecommendedConfigPathEnvVar = "EIDOLON_CONFIG"
+)
+
+// RecommendedConfigPath returns the default path of the configuration file,
+// like: /home/eidolon/.eidolon/config.
+func RecommendedConfigPath() string {
+	return filepath.Join(workspace.DefaultDir(), RecommendedFileName)
+}
+
+// LoadFromFile reads the configuration file at path. A missing file yields an
+// empty configuration.
+func LoadFromFile(path string) (*Config, error) {
+	cfg := NewConfig()
+	cfg.path = path
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if err := Validate(cfg); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// WriteToFile writes cfg to path, readable by its owner only since it may hold
+// credentials.
+func WriteToFile(cfg *Config, path string) error {
+	buf := &bytes.Buffer{}
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(cfg); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+
+	return workspace.WriteFile(path, buf.Bytes(), 0o600)
+}
+
+// Validate checks that the entries of cfg are complete and named uniquely.
+// Contexts may reference entries that do not exist yet, that is reported when
+// they are used.
+func Validate(cfg *Config) error {
+	kinds := []struct {
+		kind  string
+		names []string
+	}{
+		{"hivemind", make([]string, 0, len(cfg.Hiveminds))},
+		{"user", make([]string, 0, len(cfg.Users))},
+		{"context", make([]string, 0, len(cfg.Contexts))},
+	}
+	for _, h := range cfg.Hiveminds {
+		if h.Hivemind == nil {
+			return fmt.Errorf("hivemind %q has no settings", h.Name)
+		}
+		kinds[0].names = append(kinds[0].names, h.Name)
+	}
+	for _, u := range cfg.Users {
+		if u.User == nil {
+			return fmt.Errorf("user %q has no settings", u.Name)
+		}
+		kinds[1].names = append(kinds[1].names, u.Name)
+	}
+	for _, c := range cfg.Contexts {
+		if c.Context == nil {
+			return fmt.Errorf("context %q has no settings", c.Name)
+		}
+		kinds[2].names = append(kinds[2].names, c.Name)
+	}
+
+	for _, k := range kinds {
+		seen := make(map[string]struct{}, len(k.names))
+		for _, name := range k.names {
+			if name == "" {
+				return fmt.Errorf("a %s has no name", k.kind)
+			}
+			if _, ok := seen[name]; ok {
+				return fmt.Errorf("%s %q is defined more than once", k.kind, name)
+			}
+			seen[name] = struct{}{}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package code
+
+import (
+	"net/http"
+)
+
+// Common: basic errors.
+// Code must start with 1xxxxx.
+const (
+	// ErrSuccess - 200: OK.
+	ErrSuccess int = iota + 100001
+
+	// ErrUnknown - 500: Internal server error.
+	ErrUnknown
+
+	// ErrBind - 400: Error occurred while binding the request body to the struct.
+	ErrBind
+
+	// ErrValidation - 400: Validation failed.
+	ErrValidation
+
+	// ErrPageNotFound - 404: Page not found.
+	ErrPageNotFound
+
+	// ErrTooManyRequests - 429: Too many requests.
+	ErrTooManyRequests
+
+	// ErrUnauthenticated - 401: A verified client certificate is required.
+	ErrUnauthenticated
+
+	// ErrPermissionDenied - 403: Permission denied.
+	ErrPermissionDenied
+)
+
+func init() {
+	register(ErrSuccess, http.StatusOK, "OK")
+	register(ErrUnknown, http.StatusInternalServerError, "Internal server error")
+	register(ErrBind, http.StatusBadRequest, "Error occurred while binding the request body to the struct")
+	register(ErrValidation, http.StatusBadRequest, "Validation failed")
+	register(ErrPageNotFound, http.StatusNotFound, "Page not found")
+	register(ErrTooManyRequests, http.StatusTooManyRequests, "Too many requests")
+	register(ErrUnauthenticated, http.StatusUnauthorized, "A verified client certificate is required")
+	register(ErrPermissionDenied, http.StatusForbidden, "Permission denied")
+}
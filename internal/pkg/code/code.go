@@ -0,0 +1,83 @@
+package code
+
+import (
+	"net/http"
+
+	"github.com/kiosk404/eidolon/pkg/errorx"
+)
+
+// ErrCode implements `github.com/kiosk404/eidolon/pkg/errorx`.Coder interface.
+type ErrCode struct {
+	// C refers to the code of the ErrCode.
+	C int
+
+	// HTTP status that should be used for the associated error code.
+	HTTP int
+
+	// External (user) facing error text.
+	Ext string
+
+	// Ref specify the reference document.
+	Ref string
+}
+
+var _ errorx.Coder = &ErrCode{}
+
+// Code returns the integer code of ErrCode.
+func (coder ErrCode) Code() int {
+	return coder.C
+}
+
+// String implements stringer. String returns the external error message,
+// if any.
+func (coder ErrCode) String() string {
+	return coder.Ext
+}
+
+// Reference returns the reference document.
+func (coder ErrCode) Reference() string {
+	return coder.Ref
+}
+
+// HTTPStatus returns the associated HTTP status code, if any. Otherwise,
+// returns 500.
+func (coder ErrCode) HTTPStatus() int {
+	if coder.HTTP == 0 {
+		return http.StatusInternalServerError
+	}
+
+	return coder.HTTP
+}
+
+// allowedHTTPStatus lists the HTTP statuses an error code may map to.
+var allowedHTTPStatus = map[int]struct{}{
+	http.StatusOK:                  {},
+	http.StatusBadRequest:          {},
+	http.StatusUnauthorized:        {},
+	http.StatusForbidden:           {},
+	http.StatusNotFound:            {},
+	http.StatusConflict:            {},
+	http.StatusTooManyRequests:     {},
+	http.StatusInternalServerError: {},
+	http.StatusServiceUnavailable:  {},
+}
+
+func register(code int, httpStatus int, message string, refs ...string) {
+	if _, ok := allowedHTTPStatus[httpStatus]; !ok {
+		panic("http code not in `200, 400, 401, 403, 404, 409, 429, 500, 503`")
+	}
+
+	var reference string
+	if len(refs) > 0 {
+		reference = refs[0]
+	}
+
+	coder := &ErrCode{
+		C:    code,
+		HTTP: httpStatus,
+		Ext:  message,
+		Ref:  reference,
+	}
+
+	errorx.MustRegister(coder)
+}
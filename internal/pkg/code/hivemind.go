@@ -0,0 +1,100 @@
+package code
+
+import (
+	"net/http"
+)
+
+// hivemind: webhook errors.
+// Code must start with 1101xx.
+const (
+	// ErrWebhookNotFound - 404: Webhook not found.
+	ErrWebhookNotFound int = iota + 110101
+
+	// ErrWebhookAlreadyExist - 409: Webhook already exist.
+	ErrWebhookAlreadyExist
+)
+
+func init() {
+	register(ErrWebhookNotFound, http.StatusNotFound, "Webhook not found")
+	register(ErrWebhookAlreadyExist, http.StatusConflict, "Webhook already exist")
+}
+
+// hivemind: task errors.
+// Code must start with 1102xx.
+const (
+	// ErrTaskNotFound - 404: Task not found.
+	ErrTaskNotFound int = iota + 110201
+
+	// ErrTaskAlreadyFinished - 409: Task already finished.
+	ErrTaskAlreadyFinished
+
+	// ErrTaskScheduleFailed - 500: Task could not be scheduled.
+	ErrTaskScheduleFailed
+
+	// ErrTaskNotScheduled - 404: Task has not been scheduled yet.
+	ErrTaskNotScheduled
+)
+
+func init() {
+	register(ErrTaskNotFound, http.StatusNotFound, "Task not found")
+	register(ErrTaskAlreadyFinished, http.StatusConflict, "Task already finished")
+	register(ErrTaskScheduleFailed, http.StatusInternalServerError, "Task could not be scheduled")
+	register(ErrTaskNotScheduled, http.StatusNotFound, "Task has not been scheduled yet")
+}
+
+// hivemind: node errors.
+// Code must start with 1103xx.
+const (
+	// ErrNodeNotFound - 404: Node not found.
+	ErrNodeNotFound int = iota + 110301
+
+	// ErrNodeDraining - 409: Node is already draining.
+	ErrNodeDraining
+
+	// ErrNodeBusy - 409: Node still has running tasks.
+	ErrNodeBusy
+)
+
+func init() {
+	register(ErrNodeNotFound, http.StatusNotFound, "Node not found")
+	register(ErrNodeDraining, http.StatusConflict, "Node is already draining")
+	register(ErrNodeBusy, http.StatusConflict, "Node still has running tasks")
+}
+
+// hivemind: bootstrap token errors.
+// Code must start with 1104xx.
+const (
+	// ErrTokenNotFound - 404: Bootstrap token not found.
+	ErrTokenNotFound int = iota + 110401
+
+	// ErrTokenInvalid - 401: Bootstrap token is invalid, expired or used up.
+	ErrTokenInvalid
+)
+
+func init() {
+	register(ErrTokenNotFound, http.StatusNotFound, "Bootstrap token not found")
+	register(ErrTokenInvalid, http.StatusUnauthorized, "Bootstrap token is invalid, expired or used up")
+}
+
+// hivemind: skill registry errors.
+// Code must start with 1105xx.
+const (
+	// ErrSkillNotFound - 404: Skill not found.
+	ErrSkillNotFound int = iota + 110501
+
+	// ErrSkillAlreadyExist - 409: Skill version already exist with another content.
+	ErrSkillAlreadyExist
+
+	// ErrSkillPackageInvalid - 400: Skill package is invalid.
+	ErrSkillPackageInvalid
+
+	// ErrSkillRegistryDisabled - 404: Skill registry is not enabled.
+	ErrSkillRegistryDisabled
+)
+
+func init() {
+	register(ErrSkillNotFound, http.StatusNotFound, "Skill not found")
+	register(ErrSkillAlreadyExist, http.StatusConflict, "Skill version already exist with another content")
+	register(ErrSkillPackageInvalid, http.StatusBadRequest, "Skill package is invalid")
+	register(ErrSkillRegistryDisabled, http.StatusNotFound, "Skill registry is not enabled")
+}
@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	grpcHandledTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "grpc_server",
+		Name:      "handled_total",
+		Help:      "Total number of RPCs completed on the server, regardless of success or failure.",
+	}, []string{"grpc_type", "grpc_service", "grpc_method", "grpc_code"})
+
+	grpcHandlingSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "grpc_server",
+		Name:      "handling_seconds",
+		Help:      "Latency of RPCs handled by the server. Streams are measured until they close.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"grpc_type", "grpc_service", "grpc_method"})
+)
+
+func init() {
+	Registry.MustRegister(grpcHandledTotal, grpcHandlingSeconds)
+}
+
+// UnaryServerInterceptor records the outcome and latency of unary RPCs.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observeRPC("unary", info.FullMethod, start, err)
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor records the outcome and lifetime of streaming RPCs.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		typ := "bidi_stream"
+		switch {
+		case info.IsClientStream && !info.IsServerStream:
+			typ = "client_stream"
+		case !info.IsClientStream && info.IsServerStream:
+			typ = "server_stream"
+		}
+
+		start := time.Now()
+		err := handler(srv, ss)
+		observeRPC(typ, info.FullMethod, start, err)
+
+		return err
+	}
+}
+
+func observeRPC(typ, fullMethod string, start time.Time, err error) {
+	service, method := splitMethodName(fullMethod)
+	grpcHandledTotal.WithLabelValues(typ, service, method, status.Code(err).String()).Inc()
+	grpcHandlingSeconds.WithLabelValues(typ, service, method).Observe(time.Since(start).Seconds())
+}
+
+// splitMethodName splits "/package.Service/Method" into its service and method.
+func splitMethodName(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.Index(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+
+	return "unknown", "unknown"
+}
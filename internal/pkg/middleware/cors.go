@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CorsConfig configures the Cors middleware.
+type CorsConfig struct {
+	// AllowOrigins lists the allowed origins; "*" allows any origin.
+	AllowOrigins []string
+	AllowMethods []string
+	AllowHeaders []string
+	// ExposeHeaders lists the response headers readable by the browser.
+	ExposeHeaders []string
+	MaxAge        int
+}
+
+// DefaultCorsConfig allows any origin to call the API.
+func DefaultCorsConfig() CorsConfig {
+	return CorsConfig{
+		AllowOrigins:  []string{"*"},
+		AllowMethods:  []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
+		AllowHeaders:  []string{"Origin", "Content-Type", "Content-Length", "Accept", "Authorization", XRequestIDKey},
+		ExposeHeaders: []string{"Content-Length", XRequestIDKey},
+		MaxAge:        86400,
+	}
+}
+
+// Cors returns the cors middleware with the default configuration.
+func Cors() gin.HandlerFunc {
+	return CorsWithConfig(DefaultCorsConfig())
+}
+
+// CorsWithConfig returns a middleware that answers preflight requests and adds
+// the CORS headers to requests from allowed origins.
+func CorsWithConfig(cfg CorsConfig) gin.HandlerFunc {
+	allowAll := false
+	origins := make(map[string]struct{}, len(cfg.AllowOrigins))
+	for _, o := range cfg.AllowOrigins {
+		if o == "*" {
+			allowAll = true
+		}
+		origins[o] = struct{}{}
+	}
+	methods := strings.Join(cfg.AllowMethods, ", ")
+	headers := strings.Join(cfg.AllowHeaders, ", ")
+	expose := strings.Join(cfg.ExposeHeaders, ", ")
+	maxAge := ""
+	if cfg.MaxAge > 0 {
+		maxAge = strconv.Itoa(cfg.MaxAge)
+	}
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+		if _, ok := origins[origin]; !ok && !allowAll {
+			if c.Request.Method == http.MethodOptions {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		if allowAll {
+			c.Header("Access-Control-Allow-Origin", "*")
+		} else {
+			c.Header("Access-Control-Allow-Origin", origin)
+			c.Header("Vary", "Origin")
+		}
+		if expose != "" {
+			c.Header("Access-Control-Expose-Headers", expose)
+		}
+
+		if c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != "" {
+			c.Header("Access-Control-Allow-Methods", methods)
+			c.Header("Access-Control-Allow-Headers", headers)
+			if maxAge != "" {
+				c.Header("Access-Control-Max-Age", maxAge)
+			}
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		c.Next()
+	}
+}
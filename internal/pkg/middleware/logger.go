@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// Logger is a middleware that logs one line per request once it has been
+// served. Install it after requestid so the line carries the request ID.
+func Logger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		path := c.Request.URL.Path
+		if raw := c.Request.URL.RawQuery; raw != "" {
+			path = path + "?" + raw
+		}
+
+		c.Next()
+
+		status := c.Writer.Status()
+		line := "%3d - [%s] %v %s %s"
+		args := []interface{}{status, c.ClientIP(), time.Since(start), c.Request.Method, path}
+		if errs := c.Errors.ByType(gin.ErrorTypePrivate).String(); errs != "" {
+			line += " %s"
+			args = append(args, errs)
+		}
+
+		switch {
+		case status >= 500:
+			logger.CtxError(c, line, args...)
+		case status >= 400:
+			logger.CtxWarn(c, line, args...)
+		default:
+			logger.CtxInfo(c, line, args...)
+		}
+	}
+}
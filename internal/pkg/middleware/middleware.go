@@ -0,0 +1,53 @@
+// Package middleware holds the gin middlewares that can be enabled by name
+// through --server.middlewares.
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Middlewares store registered middlewares, keyed by the name used in
+// --server.middlewares. They are installed in the order the names are listed.
+var Middlewares = defaultMiddlewares()
+
+// DefaultNames lists the middlewares installed when none are configured.
+var DefaultNames = []string{"recovery", "requestid", "logger"}
+
+// NoCache is a middleware function that appends headers
+// to prevent the client from caching the HTTP response.
+func NoCache(c *gin.Context) {
+	c.Header("Cache-Control", "no-cache, no-store, max-age=0, must-revalidate, value")
+	c.Header("Expires", "Thu, 01 Jan 1970 00:00:00 GMT")
+	c.Header("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+	c.Next()
+}
+
+// Secure is a middleware function that appends security
+// and resource access headers.
+func Secure(c *gin.Context) {
+	c.Header("X-Frame-Options", "DENY")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("X-XSS-Protection", "1; mode=block")
+	c.Header("Referrer-Policy", "no-referrer")
+	if c.Request.TLS != nil {
+		c.Header("Strict-Transport-Security", "max-age=31536000")
+	}
+	c.Next()
+}
+
+func defaultMiddlewares() map[string]gin.HandlerFunc {
+	return map[string]gin.HandlerFunc{
+		"recovery":  gin.Recovery(),
+		"requestid": RequestID(),
+		"logger":    Logger(),
+		"cors":      Cors(),
+		"secure":    Secure,
+		"nocache":   NoCache,
+		"timeout":   Timeout(DefaultRequestTimeout),
+		"gzip":      Gzip(),
+		"ratelimit": RateLimit(DefaultRateLimit, DefaultRateBurst),
+	}
+}
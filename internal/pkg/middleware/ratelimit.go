@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/eidolon/internal/pkg/code"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/pkg/errorx"
+)
+
+const (
+	// DefaultRateLimit is the number of requests per second allowed by the ratelimit middleware.
+	DefaultRateLimit = 100
+
+	// DefaultRateBurst is the burst size allowed by the ratelimit middleware.
+	DefaultRateBurst = 200
+)
+
+// tokenBucket is a minimal thread-safe token bucket.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// allow takes a token if one is available.
+func (b *tokenBucket) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+
+	return true
+}
+
+// RateLimit rejects requests with 429 once the server receives more than
+// ratePerSecond requests per second on average, allowing bursts of up to burst.
+func RateLimit(ratePerSecond float64, burst int) gin.HandlerFunc {
+	b := &tokenBucket{
+		rate:   ratePerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+
+	return func(c *gin.Context) {
+		if !b.allow(time.Now()) {
+			core.WriteResponse(c, errorx.WithCode(code.ErrTooManyRequests, "rate limit of %v requests per second exceeded", ratePerSecond), nil)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/eidolon/pkg/logger"
+	"github.com/kiosk404/eidolon/pkg/utils/idutil"
+)
+
+// XRequestIDKey defines X-Request-ID key string.
+const XRequestIDKey = "X-Request-ID"
+
+// maxRequestIDLength bounds the length of a request ID accepted from a client.
+const maxRequestIDLength = 128
+
+// RequestID is a middleware that injects a 'X-Request-ID' into the context and
+// request/response header of each request. An ID sent by the client is reused.
+// The ID is also stored under logger.CtxKeyLogID, so logger.GetLogID and the
+// logger.Ctx* functions pick it up from both the gin and the request context.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rid := c.GetHeader(XRequestIDKey)
+		if rid == "" || len(rid) > maxRequestIDLength {
+			rid = idutil.RandomHex(16)
+			c.Request.Header.Set(XRequestIDKey, rid)
+		}
+
+		c.Set(XRequestIDKey, rid)
+		c.Set(logger.CtxKeyLogID, rid)
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), logger.CtxKeyLogID, rid))
+
+		// Set XRequestIDKey header
+		c.Writer.Header().Set(XRequestIDKey, rid)
+		c.Next()
+	}
+}
+
+// GetRequestID returns the request ID set by the RequestID middleware.
+func GetRequestID(c *gin.Context) string {
+	return c.GetString(XRequestIDKey)
+}
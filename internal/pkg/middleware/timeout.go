@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultRequestTimeout is the deadline applied by the timeout middleware.
+const DefaultRequestTimeout = 30 * time.Second
+
+// Timeout puts a deadline on the request context. Handlers and services that
+// honour their context stop once it expires; long-lived streams such as SSE
+// should not be routed through this middleware.
+func Timeout(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
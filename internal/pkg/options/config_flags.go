@@ -1,19 +1,154 @@
 package options
 
+import (
+	"os"
+	"sync"
+
+	"github.com/kiosk404/eidolon/internal/pkg/clientcmd"
+	"github.com/spf13/pflag"
+)
+
+// Flags of the client configuration.
+const (
+	flagEidoConfig           = "eidoconfig"
+	flagContext              = "context"
+	flagUser                 = "user"
+	flagHivemindAPI          = "hivemind-api"
+	flagHivemindAddr         = "hivemind-addr"
+	flagCertificateAuthority = "certificate-authority"
+)
+
 // RESTClientGetter is an interface that the ConfigFlags describe to provide an easier way to mock for commands
 // and eliminate the direct coupling to a struct type.  Users may wish to duplicate this type in their own packages
 // as per the golang type overlapping.
 type RESTClientGetter interface {
+	// ToRawConfig returns the configuration file as it is on disk, without
+	// the flag and environment overrides.
+	ToRawConfig() (*clientcmd.Config, error)
+	// ToClientConfig returns the settings of the selected context with the
+	// flag and environment overrides applied.
+	ToClientConfig() (*clientcmd.ClientConfig, error)
+	// ConfigPath returns the path of the configuration file.
+	ConfigPath() string
 }
 
 var _ RESTClientGetter = &ConfigFlags{}
 
 // ConfigFlags composes the set of values necessary
 // for obtaining a REST client config.
+//
+// Every setting is read from its flag if set, from its environment variable
+// otherwise, and from the configuration file last.
 type ConfigFlags struct {
+	EidoConfig           *string
+	Context              *string
+	User                 *string
+	HivemindAPI          *string
+	HivemindAddr         *string
+	CertificateAuthority *string
+
+	// usePersistentConfig loads the configuration file once and reuses it.
+	usePersistentConfig bool
+	rawConfig           *clientcmd.Config
+	lock                sync.Mutex
 }
 
 // NewConfigFlags returns ConfigFlags with default values set.
 func NewConfigFlags(usePersistentConfig bool) *ConfigFlags {
-	return &ConfigFlags{}
+	return &ConfigFlags{
+		EidoConfig:           stringptr(""),
+		Context:              stringptr(""),
+		User:                 stringptr(""),
+		HivemindAPI:          stringptr(""),
+		HivemindAddr:         stringptr(""),
+		CertificateAuthority: stringptr(""),
+
+		usePersistentConfig: usePersistentConfig,
+	}
+}
+
+// AddFlags binds client configuration flags to a given flagset.
+func (f *ConfigFlags) AddFlags(flags *pflag.FlagSet) {
+	if f.EidoConfig != nil {
+		flags.StringVar(f.EidoConfig, flagEidoConfig, *f.EidoConfig,
+			"Path to the eidoctl configuration file, defaults to $"+clientcmd.RecommendedConfigPathEnvVar+
+				" or ~/.eidolon/"+clientcmd.RecommendedFileName)
+	}
+	if f.Context != nil {
+		flags.StringVar(f.Context, flagContext, *f.Context,
+			"The name of the context to use instead of the current context, defaults to $"+clientcmd.ContextEnvVar)
+	}
+	if f.User != nil {
+		flags.StringVar(f.User, flagUser, *f.User, "The name of the credentials to use instead of those of the context")
+	}
+	if f.HivemindAPI != nil {
+		flags.StringVar(f.HivemindAPI, flagHivemindAPI, *f.HivemindAPI,
+			"URL of the hivemind REST API used by the management commands, defaults to $"+
+				clientcmd.HivemindAPIEnvVar+", the context or http://127.0.0.1:11789")
+	}
+	if f.HivemindAddr != nil {
+		flags.StringVar(f.HivemindAddr, flagHivemindAddr, *f.HivemindAddr,
+			"Address of the hivemind central server (host:port), defaults to $"+
+				clientcmd.HivemindAddrEnvVar+", the context or "+clientcmd.DefaultGRPCServer)
+	}
+	if f.CertificateAuthority != nil {
+		flags.StringVar(f.CertificateAuthority, flagCertificateAuthority, *f.CertificateAuthority,
+			"Path to a CA bundle to verify the hivemind REST API with")
+	}
+}
+
+// ConfigPath returns the path of the configuration file.
+func (f *ConfigFlags) ConfigPath() string {
+	return firstNonEmpty(*f.EidoConfig, os.Getenv(clientcmd.RecommendedConfigPathEnvVar), clientcmd.RecommendedConfigPath())
+}
+
+// ToRawConfig implements RESTClientGetter.
+func (f *ConfigFlags) ToRawConfig() (*clientcmd.Config, error) {
+	if !f.usePersistentConfig {
+		return clientcmd.LoadFromFile(f.ConfigPath())
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.rawConfig == nil {
+		cfg, err := clientcmd.LoadFromFile(f.ConfigPath())
+		if err != nil {
+			return nil, err
+		}
+		f.rawConfig = cfg
+	}
+
+	return f.rawConfig, nil
+}
+
+// ToClientConfig implements RESTClientGetter.
+func (f *ConfigFlags) ToClientConfig() (*clientcmd.ClientConfig, error) {
+	cfg, err := f.ToRawConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return clientcmd.NewClientConfig(cfg, &clientcmd.Overrides{
+		Context: firstNonEmpty(*f.Context, os.Getenv(clientcmd.ContextEnvVar)),
+		User:    *f.User,
+		Hivemind: clientcmd.Hivemind{
+			Server:               firstNonEmpty(*f.HivemindAPI, os.Getenv(clientcmd.HivemindAPIEnvVar)),
+			GRPCServer:           firstNonEmpty(*f.HivemindAddr, os.Getenv(clientcmd.HivemindAddrEnvVar)),
+			CertificateAuthority: *f.CertificateAuthority,
+		},
+	})
+}
+
+func stringptr(val string) *string {
+	return &val
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
 }
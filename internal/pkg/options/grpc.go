@@ -6,16 +6,16 @@ import (
 	"github.com/spf13/pflag"
 )
 
-// GRPCOptions are for creating an unauthenticated, unauthorized, insecure port.
-// No one should be using these anymore.
+// GRPCOptions are for creating the port golems connect to. The port serves TLS
+// with the certificate of SecureServingOptions once one is configured, and
+// requires client certificates when a client CA is configured as well.
 type GRPCOptions struct {
 	BindAddress string `json:"bind-address" mapstructure:"bind-address"`
 	BindPort    int    `json:"bind-port"    mapstructure:"bind-port"`
 	MaxMsgSize  int    `json:"max-msg-size" mapstructure:"max-msg-size"`
 }
 
-// NewGRPCOptions is for creating an unauthenticated, unauthorized, insecure port.
-// No one should be using these anymore.
+// NewGRPCOptions creates a GRPCOptions object with default parameters.
 func NewGRPCOptions() *GRPCOptions {
 	return &GRPCOptions{
 		BindAddress: "0.0.0.0",
@@ -33,7 +33,7 @@ func (s *GRPCOptions) Validate() []error {
 		errors = append(
 			errors,
 			fmt.Errorf(
-				"--insecure-port %v must be between 0 and 65535, inclusive. 0 for turning off insecure (HTTP) port",
+				"--grpc.bind-port %v must be between 0 and 65535, inclusive. 0 for turning off grpc port",
 				s.BindPort,
 			),
 		)
@@ -49,10 +49,9 @@ func (s *GRPCOptions) AddFlags(fs *pflag.FlagSet) {
 		"The IP address on which to serve the --grpc.bind-port(set to 0.0.0.0 for all IPv4 interfaces and :: for all IPv6 interfaces).")
 
 	fs.IntVar(&s.BindPort, "grpc.bind-port", s.BindPort, ""+
-		"The port on which to serve unsecured, unauthenticated grpc access. It is assumed "+
-		"that firewall rules are set up such that this port is not reachable from outside of "+
-		"the deployed machine and that port 11789 on the ultronix hivemind public address is proxied to this "+
-		"port. This is performed by nginx in the default setup. Set to zero to disable.")
+		"The port on which to serve grpc access for golems. Unless --secure.tls.cert-key.cert-file is "+
+		"set the port is unsecured and unauthenticated, and it is assumed that firewall rules are set up "+
+		"such that it is not reachable from outside of the deployed machine. Set to zero to disable.")
 
 	fs.IntVar(&s.MaxMsgSize, "grpc.max-msg-size", s.MaxMsgSize, "gRPC max message size.")
 }
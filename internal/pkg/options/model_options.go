@@ -1,37 +1,241 @@
 package options
 
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/kiosk404/eidolon/pkg/utils/cliflag"
+	"github.com/spf13/pflag"
+)
+
+// The APIs a model can be called through.
+const (
+	ModelAPIOpenAICompletions  = "openai-completions"
+	ModelAPIOpenAIResponses    = "openai-responses"
+	ModelAPIAnthropicMessages  = "anthropic-messages"
+	ModelAPIGoogleGenerativeAI = "google-generative-ai"
+	ModelAPIOllama             = "ollama"
+)
+
+// DefaultModelAPIKeyEnv is the environment variable the API key of the custom
+// proxy is read from by default.
+const DefaultModelAPIKeyEnv = "EIDOLON_MODEL_API_KEY"
+
+var knownModelAPIs = []string{
+	ModelAPIOpenAICompletions,
+	ModelAPIOpenAIResponses,
+	ModelAPIAnthropicMessages,
+	ModelAPIGoogleGenerativeAI,
+	ModelAPIOllama,
+}
+
+// ModelOptions contains configuration items related to the models the
+// hivemind calls. The keys of the section are snake_case, its flags
+// kebab-case like the other flags.
 type ModelOptions struct {
-	Mode      string        `json:"mode"`
-	Providers ModelProvider `json:"providers"`
+	Mode      string        `json:"mode"      mapstructure:"mode"`
+	Providers ModelProvider `json:"providers" mapstructure:"providers"`
+
+	// MetaFile is the YAML or JSON catalog of the model meta, the defaults
+	// of the models by model class and name. It is reloaded on change.
+	MetaFile string `json:"meta_file" mapstructure:"meta_file"`
+
+	// ModelsFile is the JSON file the models added through the hivemind are
+	// kept in. Empty keeps them in memory only, they are lost on restart.
+	ModelsFile string `json:"models_file" mapstructure:"models_file"`
 }
 
 type ModelProvider struct {
-	CustomProxy ModelCustomProxy `json:"custom_proxy"`
+	CustomProxy ModelCustomProxy `json:"custom_proxy" mapstructure:"custom_proxy"`
 }
 
+// ModelCustomProxy is an endpoint serving the models through one of the
+// known APIs, like an OpenAI compatible gateway.
 type ModelCustomProxy struct {
-	BaseURL    string            `json:"base_url"`
-	APIKey     string            `json:"api_key"`
-	Api        string            `json:"api"`
-	AuthHeader bool              `json:"auth_header"`
-	Headers    map[string]string `json:"headers"`
-	Models     []Model           `json:"models"`
+	BaseURL string `json:"base_url" mapstructure:"base_url"`
+	APIKey  string `json:"api_key"  mapstructure:"api_key"`
+
+	// APIKeyEnv names the environment variable that overrides APIKey when set.
+	APIKeyEnv string `json:"api_key_env" mapstructure:"api_key_env"`
+
+	// Api is the API of the models that do not set their own.
+	Api        string            `json:"api"         mapstructure:"api"`
+	AuthHeader bool              `json:"auth_header" mapstructure:"auth_header"`
+	Headers    map[string]string `json:"headers"     mapstructure:"headers"`
+	Models     []Model           `json:"models"      mapstructure:"models"`
 }
 
 type Model struct {
-	ID            string    `json:"id"`
-	Name          string    `json:"name"`
-	Api           string    `json:"api"`
-	Reason        string    `json:"reason"`
-	Input         []string  `json:"input"`
-	Cost          ModelCost `json:"cost"`
-	ContextWindow int       `json:"context_window"`
-	MaxTokens     int       `json:"max_tokens"`
+	ID            string    `json:"id"             mapstructure:"id"`
+	Name          string    `json:"name"           mapstructure:"name"`
+	Api           string    `json:"api"            mapstructure:"api"`
+	Reason        string    `json:"reason"         mapstructure:"reason"`
+	Input         []string  `json:"input"          mapstructure:"input"`
+	Cost          ModelCost `json:"cost"           mapstructure:"cost"`
+	ContextWindow int       `json:"context_window" mapstructure:"context_window"`
+	MaxTokens     int       `json:"max_tokens"     mapstructure:"max_tokens"`
 }
 
 type ModelCost struct {
-	Input      int `json:"input"`
-	Output     int `json:"output"`
-	CacheRead  int `json:"cache_read"`
-	CacheWrite int `json:"cache_write"`
+	Input      int `json:"input"       mapstructure:"input"`
+	Output     int `json:"output"      mapstructure:"output"`
+	CacheRead  int `json:"cache_read"  mapstructure:"cache_read"`
+	CacheWrite int `json:"cache_write" mapstructure:"cache_write"`
+}
+
+// MarshalJSON hides the API key and the header values, which often carry
+// credentials too, so that the options can be logged.
+func (p ModelCustomProxy) MarshalJSON() ([]byte, error) {
+	type proxy ModelCustomProxy
+	out := proxy(p)
+	if out.APIKey != "" {
+		out.APIKey = "******"
+	}
+	if len(p.Headers) > 0 {
+		out.Headers = make(map[string]string, len(p.Headers))
+		for k := range p.Headers {
+			out.Headers[k] = "******"
+		}
+	}
+
+	return json.Marshal(out)
+}
+
+// NewModelOptions creates a ModelOptions object with default parameters.
+func NewModelOptions() *ModelOptions {
+	return &ModelOptions{
+		Providers: ModelProvider{
+			CustomProxy: ModelCustomProxy{
+				APIKeyEnv: DefaultModelAPIKeyEnv,
+			},
+		},
+	}
+}
+
+// Complete reads the API key from the environment when it is set there.
+func (o *ModelOptions) Complete() error {
+	proxy := &o.Providers.CustomProxy
+	if proxy.APIKeyEnv == "" {
+		return nil
+	}
+	if key := os.Getenv(proxy.APIKeyEnv); key != "" {
+		proxy.APIKey = key
+	}
+
+	return nil
+}
+
+// Validate is used to parse and validate the parameters entered by the user at
+// the command line when the program starts.
+func (o *ModelOptions) Validate() []error {
+	var errors []error
+
+	if o.MetaFile != "" {
+		if _, err := os.Stat(o.MetaFile); err != nil {
+			errors = append(errors, fmt.Errorf("--model.meta-file %s: %w", o.MetaFile, err))
+		}
+	}
+
+	proxy := o.Providers.CustomProxy
+	if proxy.BaseURL != "" {
+		if u, err := url.Parse(proxy.BaseURL); err != nil || u.Scheme == "" || u.Host == "" {
+			errors = append(errors, fmt.Errorf("--model.providers.custom-proxy.base-url %q is not an absolute URL", proxy.BaseURL))
+		}
+	} else if len(proxy.Models) > 0 {
+		errors = append(errors, fmt.Errorf("--model.providers.custom-proxy.base-url must be set to serve models"))
+	}
+	if proxy.Api != "" && !isKnownModelAPI(proxy.Api) {
+		errors = append(errors, fmt.Errorf("--model.providers.custom-proxy.api %q must be one of: %s",
+			proxy.Api, strings.Join(knownModelAPIs, ", ")))
+	}
+
+	ids := make(map[string]bool, len(proxy.Models))
+	for i, m := range proxy.Models {
+		if m.ID == "" {
+			errors = append(errors, fmt.Errorf("model %d of the custom proxy has no id", i))
+			continue
+		}
+		if ids[m.ID] {
+			errors = append(errors, fmt.Errorf("model %q of the custom proxy is declared twice", m.ID))
+		}
+		ids[m.ID] = true
+
+		switch {
+		case m.Api == "" && proxy.Api == "":
+			errors = append(errors, fmt.Errorf("model %q has no api and the custom proxy sets none", m.ID))
+		case m.Api != "" && !isKnownModelAPI(m.Api):
+			errors = append(errors, fmt.Errorf("model %q: api %q must be one of: %s",
+				m.ID, m.Api, strings.Join(knownModelAPIs, ", ")))
+		}
+		if m.ContextWindow <= 0 {
+			errors = append(errors, fmt.Errorf("model %q: context window %d must be positive", m.ID, m.ContextWindow))
+		}
+		if m.MaxTokens < 0 || m.MaxTokens > m.ContextWindow {
+			errors = append(errors, fmt.Errorf("model %q: max tokens %d must be between 0 and the context window",
+				m.ID, m.MaxTokens))
+		}
+		if c := m.Cost; c.Input < 0 || c.Output < 0 || c.CacheRead < 0 || c.CacheWrite < 0 {
+			errors = append(errors, fmt.Errorf("model %q: costs must not be negative", m.ID))
+		}
+	}
+
+	return errors
+}
+
+// AddFlags adds flags related to the models to the specified FlagSet. The
+// models of the custom proxy can only be set in the configuration file.
+func (o *ModelOptions) AddFlags(fs *pflag.FlagSet) {
+	if fs == nil {
+		return
+	}
+
+	proxy := &o.Providers.CustomProxy
+
+	fs.StringVar(&o.Mode, "model.mode", o.Mode, "Mode the models are accessed in.")
+
+	fs.StringVar(&o.MetaFile, "model.meta-file", o.MetaFile, ""+
+		"YAML or JSON catalog of the default meta of the models, by model class and model name. "+
+		"The file is reloaded when it changes.")
+
+	fs.StringVar(&o.ModelsFile, "model.models-file", o.ModelsFile, ""+
+		"JSON file the models added through the hivemind are kept in, readable by its owner only. "+
+		"Empty keeps them in memory only.")
+
+	fs.StringVar(&proxy.BaseURL, "model.providers.custom-proxy.base-url", proxy.BaseURL,
+		"Base URL of the custom proxy serving the models.")
+
+	fs.StringVar(&proxy.APIKey, "model.providers.custom-proxy.api-key", proxy.APIKey, ""+
+		"API key of the custom proxy. Prefer --model.providers.custom-proxy.api-key-env, flags are visible to other users.")
+
+	fs.StringVar(&proxy.APIKeyEnv, "model.providers.custom-proxy.api-key-env", proxy.APIKeyEnv,
+		"Environment variable that overrides the API key of the custom proxy when it is set.")
+
+	fs.StringVar(&proxy.Api, "model.providers.custom-proxy.api", proxy.Api, ""+
+		"API of the models of the custom proxy that do not set their own, one of: "+strings.Join(knownModelAPIs, ", ")+".")
+
+	fs.BoolVar(&proxy.AuthHeader, "model.providers.custom-proxy.auth-header", proxy.AuthHeader,
+		"Send the API key in the Authorization header.")
+
+	fs.StringToStringVar(&proxy.Headers, "model.providers.custom-proxy.headers", proxy.Headers,
+		"Extra headers sent to the custom proxy, like: X-Team=infra,X-Env=prod.")
+
+	// The flags set the snake_case keys of the section.
+	fs.VisitAll(func(flag *pflag.Flag) {
+		if key := strings.ReplaceAll(flag.Name, "-", "_"); key != flag.Name && strings.HasPrefix(key, "model.") {
+			cliflag.SetConfigKey(fs, flag.Name, key)
+		}
+	})
+}
+
+func isKnownModelAPI(api string) bool {
+	for _, known := range knownModelAPIs {
+		if api == known {
+			return true
+		}
+	}
+
+	return false
 }
@@ -0,0 +1,163 @@
+package options
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kiosk404/eidolon/internal/pkg/server"
+	"github.com/kiosk404/eidolon/pkg/utils/certutil"
+	"github.com/spf13/pflag"
+)
+
+// SecureServingOptions contains configuration items related to HTTPS server startup.
+// The same certificate is used by the gRPC server, which then requires golems to
+// present a client certificate signed by ClientCAFile.
+type SecureServingOptions struct {
+	BindAddress string `json:"bind-address" mapstructure:"bind-address"`
+	BindPort    int    `json:"bind-port"    mapstructure:"bind-port"`
+	// ServerCert is the TLS cert info for serving secure traffic
+	ServerCert GeneratableKeyCert `json:"tls"          mapstructure:"tls"`
+}
+
+// CertKey contains configuration items related to certificate.
+type CertKey struct {
+	// CertFile is a file containing a PEM-encoded certificate, and possibly the complete certificate chain
+	CertFile string `json:"cert-file"        mapstructure:"cert-file"`
+	// KeyFile is a file containing a PEM-encoded private key for the certificate specified by CertFile
+	KeyFile string `json:"private-key-file" mapstructure:"private-key-file"`
+}
+
+// GeneratableKeyCert contains configuration items related to certificate.
+type GeneratableKeyCert struct {
+	// CertKey allows setting an explicit cert/key file to use.
+	CertKey CertKey `json:"cert-key" mapstructure:"cert-key"`
+
+	// ClientCAFile is a PEM bundle of the CAs used to verify client certificates.
+	// Leave it empty to disable mutual TLS.
+	ClientCAFile string `json:"client-ca-file" mapstructure:"client-ca-file"`
+
+	// ClientCAKeyFile is the private key of ClientCAFile. When set, the hivemind
+	// issues client certificates to golems joining with a bootstrap token.
+	ClientCAKeyFile string `json:"client-ca-key-file" mapstructure:"client-ca-key-file"`
+
+	// MinVersion is the minimum TLS version accepted, either 1.2 or 1.3.
+	MinVersion string `json:"min-version" mapstructure:"min-version"`
+}
+
+// NewSecureServingOptions creates a SecureServingOptions object with default parameters.
+func NewSecureServingOptions() *SecureServingOptions {
+	return &SecureServingOptions{
+		BindAddress: "0.0.0.0",
+		BindPort:    11790,
+		ServerCert: GeneratableKeyCert{
+			MinVersion: "1.2",
+		},
+	}
+}
+
+// Enabled reports whether a certificate is configured, which turns secure serving on.
+func (s *SecureServingOptions) Enabled() bool {
+	return s.ServerCert.CertKey.CertFile != "" || s.ServerCert.CertKey.KeyFile != ""
+}
+
+// ApplyTo applies the run options to the method receiver and returns self.
+func (s *SecureServingOptions) ApplyTo(c *server.Config) error {
+	if !s.Enabled() {
+		return nil
+	}
+
+	minVersion, err := certutil.ParseTLSVersion(s.ServerCert.MinVersion)
+	if err != nil {
+		return err
+	}
+
+	// SecureServing is required to serve https
+	c.SecureServing = &server.SecureServingInfo{
+		BindAddress:  s.BindAddress,
+		BindPort:     s.BindPort,
+		CertFile:     s.ServerCert.CertKey.CertFile,
+		KeyFile:      s.ServerCert.CertKey.KeyFile,
+		ClientCAFile: s.ServerCert.ClientCAFile,
+		MinVersion:   minVersion,
+	}
+
+	return nil
+}
+
+// Validate is used to parse and validate the parameters entered by the user at
+// the command line when the program starts.
+func (s *SecureServingOptions) Validate() []error {
+	if s == nil || !s.Enabled() {
+		return nil
+	}
+
+	errors := []error{}
+
+	if s.BindPort < 1 || s.BindPort > 65535 {
+		errors = append(
+			errors,
+			fmt.Errorf(
+				"--secure.bind-port %v must be between 1 and 65535, inclusive. It cannot be turned off with 0",
+				s.BindPort,
+			),
+		)
+	}
+
+	certKey := s.ServerCert.CertKey
+	if certKey.CertFile == "" || certKey.KeyFile == "" {
+		errors = append(errors, fmt.Errorf(
+			"--secure.tls.cert-key.cert-file and --secure.tls.cert-key.private-key-file must be set together"))
+	}
+	if s.ServerCert.ClientCAKeyFile != "" && s.ServerCert.ClientCAFile == "" {
+		errors = append(errors, fmt.Errorf(
+			"--secure.tls.client-ca-key-file requires --secure.tls.client-ca-file"))
+	}
+	for _, f := range []string{
+		certKey.CertFile, certKey.KeyFile, s.ServerCert.ClientCAFile, s.ServerCert.ClientCAKeyFile,
+	} {
+		if f == "" {
+			continue
+		}
+		if _, err := os.Stat(f); err != nil {
+			errors = append(errors, fmt.Errorf("tls file %s: %w", f, err))
+		}
+	}
+
+	if _, err := certutil.ParseTLSVersion(s.ServerCert.MinVersion); err != nil {
+		errors = append(errors, fmt.Errorf("--secure.tls.min-version: %w", err))
+	}
+
+	return errors
+}
+
+// AddFlags adds flags related to HTTPS server for a specific APIServer to the
+// specified FlagSet.
+func (s *SecureServingOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&s.BindAddress, "secure.bind-address", s.BindAddress, ""+
+		"The IP address on which to listen for the --secure.bind-port port. The "+
+		"associated interface(s) must be reachable by the rest of the engine, and by CLI/web "+
+		"clients. If blank, all interfaces will be used (0.0.0.0 for all IPv4 interfaces and :: for all IPv6 interfaces).")
+	fs.IntVar(&s.BindPort, "secure.bind-port", s.BindPort, ""+
+		"The port on which to serve HTTPS with authentication and authorization. "+
+		"HTTPS is only served when --secure.tls.cert-key.cert-file is set.")
+
+	fs.StringVar(&s.ServerCert.CertKey.CertFile, "secure.tls.cert-key.cert-file", s.ServerCert.CertKey.CertFile, ""+
+		"File containing the default x509 Certificate for HTTPS and gRPC. (CA cert, if any, concatenated "+
+		"after server cert). Secure serving is disabled while it is empty.")
+
+	fs.StringVar(&s.ServerCert.CertKey.KeyFile, "secure.tls.cert-key.private-key-file",
+		s.ServerCert.CertKey.KeyFile, ""+
+			"File containing the default x509 private key matching --secure.tls.cert-key.cert-file.")
+
+	fs.StringVar(&s.ServerCert.ClientCAFile, "secure.tls.client-ca-file", s.ServerCert.ClientCAFile, ""+
+		"If set, any request presenting a client certificate signed by one of the authorities in "+
+		"the client-ca-file is authenticated with an identity corresponding to the CommonName of "+
+		"the client certificate. The gRPC server then rejects golems without such a certificate.")
+
+	fs.StringVar(&s.ServerCert.ClientCAKeyFile, "secure.tls.client-ca-key-file", s.ServerCert.ClientCAKeyFile, ""+
+		"Private key of --secure.tls.client-ca-file. If set, golems joining with a bootstrap token "+
+		"receive a client certificate signed by it.")
+
+	fs.StringVar(&s.ServerCert.MinVersion, "secure.tls.min-version", s.ServerCert.MinVersion,
+		"Minimum TLS version supported. Possible values: 1.2, 1.3.")
+}
@@ -0,0 +1,68 @@
+package options
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// SkillRegistryOptions contains configuration items related to the skill
+// packages the hivemind hosts and installs on golems on demand.
+type SkillRegistryOptions struct {
+	// Dir is the directory the packages are stored in; empty disables the registry.
+	Dir string `json:"dir"              mapstructure:"dir"`
+
+	// MaxPackageSize bounds the size of an uploaded package archive in bytes.
+	MaxPackageSize int64 `json:"max-package-size" mapstructure:"max-package-size"`
+
+	// InstallTimeout bounds the time a golem is given to install the skills a
+	// task requires before another node is tried.
+	InstallTimeout time.Duration `json:"install-timeout"  mapstructure:"install-timeout"`
+}
+
+// NewSkillRegistryOptions creates a SkillRegistryOptions object with default parameters.
+func NewSkillRegistryOptions() *SkillRegistryOptions {
+	return &SkillRegistryOptions{
+		Dir:            "",
+		MaxPackageSize: 64 << 20,
+		InstallTimeout: 5 * time.Minute,
+	}
+}
+
+// Enabled reports whether the skill registry is configured.
+func (o *SkillRegistryOptions) Enabled() bool {
+	return o.Dir != ""
+}
+
+// Validate is used to parse and validate the parameters entered by the user at
+// the command line when the program starts.
+func (o *SkillRegistryOptions) Validate() []error {
+	var errors []error
+
+	if o.MaxPackageSize <= 0 {
+		errors = append(errors, fmt.Errorf("--skill-registry.max-package-size %v must be positive", o.MaxPackageSize))
+	}
+	if o.InstallTimeout <= 0 {
+		errors = append(errors, fmt.Errorf("--skill-registry.install-timeout %v must be positive", o.InstallTimeout))
+	}
+
+	return errors
+}
+
+// AddFlags adds flags related to the skill registry to the specified FlagSet.
+func (o *SkillRegistryOptions) AddFlags(fs *pflag.FlagSet) {
+	if fs == nil {
+		return
+	}
+
+	fs.StringVar(&o.Dir, "skill-registry.dir", o.Dir, ""+
+		"Directory in which uploaded skill packages are stored. Tasks can ask the hivemind to install "+
+		"their missing skills from it on a golem before they run. Leave empty to disable the registry.")
+
+	fs.Int64Var(&o.MaxPackageSize, "skill-registry.max-package-size", o.MaxPackageSize,
+		"Maximum size in bytes of an uploaded skill package archive.")
+
+	fs.DurationVar(&o.InstallTimeout, "skill-registry.install-timeout", o.InstallTimeout,
+		"Time a golem is given to install the skills of a task before the hivemind tries another node.")
+}
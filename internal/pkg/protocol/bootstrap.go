@@ -0,0 +1,106 @@
+package protocol
+
+import (
+	"crypto/rand"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// bootstrapTokenIDLength and bootstrapTokenSecretLength are the lengths of
+	// the two halves of a bootstrap token.
+	bootstrapTokenIDLength     = 6
+	bootstrapTokenSecretLength = 16
+
+	bootstrapTokenChars = "abcdefghijklmnopqrstuvwxyz0123456789"
+)
+
+// bootstrapTokenRegexp matches a bootstrap token, like: 07401b.f395accd246ae52d.
+var bootstrapTokenRegexp = regexp.MustCompile(`^([a-z0-9]{6})\.([a-z0-9]{16})$`)
+
+// BootstrapToken is the one-time secret a golem presents to join the hivemind.
+// The ID is public and identifies the token; the secret proves possession.
+type BootstrapToken struct {
+	ID     string
+	Secret string
+}
+
+// String returns the token in its "<id>.<secret>" wire form.
+func (t BootstrapToken) String() string {
+	return t.ID + "." + t.Secret
+}
+
+// ParseBootstrapToken splits a token of the form "<id>.<secret>" where the id
+// has 6 and the secret 16 lower case alphanumeric characters.
+func ParseBootstrapToken(s string) (BootstrapToken, error) {
+	m := bootstrapTokenRegexp.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return BootstrapToken{}, fmt.Errorf("token must be of the form %q", "[a-z0-9]{6}.[a-z0-9]{16}")
+	}
+
+	return BootstrapToken{ID: m[1], Secret: m[2]}, nil
+}
+
+// NewBootstrapToken generates a random bootstrap token.
+func NewBootstrapToken() (BootstrapToken, error) {
+	id, err := randomString(bootstrapTokenIDLength)
+	if err != nil {
+		return BootstrapToken{}, err
+	}
+	secret, err := randomString(bootstrapTokenSecretLength)
+	if err != nil {
+		return BootstrapToken{}, err
+	}
+
+	return BootstrapToken{ID: id, Secret: secret}, nil
+}
+
+func randomString(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	for i, b := range buf {
+		// 256 is not a multiple of 36, the slight bias is irrelevant for tokens.
+		buf[i] = bootstrapTokenChars[int(b)%len(bootstrapTokenChars)]
+	}
+
+	return string(buf), nil
+}
+
+// JoinRequest is sent by a golem to exchange a bootstrap token for its identity.
+type JoinRequest struct {
+	// Token is the bootstrap token in "<id>.<secret>" form.
+	Token string `json:"token"`
+
+	// NodeInfo describes the joining node. Its ID is granted when it is the
+	// one derived from the ed25519 identity key that signed CSR, the hivemind
+	// assigns one otherwise.
+	NodeInfo NodeInfo `json:"node_info"`
+
+	// InstalledSkills are the skills found in the skills directory of the node.
+	InstalledSkills []SkillInfo `json:"installed_skills,omitempty"`
+
+	// CSR is a PEM encoded certificate request whose public key is certified
+	// as the node's client certificate. Nodes with an identity sign it with
+	// their identity key, binding the certificate to their node ID.
+	CSR []byte `json:"csr,omitempty"`
+}
+
+// JoinResponse carries the identity the hivemind issued to a joined golem.
+type JoinResponse struct {
+	// NodeID is the identifier the golem must use from now on.
+	NodeID string `json:"node_id"`
+
+	// Certificate is the PEM encoded client certificate for CSR. A hivemind
+	// without a CA to sign with refuses joins.
+	Certificate []byte `json:"certificate,omitempty"`
+
+	// CACertificate is the PEM encoded CA that signed Certificate, which the
+	// golem also uses to verify the hivemind.
+	CACertificate []byte `json:"ca_certificate,omitempty"`
+
+	// Tags are the labels attached to the node from the token.
+	Tags map[string]string `json:"tags,omitempty"`
+}
@@ -0,0 +1,91 @@
+package protocol
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// NodeStatus describes the connectivity state of a Golem node.
+type NodeStatus string
+
+const (
+	// NodeStatusOnline means the node is heartbeating and can receive tasks.
+	NodeStatusOnline NodeStatus = "online"
+
+	// NodeStatusOffline means the node missed its heartbeats.
+	NodeStatusOffline NodeStatus = "offline"
+)
+
+// NodeIDFromKey derives a node ID from the public key of a node identity, like:
+// golem-5f1c0a9b3e2d4c17. The ID stays the same for as long as the key does,
+// and the hivemind only grants it to a node proving it holds the key.
+func NodeIDFromKey(pub ed25519.PublicKey) string {
+	sum := sha256.Sum256(pub)
+
+	return "golem-" + hex.EncodeToString(sum[:8])
+}
+
+// Capability is a named ability advertised by a Golem node.
+type Capability struct {
+	Name        string `json:"name"                  yaml:"name"`
+	Version     string `json:"version,omitempty"     yaml:"version,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// SkillInfo describes a skill installed on a Golem node.
+type SkillInfo struct {
+	ID           string   `json:"id"                     yaml:"id"`
+	Name         string   `json:"name"                   yaml:"name"`
+	Version      string   `json:"version"                yaml:"version"`
+	Capabilities []string `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
+}
+
+// SystemInfo is the static hardware description of a Golem node.
+type SystemInfo struct {
+	OS         string `json:"os"           yaml:"os"`
+	Arch       string `json:"arch"         yaml:"arch"`
+	Hostname   string `json:"hostname"     yaml:"hostname"`
+	CPUCores   int    `json:"cpu_cores"    yaml:"cpuCores"`
+	MemoryMB   uint64 `json:"memory_mb"    yaml:"memoryMB"`
+	DiskFreeMB uint64 `json:"disk_free_mb" yaml:"diskFreeMB"`
+}
+
+// NodeInfo is the registration data a Golem node reports to the hivemind.
+type NodeInfo struct {
+	ID           string       `json:"id"                     yaml:"id"`
+	Name         string       `json:"name"                   yaml:"name"`
+	Address      string       `json:"address,omitempty"      yaml:"address,omitempty"`
+	Version      string       `json:"version,omitempty"      yaml:"version,omitempty"`
+	Status       NodeStatus   `json:"status"                 yaml:"status"`
+	Capabilities []Capability `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
+	SystemInfo   SystemInfo   `json:"system_info"            yaml:"systemInfo"`
+	RegisteredAt time.Time    `json:"registered_at"          yaml:"registeredAt"`
+}
+
+// NodeLoadInfo is the dynamic load reported in every Golem heartbeat.
+type NodeLoadInfo struct {
+	CPUPercent    float64   `json:"cpu_percent"    yaml:"cpuPercent"`
+	MemoryPercent float64   `json:"memory_percent" yaml:"memoryPercent"`
+	ActiveTasks   int       `json:"active_tasks"   yaml:"activeTasks"`
+	QueuedTasks   int       `json:"queued_tasks"   yaml:"queuedTasks"`
+	ReportedAt    time.Time `json:"reported_at"    yaml:"reportedAt"`
+}
+
+// Heartbeat is the periodic report of a Golem node.
+type Heartbeat struct {
+	NodeID string       `json:"node_id" yaml:"nodeID"`
+	Load   NodeLoadInfo `json:"load"    yaml:"load"`
+
+	// InstalledSkills replaces the skills the hivemind knows for the node. It
+	// is nil when they did not change since the previous heartbeat, and empty
+	// when the last skill was removed, hence no omitempty.
+	InstalledSkills []SkillInfo `json:"installed_skills" yaml:"installedSkills"`
+}
+
+// HeartbeatResponse carries the tasks the hivemind queued for a Golem since its
+// previous heartbeat.
+type HeartbeatResponse struct {
+	Tasks []*Task `json:"tasks,omitempty" yaml:"tasks,omitempty"`
+}
@@ -0,0 +1,208 @@
+package protocol
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+// JSONCodecName is the gRPC content subtype of the messages in this package.
+// The hivemind services exchange the plain Go structs of this package encoded
+// as JSON instead of protobuf messages.
+const JSONCodecName = "json"
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) { return json.Marshal(v) }
+
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+func (jsonCodec) Name() string { return JSONCodecName }
+
+// BootstrapJoinFullMethodName is the full gRPC method name of Bootstrap.Join.
+const BootstrapJoinFullMethodName = "/eidolon.hivemind.v1.Bootstrap/Join"
+
+// BootstrapServer is the server API for the Bootstrap service, which lets
+// golems without a client certificate join the hivemind.
+type BootstrapServer interface {
+	Join(ctx context.Context, req *JoinRequest) (*JoinResponse, error)
+}
+
+// RegisterBootstrapServer registers srv on s.
+func RegisterBootstrapServer(s grpc.ServiceRegistrar, srv BootstrapServer) {
+	s.RegisterService(&bootstrapServiceDesc, srv)
+}
+
+func bootstrapJoinHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(JoinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BootstrapServer).Join(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BootstrapJoinFullMethodName,
+	}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(BootstrapServer).Join(ctx, req.(*JoinRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+var bootstrapServiceDesc = grpc.ServiceDesc{
+	ServiceName: "eidolon.hivemind.v1.Bootstrap",
+	HandlerType: (*BootstrapServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Join",
+			Handler:    bootstrapJoinHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+// BootstrapClient is the client API for the Bootstrap service.
+type BootstrapClient interface {
+	Join(ctx context.Context, req *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error)
+}
+
+type bootstrapClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewBootstrapClient creates a BootstrapClient on cc.
+func NewBootstrapClient(cc grpc.ClientConnInterface) BootstrapClient {
+	return &bootstrapClient{cc}
+}
+
+func (c *bootstrapClient) Join(ctx context.Context, req *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error) {
+	out := new(JoinResponse)
+	opts = append([]grpc.CallOption{grpc.CallContentSubtype(JSONCodecName)}, opts...)
+	if err := c.cc.Invoke(ctx, BootstrapJoinFullMethodName, req, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// NodeServicePrefix prefixes the full gRPC method names of the Node service.
+const NodeServicePrefix = "/eidolon.hivemind.v1.Node/"
+
+// The full gRPC method names of the Node service.
+const (
+	NodeHeartbeatFullMethodName    = NodeServicePrefix + "Heartbeat"
+	NodeReportResultFullMethodName = NodeServicePrefix + "ReportResult"
+)
+
+// NodeServer is the server API for the Node service, which joined golems call
+// with their client certificate.
+type NodeServer interface {
+	// Heartbeat records the report of a golem and returns the tasks queued for it.
+	Heartbeat(ctx context.Context, req *Heartbeat) (*HeartbeatResponse, error)
+
+	// ReportResult records the outcome of a task the golem ran.
+	ReportResult(ctx context.Context, req *TaskResult) (*ReportResultResponse, error)
+}
+
+// RegisterNodeServer registers srv on s.
+func RegisterNodeServer(s grpc.ServiceRegistrar, srv NodeServer) {
+	s.RegisterService(&nodeServiceDesc, srv)
+}
+
+func nodeHeartbeatHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(Heartbeat)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeHeartbeatFullMethodName,
+	}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(NodeServer).Heartbeat(ctx, req.(*Heartbeat))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+func nodeReportResultHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(TaskResult)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).ReportResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeReportResultFullMethodName,
+	}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(NodeServer).ReportResult(ctx, req.(*TaskResult))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+var nodeServiceDesc = grpc.ServiceDesc{
+	ServiceName: "eidolon.hivemind.v1.Node",
+	HandlerType: (*NodeServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Heartbeat",
+			Handler:    nodeHeartbeatHandler,
+		},
+		{
+			MethodName: "ReportResult",
+			Handler:    nodeReportResultHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+// NodeClient is the client API for the Node service.
+type NodeClient interface {
+	Heartbeat(ctx context.Context, req *Heartbeat, opts ...grpc.CallOption) (*HeartbeatResponse, error)
+	ReportResult(ctx context.Context, req *TaskResult, opts ...grpc.CallOption) (*ReportResultResponse, error)
+}
+
+type nodeClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewNodeClient creates a NodeClient on cc.
+func NewNodeClient(cc grpc.ClientConnInterface) NodeClient {
+	return &nodeClient{cc}
+}
+
+func (c *nodeClient) Heartbeat(ctx context.Context, req *Heartbeat, opts ...grpc.CallOption) (*HeartbeatResponse, error) {
+	out := new(HeartbeatResponse)
+	opts = append([]grpc.CallOption{grpc.CallContentSubtype(JSONCodecName)}, opts...)
+	if err := c.cc.Invoke(ctx, NodeHeartbeatFullMethodName, req, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (c *nodeClient) ReportResult(ctx context.Context, req *TaskResult, opts ...grpc.CallOption) (*ReportResultResponse, error) {
+	out := new(ReportResultResponse)
+	opts = append([]grpc.CallOption{grpc.CallContentSubtype(JSONCodecName)}, opts...)
+	if err := c.cc.Invoke(ctx, NodeReportResultFullMethodName, req, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
@@ -0,0 +1,67 @@
+package protocol
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TaskTypeInstallSkills is the type of the tasks the hivemind sends a Golem
+// to make it install skill packages from the skill registry before it is
+// given a task that requires them. The Golem handles them itself instead of
+// passing them to an executor.
+const TaskTypeInstallSkills = "eidolon.install-skills"
+
+// SkillPackage locates a package of the skill registry.
+type SkillPackage struct {
+	ID      string `json:"id"      yaml:"id"`
+	Version string `json:"version" yaml:"version"`
+
+	// Digest is the digest of the package archive, like: sha256:9f86d0...
+	Digest string `json:"digest" yaml:"digest"`
+
+	// Size is the size of the archive in bytes.
+	Size int64 `json:"size" yaml:"size"`
+
+	// Path is the path of the archive on the hivemind API, like:
+	// /api/v1/skills/web-search/versions/1.2.0/package.
+	Path string `json:"path" yaml:"path"`
+
+	// Constraints are the version constraints the package was resolved for,
+	// like: >=1.2.0,<2.0.0. A node keeps its active version of the skill when
+	// it satisfies them.
+	Constraints string `json:"constraints,omitempty" yaml:"constraints,omitempty"`
+}
+
+// InstallSkillsPayload is the payload of a TaskTypeInstallSkills task.
+type InstallSkillsPayload struct {
+	Packages []SkillPackage `json:"packages"`
+}
+
+// Payload returns p as a task payload, made of the plain values JSON decodes
+// to so that every transport can encode it.
+func (p *InstallSkillsPayload) Payload() map[string]interface{} {
+	payload := map[string]interface{}{}
+	if data, err := json.Marshal(p); err == nil {
+		_ = json.Unmarshal(data, &payload)
+	}
+
+	return payload
+}
+
+// ParseInstallSkillsPayload decodes the payload of a TaskTypeInstallSkills task.
+func ParseInstallSkillsPayload(payload map[string]interface{}) (*InstallSkillsPayload, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &InstallSkillsPayload{}
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("invalid %s payload: %w", TaskTypeInstallSkills, err)
+	}
+	if len(p.Packages) == 0 {
+		return nil, fmt.Errorf("invalid %s payload: no packages", TaskTypeInstallSkills)
+	}
+
+	return p, nil
+}
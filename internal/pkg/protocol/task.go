@@ -0,0 +1,106 @@
+package protocol
+
+import (
+	"time"
+)
+
+// TaskStatus describes where a task is in its lifecycle.
+type TaskStatus string
+
+const (
+	// TaskStatusPending means the task is waiting in the scheduling queue.
+	TaskStatusPending TaskStatus = "pending"
+
+	// TaskStatusAssigned means the task has been dispatched to a Golem node.
+	TaskStatusAssigned TaskStatus = "assigned"
+
+	// TaskStatusRunning means the Golem node has started executing the task.
+	TaskStatusRunning TaskStatus = "running"
+
+	// TaskStatusCompleted means the task finished successfully.
+	TaskStatusCompleted TaskStatus = "completed"
+
+	// TaskStatusFailed means the task finished with an error.
+	TaskStatusFailed TaskStatus = "failed"
+
+	// TaskStatusCancelled means the task was aborted by a caller.
+	TaskStatusCancelled TaskStatus = "cancelled"
+
+	// TaskStatusTimedOut means the task exceeded its timeout.
+	TaskStatusTimedOut TaskStatus = "timed_out"
+)
+
+// IsTerminal reports whether the status is a final state.
+func (s TaskStatus) IsTerminal() bool {
+	switch s {
+	case TaskStatusCompleted, TaskStatusFailed, TaskStatusCancelled, TaskStatusTimedOut:
+		return true
+	}
+	return false
+}
+
+// TaskPriority orders tasks in the scheduling queue (higher is more urgent).
+type TaskPriority int
+
+const (
+	TaskPriorityLow      TaskPriority = 0
+	TaskPriorityNormal   TaskPriority = 1
+	TaskPriorityHigh     TaskPriority = 2
+	TaskPriorityCritical TaskPriority = 3
+)
+
+// Task is the unit of work exchanged between the hivemind and Golem nodes.
+type Task struct {
+	// ID uniquely identifies the task.
+	ID string `json:"id" yaml:"id"`
+
+	// Name is a short human-readable label.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	// Type tells the Golem which executor should run the task.
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+
+	// Payload is the executor-specific input.
+	Payload map[string]interface{} `json:"payload,omitempty" yaml:"payload,omitempty"`
+
+	// Priority orders the task in the scheduling queue.
+	Priority TaskPriority `json:"priority" yaml:"priority"`
+
+	// Status is the current lifecycle state.
+	Status TaskStatus `json:"status" yaml:"status"`
+
+	// Timeout bounds the execution time; 0 means the scheduler default.
+	Timeout time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+
+	// AssignedNodeID is the Golem node the task was dispatched to.
+	AssignedNodeID string `json:"assigned_node_id,omitempty" yaml:"assignedNodeID,omitempty"`
+
+	// Metadata carries arbitrary caller-defined labels.
+	Metadata map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+
+	CreatedAt   time.Time  `json:"created_at"             yaml:"createdAt"`
+	StartedAt   *time.Time `json:"started_at,omitempty"   yaml:"startedAt,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" yaml:"completedAt,omitempty"`
+}
+
+// TaskProgress is an incremental progress report sent by a Golem while a task runs.
+type TaskProgress struct {
+	TaskID    string    `json:"task_id"           yaml:"taskID"`
+	NodeID    string    `json:"node_id"           yaml:"nodeID"`
+	Percent   float64   `json:"percent"           yaml:"percent"`
+	Message   string    `json:"message,omitempty" yaml:"message,omitempty"`
+	Timestamp time.Time `json:"timestamp"         yaml:"timestamp"`
+}
+
+// TaskResult is the final outcome of a task reported by a Golem.
+type TaskResult struct {
+	TaskID      string                 `json:"task_id"          yaml:"taskID"`
+	NodeID      string                 `json:"node_id"          yaml:"nodeID"`
+	Success     bool                   `json:"success"          yaml:"success"`
+	Output      map[string]interface{} `json:"output,omitempty" yaml:"output,omitempty"`
+	Error       string                 `json:"error,omitempty"  yaml:"error,omitempty"`
+	CompletedAt time.Time              `json:"completed_at"     yaml:"completedAt"`
+}
+
+// ReportResultResponse acknowledges a TaskResult.
+type ReportResultResponse struct{}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/kiosk404/eidolon/pkg/logger"
+	"github.com/kiosk404/eidolon/pkg/utils/certutil"
 	"github.com/kiosk404/eidolon/pkg/utils/homedir"
 	"github.com/spf13/viper"
 )
@@ -23,6 +24,7 @@ const (
 // Config is a structure used to configure a GenericAPIServer.
 // Its members are sorted roughly in order of importance for composers.
 type Config struct {
+	SecureServing   *SecureServingInfo
 	Serving         *ServingInfo
 	Mode            string
 	Middlewares     []string
@@ -31,7 +33,33 @@ type Config struct {
 	EnableMetrics   bool
 }
 
-// ServingInfo holds configuration
+// SecureServingInfo holds configuration of the TLS server.
+type SecureServingInfo struct {
+	BindAddress string
+	BindPort    int
+
+	// CertFile and KeyFile are reloaded whenever they change on disk.
+	CertFile string
+	KeyFile  string
+
+	// ClientCAFile enables mutual TLS when set.
+	ClientCAFile string
+
+	// MinVersion is one of the tls.VersionTLS* constants.
+	MinVersion uint16
+}
+
+// Address join host IP address and host port number into an address string, like: 0.0.0.0:11790.
+func (s *SecureServingInfo) Address() string {
+	return net.JoinHostPort(s.BindAddress, strconv.Itoa(s.BindPort))
+}
+
+// NewReloader loads the certificates of the secure server.
+func (s *SecureServingInfo) NewReloader() (*certutil.Reloader, error) {
+	return certutil.NewReloader(s.CertFile, s.KeyFile, s.ClientCAFile)
+}
+
+// ServingInfo holds configuration of the insecure http server.
 type ServingInfo struct {
 	BindAddress string
 	BindPort    int
@@ -51,7 +79,7 @@ func NewConfig() *Config {
 		Healthz:         true,
 		Mode:            gin.DebugMode,
 		Middlewares:     []string{},
-		EnableProfiling: true,
+		EnableProfiling: false,
 		EnableMetrics:   true,
 	}
 }
@@ -73,12 +101,13 @@ func (c CompletedConfig) New() (*GenericAPIServer, error) {
 	gin.SetMode(c.Mode)
 
 	s := &GenericAPIServer{
-		ServingInfo:     c.Serving,
-		healthz:         c.Healthz,
-		enableMetrics:   c.EnableMetrics,
-		enableProfiling: c.EnableProfiling,
-		middlewares:     c.Middlewares,
-		Engine:          gin.New(),
+		SecureServingInfo: c.SecureServing,
+		ServingInfo:       c.Serving,
+		healthz:           c.Healthz,
+		enableMetrics:     c.EnableMetrics,
+		enableProfiling:   c.EnableProfiling,
+		middlewares:       c.Middlewares,
+		Engine:            gin.New(),
 	}
 
 	initGenericAPIServer(s)
@@ -2,13 +2,18 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/gin-contrib/pprof"
 	"github.com/gin-gonic/gin"
 	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/internal/pkg/metrics"
+	"github.com/kiosk404/eidolon/internal/pkg/middleware"
 	"github.com/kiosk404/eidolon/pkg/logger"
+	"github.com/kiosk404/eidolon/pkg/utils/certutil"
 	"github.com/kiosk404/eidolon/pkg/version"
 )
 
@@ -16,7 +21,10 @@ import (
 // type GenericAPIServer gin.Engine.
 type GenericAPIServer struct {
 	middlewares []string
-	// ServingInfo holds configuration of the TLS server.
+	// SecureServingInfo holds configuration of the TLS server, nil when HTTPS is disabled.
+	SecureServingInfo *SecureServingInfo
+
+	// ServingInfo holds configuration of the insecure http server.
 	ServingInfo *ServingInfo
 
 	// ShutdownTimeout is the timeout used for server shutdown. This specifies the timeout before server
@@ -28,7 +36,10 @@ type GenericAPIServer struct {
 	enableMetrics   bool
 	enableProfiling bool
 
-	Server *http.Server
+	insecureServer, secureServer *http.Server
+
+	// stopReload stops watching the TLS certificates.
+	stopReload context.CancelFunc
 }
 
 func initGenericAPIServer(s *GenericAPIServer) {
@@ -49,7 +60,27 @@ func (s *GenericAPIServer) Setup() {
 
 // InstallMiddlewares installs middlewares to gin engine.
 func (s *GenericAPIServer) InstallMiddlewares() {
+	// install metric middleware before any route so that every request is measured
+	if s.enableMetrics {
+		s.Use(metrics.HTTPMiddleware())
+	}
 
+	// install custom middlewares in the order they are configured
+	names := s.middlewares
+	if len(names) == 0 {
+		names = middleware.DefaultNames
+	}
+	for _, m := range names {
+		mw, ok := middleware.Middlewares[m]
+		if !ok {
+			logger.Warn("can not find middleware: %s", m)
+
+			continue
+		}
+
+		logger.Info("install middleware: %s", m)
+		s.Use(mw)
+	}
 }
 
 func (s *GenericAPIServer) InstallAPIs() {
@@ -60,6 +91,11 @@ func (s *GenericAPIServer) InstallAPIs() {
 		})
 	}
 
+	// install metric handler
+	if s.enableMetrics {
+		s.GET("/metrics", gin.WrapH(metrics.Handler()))
+	}
+
 	// install pprof handler
 	if s.enableProfiling {
 		pprof.Register(s.Engine)
@@ -70,6 +106,72 @@ func (s *GenericAPIServer) InstallAPIs() {
 	})
 }
 
+// Run spawns the http servers. It only returns when a port cannot be listened on initially
+// or the servers are closed.
+func (s *GenericAPIServer) Run() error {
+	s.insecureServer = &http.Server{
+		Addr:    s.ServingInfo.Address(),
+		Handler: s,
+	}
+
+	errCh := make(chan error, 2)
+	running := 1
+	go func() {
+		logger.Info("Start to listening the incoming requests on http address: %s", s.ServingInfo.Address())
+		errCh <- serve(s.insecureServer, s.insecureServer.ListenAndServe)
+	}()
+
+	if s.SecureServingInfo != nil {
+		reloader, err := s.SecureServingInfo.NewReloader()
+		if err != nil {
+			s.Close()
+
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		s.stopReload = cancel
+		go func() {
+			if err := reloader.Run(ctx); err != nil {
+				logger.Error("Watch TLS certificates failed, they will not be reloaded: %s", err.Error())
+			}
+		}()
+
+		s.secureServer = &http.Server{
+			Addr:      s.SecureServingInfo.Address(),
+			Handler:   s,
+			TLSConfig: certutil.ServerConfig(reloader, s.SecureServingInfo.MinVersion, tls.RequireAndVerifyClientCert),
+		}
+		running++
+		go func() {
+			logger.Info("Start to listening the incoming requests on https address: %s", s.SecureServingInfo.Address())
+			// The certificate is served by TLSConfig.GetCertificate.
+			errCh <- serve(s.secureServer, func() error { return s.secureServer.ListenAndServeTLS("", "") })
+		}()
+	}
+
+	var lastErr error
+	for ; running > 0; running-- {
+		if err := <-errCh; err != nil && lastErr == nil {
+			// Take the other server down with the failed one.
+			lastErr = err
+			s.Close()
+		}
+	}
+
+	return lastErr
+}
+
+func serve(srv *http.Server, listen func() error) error {
+	if err := listen(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	logger.Info("Server on %s stopped", srv.Addr)
+
+	return nil
+}
+
 // Close graceful shutdown the api server.
 func (s *GenericAPIServer) Close() {
 	// The context is used to inform the server it has 10 seconds to finish
@@ -77,7 +179,19 @@ func (s *GenericAPIServer) Close() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	if err := s.Server.Shutdown(ctx); err != nil {
-		logger.Warn("Shutdown secure server failed: %s", err.Error())
+	if s.stopReload != nil {
+		s.stopReload()
+	}
+
+	if s.secureServer != nil {
+		if err := s.secureServer.Shutdown(ctx); err != nil {
+			logger.Warn("Shutdown secure server failed: %s", err.Error())
+		}
+	}
+
+	if s.insecureServer != nil {
+		if err := s.insecureServer.Shutdown(ctx); err != nil {
+			logger.Warn("Shutdown insecure server failed: %s", err.Error())
+		}
 	}
 }
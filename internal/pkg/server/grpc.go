@@ -1,19 +1,31 @@
 package server
 
 import (
+	"context"
 	"net"
 
 	"github.com/kiosk404/eidolon/pkg/logger"
+	"github.com/kiosk404/eidolon/pkg/utils/certutil"
 	"google.golang.org/grpc"
 )
 
 type GRPCAPIServer struct {
 	*grpc.Server
 	address string
+
+	// reloader keeps the TLS certificates of a secure server up to date.
+	reloader   *certutil.Reloader
+	stopReload context.CancelFunc
 }
 
 func NewGRPCAPIServer(srv *grpc.Server, address string) *GRPCAPIServer {
-	return &GRPCAPIServer{srv, address}
+	return &GRPCAPIServer{Server: srv, address: address}
+}
+
+// NewSecureGRPCAPIServer creates a GRPCAPIServer whose TLS certificates, loaded by
+// reloader, are reloaded while the server runs.
+func NewSecureGRPCAPIServer(srv *grpc.Server, address string, reloader *certutil.Reloader) *GRPCAPIServer {
+	return &GRPCAPIServer{Server: srv, address: address, reloader: reloader}
 }
 
 func (s *GRPCAPIServer) Run() {
@@ -22,16 +34,29 @@ func (s *GRPCAPIServer) Run() {
 		logger.Fatal("failed to listen: %s", err.Error())
 	}
 
+	if s.reloader != nil {
+		var ctx context.Context
+		ctx, s.stopReload = context.WithCancel(context.Background())
+		go func() {
+			if err := s.reloader.Run(ctx); err != nil {
+				logger.Error("watch grpc TLS certificates failed, they will not be reloaded: %s", err.Error())
+			}
+		}()
+	}
+
 	go func() {
 		if err := s.Serve(listen); err != nil {
 			logger.Fatal("failed to start grpc server: %s", err.Error())
 		}
 	}()
 
-	logger.Info("start grpc server at %s", s.address)
+	logger.Info("start grpc server at %s, tls: %t", s.address, s.reloader != nil)
 }
 
 func (s *GRPCAPIServer) Close() {
+	if s.stopReload != nil {
+		s.stopReload()
+	}
 	s.GracefulStop()
 	logger.Info("GRPC server on %s stopped", s.address)
 }
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"context"
+	"crypto/x509"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCAccess lists the gRPC method prefixes open to the callers that are not
+// admins, by a full method name prefix like: /eidolon.hivemind.v1.Node/.
+type GRPCAccess struct {
+	// Anonymous methods are callable without a client certificate.
+	Anonymous []string
+
+	// Node methods are callable with any verified client certificate, like
+	// the ones the golems are issued. The other methods require a certificate
+	// of the AdminOrganization.
+	Node []string
+}
+
+// PeerCommonName returns the CommonName of the verified client certificate
+// the caller presented, false if it presented none.
+func PeerCommonName(ctx context.Context) (string, bool) {
+	cert := peerCertificate(ctx)
+	if cert == nil {
+		return "", false
+	}
+
+	return cert.Subject.CommonName, true
+}
+
+// PeerIsAdmin reports whether the caller presented a verified client
+// certificate of the AdminOrganization.
+func PeerIsAdmin(ctx context.Context) bool {
+	cert := peerCertificate(ctx)
+
+	return cert != nil && slices.Contains(cert.Subject.Organization, AdminOrganization)
+}
+
+func peerCertificate(ctx context.Context) *x509.Certificate {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.AuthInfo == nil {
+		return nil
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+
+	return tlsInfo.State.VerifiedChains[0][0]
+}
+
+// ClientCertUnaryInterceptor rejects the calls the client certificate of the
+// caller does not give access to.
+func ClientCertUnaryInterceptor(access GRPCAccess) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := authorizePeer(ctx, info.FullMethod, access); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// ClientCertStreamInterceptor is the streaming counterpart of ClientCertUnaryInterceptor.
+func ClientCertStreamInterceptor(access GRPCAccess) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authorizePeer(ss.Context(), info.FullMethod, access); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+func authorizePeer(ctx context.Context, fullMethod string, access GRPCAccess) error {
+	if hasPrefix(fullMethod, access.Anonymous) {
+		return nil
+	}
+
+	cn, ok := PeerCommonName(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "a client certificate signed by the hivemind CA is required")
+	}
+	if !hasPrefix(fullMethod, access.Node) && !PeerIsAdmin(ctx) {
+		return status.Errorf(codes.PermissionDenied, "client certificate %q is not in organization %q", cn, AdminOrganization)
+	}
+
+	return nil
+}
+
+func hasPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
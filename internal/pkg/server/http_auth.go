@@ -0,0 +1,105 @@
+package server
+
+import (
+	"crypto/x509"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kiosk404/eidolon/internal/pkg/code"
+	"github.com/kiosk404/eidolon/internal/pkg/core"
+	"github.com/kiosk404/eidolon/pkg/errorx"
+	"github.com/kiosk404/eidolon/pkg/logger"
+)
+
+// AdminOrganization is the Organization a client certificate must carry to be
+// allowed on the management API. The certificates the hivemind issues to the
+// golems when they join only carry their node ID, so a joined node cannot
+// manage the hivemind.
+const AdminOrganization = "eidolon:admins"
+
+// RequestCommonName returns the CommonName of the verified client certificate
+// the request was made with, false if it was made without one.
+func RequestCommonName(r *http.Request) (string, bool) {
+	cert := requestCertificate(r)
+	if cert == nil {
+		return "", false
+	}
+
+	return cert.Subject.CommonName, true
+}
+
+// RequestIsAdmin reports whether the request was made with a verified client
+// certificate of the AdminOrganization.
+func RequestIsAdmin(r *http.Request) bool {
+	cert := requestCertificate(r)
+
+	return cert != nil && slices.Contains(cert.Subject.Organization, AdminOrganization)
+}
+
+func requestCertificate(r *http.Request) *x509.Certificate {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil
+	}
+
+	return r.TLS.VerifiedChains[0][0]
+}
+
+// Authenticate returns a middleware guarding the routes it is installed on.
+// When the secure server runs, the routes are not served by the insecure
+// server, and when it verifies client certificates, a verified certificate is
+// required. Without secure serving the routes are left open.
+func (s *GenericAPIServer) Authenticate() gin.HandlerFunc {
+	if s.SecureServingInfo == nil {
+		logger.Warn("Secure serving is disabled, the API is served without authentication")
+
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	verify := s.SecureServingInfo.ClientCAFile != ""
+	if !verify {
+		logger.Warn("No client CA is configured, the API is served over TLS without authentication")
+	}
+
+	return func(c *gin.Context) {
+		if c.Request.TLS == nil {
+			core.WriteResponse(c, errorx.WithCode(code.ErrUnauthenticated,
+				"the API is only served on the secure port %d", s.SecureServingInfo.BindPort), nil)
+			c.Abort()
+
+			return
+		}
+		if _, ok := RequestCommonName(c.Request); verify && !ok {
+			core.WriteResponse(c, errorx.WithCode(code.ErrUnauthenticated,
+				"a client certificate signed by the hivemind CA is required"), nil)
+			c.Abort()
+
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// AuthorizeAdmin returns a middleware restricting the routes it is installed
+// on, after Authenticate, to the client certificates of the
+// AdminOrganization. It lets every request through when client certificates
+// are not verified, as Authenticate does.
+func (s *GenericAPIServer) AuthorizeAdmin() gin.HandlerFunc {
+	if s.SecureServingInfo == nil || s.SecureServingInfo.ClientCAFile == "" {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		if !RequestIsAdmin(c.Request) {
+			cn, _ := RequestCommonName(c.Request)
+			core.WriteResponse(c, errorx.WithCode(code.ErrPermissionDenied,
+				"client certificate %q is not in organization %q", cn, AdminOrganization), nil)
+			c.Abort()
+
+			return
+		}
+
+		c.Next()
+	}
+}
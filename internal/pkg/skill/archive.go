@@ -0,0 +1,225 @@
+package skill
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// maxPackageSize bounds the unpacked size of a skill package.
+const maxPackageSize = 1 << 30
+
+// Extract unpacks a tar archive, gzip compressed or not, into dir. Entries
+// other than regular files and directories, and paths leaving dir, are
+// rejected.
+func Extract(r io.Reader, dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	} else {
+		r = br
+	}
+
+	var total int64
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("read archive: %w", err)
+		}
+
+		name := filepath.FromSlash(strings.TrimPrefix(hdr.Name, "./"))
+		if name == "" || name == "." {
+			continue
+		}
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("archive entry %q leaves the package", hdr.Name)
+		}
+		path := filepath.Join(dir, name)
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if total += hdr.Size; total > maxPackageSize {
+				return fmt.Errorf("archive is larger than %d bytes", maxPackageSize)
+			}
+			if err := writeEntry(path, tr, fs.FileMode(hdr.Mode)); err != nil {
+				return err
+			}
+		case tar.TypeXGlobalHeader:
+			continue
+		default:
+			return fmt.Errorf("archive entry %q: only regular files and directories are allowed in a skill package", hdr.Name)
+		}
+	}
+}
+
+func writeEntry(path string, r io.Reader, mode fs.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644|mode&0o111)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// FindRoot returns the directory of the package unpacked in dir: dir itself
+// when it holds the manifest, or its only sub directory, as archives created
+// from a directory usually have one.
+func FindRoot(dir string) (string, error) {
+	if _, err := os.Stat(filepath.Join(dir, ManifestFile)); err == nil {
+		return dir, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	var dirs []string
+	for _, e := range entries {
+		if e.IsDir() {
+			dirs = append(dirs, e.Name())
+		}
+	}
+	if len(dirs) == 1 {
+		root := filepath.Join(dir, dirs[0])
+		if _, err := os.Stat(filepath.Join(root, ManifestFile)); err == nil {
+			return root, nil
+		}
+	}
+
+	return "", fmt.Errorf("no %s found at the root of the package", ManifestFile)
+}
+
+// copyTree copies the regular files and directories of src into dst, which
+// must not exist. Version control directories are left out.
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		switch {
+		case d.IsDir() && rel != "." && isVCSDir(d.Name()):
+			return filepath.SkipDir
+		case d.IsDir():
+			return os.MkdirAll(target, 0o755)
+		case rel == InstallRecordFile:
+			return nil
+		case !d.Type().IsRegular():
+			return fmt.Errorf("%s: only regular files and directories are allowed in a skill package", rel)
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		return writeEntry(target, f, info.Mode())
+	})
+}
+
+// Archive writes the package in dir to w as a gzip compressed tar archive.
+// Version control directories and the install record are left out, and the
+// owners and times of the files are not recorded, so that archiving the same
+// files twice gives the same digest.
+func Archive(dir string, w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || rel == "." {
+			return err
+		}
+
+		switch {
+		case d.IsDir() && isVCSDir(d.Name()):
+			return filepath.SkipDir
+		case rel == InstallRecordFile:
+			return nil
+		case !d.IsDir() && !d.Type().IsRegular():
+			return fmt.Errorf("%s: only regular files and directories are allowed in a skill package", rel)
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		hdr := &tar.Header{
+			Name:    filepath.ToSlash(rel),
+			Mode:    int64(info.Mode().Perm()),
+			ModTime: time.Unix(0, 0),
+			Format:  tar.FormatPAX,
+		}
+		if d.IsDir() {
+			hdr.Typeflag = tar.TypeDir
+			hdr.Name += "/"
+			return tw.WriteHeader(hdr)
+		}
+		hdr.Typeflag = tar.TypeReg
+		hdr.Size = info.Size()
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return gz.Close()
+}
@@ -0,0 +1,150 @@
+package skill
+
+import (
+	"fmt"
+	"strings"
+
+	versionutil "github.com/kiosk404/eidolon/pkg/version/util"
+)
+
+// VersionConstraints are comma separated version constraints a skill version
+// must satisfy, like: >=1.2,<2.
+//
+// The operators are =, ==, !=, <, <=, > and >=. Missing minor and patch
+// numbers are zero, so <2 admits 1.9.3 but not 2.0.0.
+//
+// As with npm, a pre-release version like 2.0.0-rc.1 only satisfies the
+// constraints when one of them names a pre-release of the same major, minor
+// and patch numbers: >=1.2,<2 does not admit it, >=2.0.0-rc.0 does.
+type VersionConstraints []versionConstraint
+
+// versionConstraint is a single comparison, like: >=1.2.0.
+type versionConstraint struct {
+	op      string
+	version *versionutil.Version
+}
+
+// constraintOperators are the constraint operators, longest first so that >=
+// is not read as >.
+var constraintOperators = []string{">=", "<=", "==", "!=", ">", "<", "="}
+
+// ParseVersionConstraints parses version constraints, an empty string has
+// none.
+func ParseVersionConstraints(s string) (VersionConstraints, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var cs VersionConstraints
+	for _, part := range strings.Split(s, ",") {
+		c, err := parseVersionConstraint(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		cs = append(cs, c)
+	}
+
+	return cs, nil
+}
+
+func parseVersionConstraint(s string) (versionConstraint, error) {
+	for _, op := range constraintOperators {
+		if v, ok := strings.CutPrefix(s, op); ok {
+			version, err := parseLooseSemantic(strings.TrimSpace(v))
+			if err != nil {
+				return versionConstraint{}, err
+			}
+			return versionConstraint{op: op, version: version}, nil
+		}
+	}
+
+	return versionConstraint{}, fmt.Errorf("constraint %q must start with one of %s", s, strings.Join(constraintOperators, " "))
+}
+
+// parseLooseSemantic parses a semantic version whose minor and patch numbers
+// may be left out, like: 2 or 1.2.
+func parseLooseSemantic(s string) (*versionutil.Version, error) {
+	core, rest := s, ""
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		core, rest = s[:i], s[i:]
+	}
+	for n := strings.Count(core, "."); n < 2 && core != ""; n++ {
+		core += ".0"
+	}
+
+	return versionutil.ParseSemantic(core + rest)
+}
+
+// String returns the constraints with full versions, like: >=1.2.0,<2.0.0.
+func (cs VersionConstraints) String() string {
+	parts := make([]string, len(cs))
+	for i, c := range cs {
+		parts[i] = c.op + c.version.String()
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// Allows reports whether version satisfies every constraint. Unparsable
+// versions only satisfy empty constraints, and pre-release versions the ones
+// naming a pre-release of the same version.
+func (cs VersionConstraints) Allows(version string) bool {
+	if len(cs) == 0 {
+		return true
+	}
+	v, err := versionutil.ParseSemantic(version)
+	if err != nil {
+		return false
+	}
+	if v.PreRelease() != "" && !cs.namePreReleaseOf(v) {
+		return false
+	}
+
+	for _, c := range cs {
+		cmp := compareVersions(v, c.version)
+		var ok bool
+		switch c.op {
+		case "=", "==":
+			ok = cmp == 0
+		case "!=":
+			ok = cmp != 0
+		case "<":
+			ok = cmp < 0
+		case "<=":
+			ok = cmp <= 0
+		case ">":
+			ok = cmp > 0
+		case ">=":
+			ok = cmp >= 0
+		}
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// namePreReleaseOf reports whether a constraint names a pre-release of the
+// major, minor and patch numbers of v.
+func (cs VersionConstraints) namePreReleaseOf(v *versionutil.Version) bool {
+	for _, c := range cs {
+		if c.version.PreRelease() != "" && c.version.Major() == v.Major() &&
+			c.version.Minor() == v.Minor() && c.version.Patch() == v.Patch() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func compareVersions(a, b *versionutil.Version) int {
+	switch {
+	case a.LessThan(b):
+		return -1
+	case b.LessThan(a):
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,97 @@
+package skill
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DigestPrefix prefixes the hex encoded digests of packages.
+const DigestPrefix = "sha256:"
+
+// Digest returns the digest of the package in dir, like: sha256:9f86d0...
+// It covers the path, the executable bit and the content of every regular
+// file in lexical order, so that it does not depend on how the package was
+// copied. The install record and version control directories are left out.
+func Digest(dir string) (string, error) {
+	h := sha256.New()
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir() && rel != "." && isVCSDir(d.Name()):
+			return filepath.SkipDir
+		case d.IsDir():
+			return nil
+		case rel == InstallRecordFile:
+			return nil
+		case !d.Type().IsRegular():
+			return fmt.Errorf("%s: only regular files and directories are allowed in a skill package", rel)
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		fmt.Fprintf(h, "%s\x00%t\x00%d\x00", filepath.ToSlash(rel), info.Mode()&0o111 != 0, info.Size())
+		_, err = io.Copy(h, f)
+
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return DigestPrefix + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// FileDigest returns the digest of the file at path, like: sha256:9f86d0...
+func FileDigest(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return DigestPrefix + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// VerifyDigest compares a digest with the expected one, which may omit the
+// sha256: prefix.
+func VerifyDigest(got, want string) error {
+	if !strings.HasPrefix(want, DigestPrefix) {
+		want = DigestPrefix + want
+	}
+	if !strings.EqualFold(got, want) {
+		return fmt.Errorf("checksum mismatch: got %s, want %s", got, want)
+	}
+
+	return nil
+}
+
+// isVCSDir reports whether name is a version control directory, which is not
+// part of a package.
+func isVCSDir(name string) bool {
+	return name == ".git" || name == ".hg" || name == ".svn"
+}
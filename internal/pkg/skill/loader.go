@@ -0,0 +1,129 @@
+package skill
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+	"github.com/kiosk404/eidolon/pkg/errorx"
+)
+
+// Skill is a skill package unpacked in the skills directory.
+type Skill struct {
+	Manifest
+
+	// Dir is the directory of the package.
+	Dir string
+}
+
+// EntrypointPath returns the absolute path of the executable of the skill.
+func (s *Skill) EntrypointPath() string {
+	return filepath.Join(s.Dir, filepath.FromSlash(s.Entrypoint))
+}
+
+// Info returns the description of the skill a golem advertises.
+func (s *Skill) Info() protocol.SkillInfo {
+	return protocol.SkillInfo{
+		ID:           s.ID,
+		Name:         s.Name,
+		Version:      s.Version,
+		Capabilities: s.Capabilities,
+	}
+}
+
+// Load reads the package in dir and checks that its entrypoint exists.
+func Load(dir string) (*Skill, error) {
+	m, err := LoadManifest(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Skill{Manifest: *m, Dir: dir}
+	info, err := os.Stat(s.EntrypointPath())
+	if err != nil {
+		return nil, fmt.Errorf("%s: entrypoint: %w", dir, err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%s: entrypoint %s is a directory", dir, s.Entrypoint)
+	}
+
+	return s, nil
+}
+
+// Scan loads the skills directly below dir, in the order of their directory
+// names. A skill is either a package installed by a Store, of which the
+// active version is loaded, or a package copied into dir. Directories without
+// a manifest or active version and hidden directories are skipped. The
+// packages that fail to load, or whose ID is already taken by an earlier one,
+// are reported in the returned error next to the valid ones. A missing dir
+// holds no skills.
+func Scan(dir string) ([]*Skill, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var (
+		skills []*Skill
+		errs   []error
+		seen   = make(map[string]string)
+	)
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		path, err := packageDir(filepath.Join(dir, e.Name()))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		} else if path == "" {
+			continue
+		}
+
+		s, err := Load(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if path != filepath.Join(dir, e.Name()) && s.ID != e.Name() {
+			errs = append(errs, fmt.Errorf("%s: skill %s is installed as %s", path, s.ID, e.Name()))
+			continue
+		}
+		if other, ok := seen[s.ID]; ok {
+			errs = append(errs, fmt.Errorf("%s: skill %s is already installed in %s", path, s.ID, other))
+			continue
+		}
+		seen[s.ID] = path
+		skills = append(skills, s)
+	}
+
+	return skills, errorx.NewAggregate(errs)
+}
+
+// packageDir returns the directory of the package to load for the skill in
+// dir: dir itself when it holds a manifest, the target of its current link
+// otherwise. It returns an empty string when there is none.
+func packageDir(dir string) (string, error) {
+	if _, err := os.Stat(filepath.Join(dir, ManifestFile)); err == nil {
+		return dir, nil
+	}
+
+	current := filepath.Join(dir, CurrentLink)
+	if _, err := os.Lstat(current); errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	// Resolve the link so that the golem keeps the version it loaded when the
+	// link is switched.
+	path, err := filepath.EvalSymlinks(current)
+	if err != nil {
+		return "", fmt.Errorf("%s: active version: %w", dir, err)
+	}
+
+	return path, nil
+}
@@ -0,0 +1,126 @@
+// Package skill describes the skill packages golems install: a directory with
+// a skill.yaml manifest next to the files the skill runs.
+package skill
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/kiosk404/eidolon/pkg/errorx"
+	versionutil "github.com/kiosk404/eidolon/pkg/version/util"
+	"go.yaml.in/yaml/v3"
+)
+
+// ManifestFile is the name of the manifest at the root of a skill package.
+const ManifestFile = "skill.yaml"
+
+// idPattern matches a skill ID, like: web-search.
+var idPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9._-]{0,61}[a-z0-9])?$`)
+
+// Manifest is the content of a skill.yaml.
+type Manifest struct {
+	// ID identifies the skill across versions, like: web-search.
+	ID string `json:"id" yaml:"id"`
+
+	// Name is the human readable name, it defaults to the ID.
+	Name string `json:"name" yaml:"name"`
+
+	// Version is the semantic version of the package, like: 1.2.0.
+	Version string `json:"version" yaml:"version"`
+
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+
+	// Capabilities are the capabilities the skill provides to the tasks that
+	// require them.
+	Capabilities []string `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
+
+	// Entrypoint is the path of the executable of the skill, relative to the
+	// package directory.
+	Entrypoint string `json:"entrypoint" yaml:"entrypoint"`
+
+	// RequiredFeatures are the node features the skill needs to run, like:
+	// browser_automation.
+	RequiredFeatures []string `json:"required_features,omitempty" yaml:"requiredFeatures,omitempty"`
+
+	// Parameters is the JSON schema of the task payload the skill accepts.
+	Parameters map[string]any `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+}
+
+// LoadManifest reads and validates the manifest at path.
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := ParseManifest(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return m, nil
+}
+
+// ParseManifest decodes and validates a manifest.
+func ParseManifest(data []byte) (*Manifest, error) {
+	m := &Manifest{}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(m); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("manifest is empty")
+		}
+
+		return nil, err
+	}
+	if m.Name == "" {
+		m.Name = m.ID
+	}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Validate checks the fields of the manifest, it does not look at the files of
+// the package.
+func (m *Manifest) Validate() error {
+	var errs []error
+	if !idPattern.MatchString(m.ID) {
+		errs = append(errs, fmt.Errorf("id %q must be 1 to 63 lower case alphanumeric characters, '-', '_' or '.'", m.ID))
+	}
+	if strings.TrimSpace(m.Name) == "" {
+		errs = append(errs, fmt.Errorf("name must not be empty"))
+	}
+	if _, err := versionutil.ParseSemantic(m.Version); err != nil {
+		errs = append(errs, fmt.Errorf("version: %w", err))
+	}
+	for _, c := range m.Capabilities {
+		if strings.TrimSpace(c) == "" || strings.ContainsAny(c, " \t,") {
+			errs = append(errs, fmt.Errorf("capability %q must be a single word", c))
+		}
+	}
+	for _, f := range m.RequiredFeatures {
+		if strings.TrimSpace(f) == "" {
+			errs = append(errs, fmt.Errorf("required features must not be empty"))
+		}
+	}
+	switch {
+	case m.Entrypoint == "":
+		errs = append(errs, fmt.Errorf("entrypoint must not be empty"))
+	case filepath.IsAbs(m.Entrypoint) || !filepath.IsLocal(filepath.FromSlash(m.Entrypoint)):
+		errs = append(errs, fmt.Errorf("entrypoint %q must be a path inside the package", m.Entrypoint))
+	}
+	if t, ok := m.Parameters["type"]; ok && t != "object" {
+		errs = append(errs, fmt.Errorf("parameters must be the schema of an object, not of a %v", t))
+	}
+
+	return errorx.NewAggregate(errs)
+}
@@ -0,0 +1,314 @@
+package skill
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kiosk404/eidolon/internal/pkg/workspace"
+	versionutil "github.com/kiosk404/eidolon/pkg/version/util"
+)
+
+const (
+	// InstallRecordFile is written next to the manifest of an installed
+	// version, it is not part of the package.
+	InstallRecordFile = ".install.json"
+
+	// CurrentLink is the symbolic link to the active version of a skill.
+	CurrentLink = "current"
+)
+
+// InstallRecord tells where an installed version comes from.
+type InstallRecord struct {
+	// Digest is the digest of the package, see Digest.
+	Digest string `json:"digest"`
+
+	// Source is the path, URL or git reference the package was installed from.
+	Source string `json:"source,omitempty"`
+
+	InstalledAt time.Time `json:"installed_at"`
+}
+
+// Version is a version of a skill installed in a Store.
+type Version struct {
+	*Skill
+
+	Record *InstallRecord
+
+	// Active tells whether it is the version the golem runs.
+	Active bool
+}
+
+// Store manages the skills directory of a golem, laid out as:
+//
+//	<dir>/<id>/<version>/skill.yaml
+//	<dir>/<id>/current -> <version>
+//
+// Several versions of a skill are kept side by side, current links to the one
+// golems load.
+type Store struct {
+	dir string
+}
+
+// NewStore creates a Store of the skills in dir.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Dir returns the skills directory.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Install copies the package in src to the store as a new version of its
+// skill and returns it. An installed version is only replaced when force is
+// set, unless it is the active one. The version is not activated.
+func (s *Store) Install(src, source string, force bool) (*Version, error) {
+	pkg, err := Load(src)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := Digest(src)
+	if err != nil {
+		return nil, err
+	}
+
+	skillDir := filepath.Join(s.dir, pkg.ID)
+	if err := os.MkdirAll(skillDir, 0o755); err != nil {
+		return nil, err
+	}
+	dst := filepath.Join(skillDir, pkg.Version)
+	if _, err := os.Lstat(dst); err == nil {
+		if !force {
+			return nil, fmt.Errorf("skill %s@%s is already installed", pkg.ID, pkg.Version)
+		}
+		if active, _ := s.Active(pkg.ID); active == pkg.Version {
+			return nil, fmt.Errorf("skill %s@%s is active and cannot be replaced", pkg.ID, pkg.Version)
+		}
+	}
+
+	// Unpack next to the final directory so that the version appears at once.
+	tmp, err := os.MkdirTemp(skillDir, ".install-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	root := filepath.Join(tmp, "pkg")
+	if err := copyTree(src, root); err != nil {
+		return nil, err
+	}
+	if got, err := Digest(root); err != nil {
+		return nil, err
+	} else if got != digest {
+		return nil, fmt.Errorf("package changed while it was copied")
+	}
+
+	record := &InstallRecord{Digest: digest, Source: source, InstalledAt: time.Now().UTC()}
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := workspace.WriteFile(filepath.Join(root, InstallRecordFile), data, 0o644); err != nil {
+		return nil, err
+	}
+
+	if err := os.RemoveAll(dst); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(root, dst); err != nil {
+		return nil, err
+	}
+
+	return s.version(pkg.ID, pkg.Version)
+}
+
+// Activate makes version the active version of skill id. The current link is
+// replaced with a rename, so golems see either the old or the new version.
+func (s *Store) Activate(id, version string) error {
+	if err := checkRef(id, version); err != nil {
+		return err
+	}
+	if _, err := s.version(id, version); err != nil {
+		return err
+	}
+
+	skillDir := filepath.Join(s.dir, id)
+	tmp := filepath.Join(skillDir, fmt.Sprintf(".%s-%d", CurrentLink, os.Getpid()))
+	_ = os.Remove(tmp)
+	if err := os.Symlink(version, tmp); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, filepath.Join(skillDir, CurrentLink)); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	return nil
+}
+
+// Active returns the active version of skill id, an empty string if it has
+// none.
+func (s *Store) Active(id string) (string, error) {
+	target, err := os.Readlink(filepath.Join(s.dir, id, CurrentLink))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return filepath.Base(target), nil
+}
+
+// Versions returns the installed versions of skill id, newest first.
+func (s *Store) Versions(id string) ([]*Version, error) {
+	if err := checkRef(id, ""); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(filepath.Join(s.dir, id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var versions []*Version
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		v, err := s.version(id, e.Name())
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, v)
+	}
+	sortVersions(versions)
+
+	return versions, nil
+}
+
+// List returns the installed versions of every skill, sorted by ID then
+// newest first. Directories that are not installed skills are skipped.
+func (s *Store) List() ([]*Version, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var list []*Version
+	for _, e := range entries {
+		if !e.IsDir() || !idPattern.MatchString(e.Name()) {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(s.dir, e.Name(), ManifestFile)); err == nil {
+			// A package copied into the directory by hand.
+			continue
+		}
+		versions, err := s.Versions(e.Name())
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, versions...)
+	}
+
+	return list, nil
+}
+
+// Remove deletes version of skill id, or the whole skill when version is
+// empty. The active version can only be removed with the whole skill.
+func (s *Store) Remove(id, version string) error {
+	if err := checkRef(id, version); err != nil {
+		return err
+	}
+	skillDir := filepath.Join(s.dir, id)
+	if _, err := os.Stat(skillDir); err != nil {
+		return fmt.Errorf("skill %s is not installed", id)
+	}
+	if version == "" {
+		return os.RemoveAll(skillDir)
+	}
+
+	if _, err := s.version(id, version); err != nil {
+		return err
+	}
+	active, err := s.Active(id)
+	if err != nil {
+		return err
+	}
+	if active == version {
+		return fmt.Errorf("skill %s@%s is active, activate another version or remove the whole skill", id, version)
+	}
+
+	return os.RemoveAll(filepath.Join(skillDir, version))
+}
+
+// version loads an installed version of skill id.
+func (s *Store) version(id, version string) (*Version, error) {
+	dir := filepath.Join(s.dir, id, version)
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("skill %s@%s is not installed", id, version)
+	}
+
+	pkg, err := Load(dir)
+	if err != nil {
+		return nil, err
+	}
+	if pkg.ID != id || pkg.Version != version {
+		return nil, fmt.Errorf("%s holds skill %s@%s", dir, pkg.ID, pkg.Version)
+	}
+
+	v := &Version{Skill: pkg}
+	if data, err := os.ReadFile(filepath.Join(dir, InstallRecordFile)); err == nil {
+		v.Record = &InstallRecord{}
+		if err := json.Unmarshal(data, v.Record); err != nil {
+			return nil, fmt.Errorf("%s: %w", InstallRecordFile, err)
+		}
+	}
+	active, err := s.Active(id)
+	if err != nil {
+		return nil, err
+	}
+	v.Active = active == version
+
+	return v, nil
+}
+
+// checkRef makes sure id and version, if set, cannot name a path outside of
+// the directory of the skill.
+func checkRef(id, version string) error {
+	if !idPattern.MatchString(id) {
+		return fmt.Errorf("invalid skill ID %q", id)
+	}
+	if version != "" {
+		if _, err := versionutil.ParseSemantic(version); err != nil {
+			return fmt.Errorf("invalid version %q of skill %s: %w", version, id, err)
+		}
+	}
+
+	return nil
+}
+
+// sortVersions sorts versions by ID, then from the newest to the oldest.
+func sortVersions(versions []*Version) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		if versions[i].ID != versions[j].ID {
+			return versions[i].ID < versions[j].ID
+		}
+		a, errA := versionutil.ParseSemantic(versions[i].Version)
+		b, errB := versionutil.ParseSemantic(versions[j].Version)
+		if errA != nil || errB != nil {
+			return versions[i].Version > versions[j].Version
+		}
+
+		return b.LessThan(a)
+	})
+}
@@ -0,0 +1,70 @@
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+const (
+	// ConfigFile is the golem configuration written by 'eidoctl init'.
+	ConfigFile = "golem.json"
+
+	// SkillsDir, DataDir, LogDir and CacheDir are the default sub directories
+	// of the workspace.
+	SkillsDir = "skills"
+	DataDir   = "data"
+	LogDir    = "logs"
+	CacheDir  = "cache"
+)
+
+// Config is the configuration a golem starts with.
+type Config struct {
+	NodeID       string `json:"node_id"`
+	Workspace    string `json:"workspace"`
+	SkillsDir    string `json:"skills_dir"`
+	DataDir      string `json:"data_dir"`
+	LogDir       string `json:"log_dir"`
+	CacheDir     string `json:"cache_dir"`
+	HivemindAddr string `json:"hivemind_addr,omitempty"`
+}
+
+// DefaultConfig returns the configuration of a golem using the default layout
+// of the workspace.
+func (w *Workspace) DefaultConfig() *Config {
+	return &Config{
+		Workspace: w.Dir,
+		SkillsDir: w.Path(SkillsDir),
+		DataDir:   w.Path(DataDir),
+		LogDir:    w.Path(DataDir, LogDir),
+		CacheDir:  w.Path(DataDir, CacheDir),
+	}
+}
+
+// ConfigPath returns the path of the golem configuration file.
+func (w *Workspace) ConfigPath() string { return w.Path(ConfigFile) }
+
+// LoadConfig reads the golem configuration file.
+func (w *Workspace) LoadConfig() (*Config, error) {
+	data, err := os.ReadFile(w.ConfigPath())
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Config{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", w.ConfigPath(), err)
+	}
+
+	return c, nil
+}
+
+// SaveConfig writes the golem configuration file.
+func (w *Workspace) SaveConfig(c *Config) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return WriteFile(w.ConfigPath(), append(data, '\n'), 0o600)
+}
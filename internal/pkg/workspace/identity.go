@@ -0,0 +1,73 @@
+package workspace
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kiosk404/eidolon/internal/pkg/protocol"
+)
+
+// IdentityFile holds the node's ID and ed25519 keypair.
+const IdentityFile = "identity.json"
+
+// Identity is the long-lived identity of a node, generated by 'eidoctl init'.
+type Identity struct {
+	NodeID     string             `json:"node_id"`
+	PublicKey  ed25519.PublicKey  `json:"public_key"`
+	PrivateKey ed25519.PrivateKey `json:"private_key"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
+
+// NewIdentity generates a keypair and derives the node ID from it.
+func NewIdentity() (*Identity, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Identity{
+		NodeID:     protocol.NodeIDFromKey(pub),
+		PublicKey:  pub,
+		PrivateKey: priv,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// IdentityPath returns the path of the identity file.
+func (w *Workspace) IdentityPath() string { return w.Path(IdentityFile) }
+
+// LoadIdentity reads and verifies the identity file. The error satisfies
+// errors.Is(err, fs.ErrNotExist) when the node has not been initialized.
+func (w *Workspace) LoadIdentity() (*Identity, error) {
+	data, err := os.ReadFile(w.IdentityPath())
+	if err != nil {
+		return nil, err
+	}
+
+	id := &Identity{}
+	if err := json.Unmarshal(data, id); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", w.IdentityPath(), err)
+	}
+	if len(id.PrivateKey) != ed25519.PrivateKeySize || !id.PublicKey.Equal(id.PrivateKey.Public()) {
+		return nil, fmt.Errorf("%s: key pair does not match", w.IdentityPath())
+	}
+	if id.NodeID != protocol.NodeIDFromKey(id.PublicKey) {
+		return nil, fmt.Errorf("%s: node ID does not match the public key", w.IdentityPath())
+	}
+
+	return id, nil
+}
+
+// SaveIdentity writes the identity file, readable by the owner only.
+func (w *Workspace) SaveIdentity(id *Identity) error {
+	data, err := json.MarshalIndent(id, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return WriteFile(w.IdentityPath(), append(data, '\n'), 0o600)
+}
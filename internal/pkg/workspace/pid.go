@@ -0,0 +1,51 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// PIDFile holds the process ID of the running golem.
+const PIDFile = "golem.pid"
+
+// PIDPath returns the path of the golem PID file.
+func (w *Workspace) PIDPath() string { return w.Path(PIDFile) }
+
+// WritePID records pid as the running golem.
+func (w *Workspace) WritePID(pid int) error {
+	return WriteFile(w.PIDPath(), []byte(strconv.Itoa(pid)+"\n"), 0o644)
+}
+
+// RemovePID removes the PID file.
+func (w *Workspace) RemovePID() error {
+	if err := os.Remove(w.PIDPath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// GolemPID returns the process ID of the running golem, or 0 when the PID file
+// is missing or its process is gone.
+func (w *Workspace) GolemPID() (int, error) {
+	data, err := os.ReadFile(w.PIDPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return 0, fmt.Errorf("%s: invalid process ID %q", w.PIDPath(), strings.TrimSpace(string(data)))
+	}
+
+	if !processAlive(pid) {
+		return 0, nil
+	}
+
+	return pid, nil
+}
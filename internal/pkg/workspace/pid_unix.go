@@ -0,0 +1,16 @@
+//go:build unix
+
+package workspace
+
+import (
+	"errors"
+	"syscall"
+)
+
+// processAlive reports whether the process pid exists. Signal 0 only checks
+// that it does; EPERM means it does but belongs to another user.
+func processAlive(pid int) bool {
+	err := syscall.Kill(pid, 0)
+
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
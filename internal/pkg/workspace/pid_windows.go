@@ -0,0 +1,17 @@
+//go:build windows
+
+package workspace
+
+import "os"
+
+// processAlive reports whether the process pid exists. On Windows
+// os.FindProcess opens the process and fails when there is none.
+func processAlive(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	_ = p.Release()
+
+	return true
+}
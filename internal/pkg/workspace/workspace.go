@@ -0,0 +1,135 @@
+// Package workspace describes the on-disk layout of a golem node's workspace
+// directory, which eidoctl prepares and the golem reads when it starts.
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kiosk404/eidolon/pkg/utils/homedir"
+)
+
+const (
+	// DefaultDirName is the name of the workspace directory in the user's home.
+	DefaultDirName = ".eidolon"
+
+	// MembershipFile records the hivemind the node joined.
+	MembershipFile = "membership.json"
+
+	// PKIDir holds the node's TLS credentials.
+	PKIDir = "pki"
+
+	// CACertFile is the CA that verifies the hivemind, inside PKIDir.
+	CACertFile = "ca.crt"
+
+	// CertFile and KeyFile are the node's client certificate and key, inside PKIDir.
+	CertFile = "golem.crt"
+	KeyFile  = "golem.key"
+)
+
+// DefaultDir returns the default workspace directory, like: /home/eidolon/.eidolon.
+func DefaultDir() string {
+	return filepath.Join(homedir.HomeDir(), DefaultDirName)
+}
+
+// Workspace is a golem workspace directory.
+type Workspace struct {
+	Dir string
+}
+
+// New returns the workspace rooted at dir.
+func New(dir string) *Workspace {
+	return &Workspace{Dir: dir}
+}
+
+// Path joins elem onto the workspace directory.
+func (w *Workspace) Path(elem ...string) string {
+	return filepath.Join(append([]string{w.Dir}, elem...)...)
+}
+
+// MembershipPath returns the path of the membership file.
+func (w *Workspace) MembershipPath() string { return w.Path(MembershipFile) }
+
+// CACertPath returns the path of the hivemind CA certificate.
+func (w *Workspace) CACertPath() string { return w.Path(PKIDir, CACertFile) }
+
+// CertPath returns the path of the node's client certificate.
+func (w *Workspace) CertPath() string { return w.Path(PKIDir, CertFile) }
+
+// KeyPath returns the path of the node's private key.
+func (w *Workspace) KeyPath() string { return w.Path(PKIDir, KeyFile) }
+
+// Membership is what a node learned when it joined a hivemind.
+type Membership struct {
+	NodeID       string            `json:"node_id"`
+	NodeName     string            `json:"node_name"`
+	HivemindAddr string            `json:"hivemind_addr"`
+	Tags         map[string]string `json:"tags,omitempty"`
+
+	// CAFile, CertFile and KeyFile are empty when the hivemind serves without TLS.
+	CAFile   string `json:"ca_file,omitempty"`
+	CertFile string `json:"cert_file,omitempty"`
+	KeyFile  string `json:"key_file,omitempty"`
+
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// LoadMembership reads the membership file. The error satisfies
+// errors.Is(err, fs.ErrNotExist) when the node has not joined yet.
+func (w *Workspace) LoadMembership() (*Membership, error) {
+	data, err := os.ReadFile(w.MembershipPath())
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Membership{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", w.MembershipPath(), err)
+	}
+
+	return m, nil
+}
+
+// SaveMembership writes the membership file.
+func (w *Workspace) SaveMembership(m *Membership) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return WriteFile(w.MembershipPath(), append(data, '\n'), 0o600)
+}
+
+// WriteFile writes data to a temporary file next to path and renames it into
+// place, so that readers never see a partially written file. Missing parent
+// directories are created with mode 0700.
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	defer os.Remove(tmp)
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Chmod(perm); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
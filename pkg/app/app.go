@@ -235,7 +235,7 @@ func (a *App) runCommand(cmd *cobra.Command, args []string) error {
 	}
 
 	if !a.noConfig {
-		if err := viper.BindPFlags(cmd.Flags()); err != nil {
+		if err := bindFlags(cmd.Flags()); err != nil {
 			return err
 		}
 
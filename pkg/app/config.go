@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/gosuri/uitable"
+	"github.com/kiosk404/eidolon/pkg/utils/cliflag"
 	"github.com/kiosk404/eidolon/pkg/utils/homedir"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -55,6 +56,18 @@ func addConfigFlag(basename string, fs *pflag.FlagSet) {
 	})
 }
 
+// bindFlags binds every flag of fs to its configuration key.
+func bindFlags(fs *pflag.FlagSet) error {
+	var err error
+	fs.VisitAll(func(flag *pflag.Flag) {
+		if err == nil {
+			err = viper.BindPFlag(cliflag.ConfigKey(flag), flag)
+		}
+	})
+
+	return err
+}
+
 func printConfig() {
 	if keys := viper.AllKeys(); len(keys) > 0 {
 		fmt.Printf("%v Configuration items:\n", progressMessage)
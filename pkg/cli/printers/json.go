@@ -0,0 +1,36 @@
+package printers
+
+import (
+	"encoding/json"
+	"io"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// JSONPrinter prints objects as indented JSON.
+type JSONPrinter struct{}
+
+// PrintObj implements ResourcePrinter.
+func (p *JSONPrinter) PrintObj(obj any, w io.Writer) error {
+	data, err := json.MarshalIndent(obj, "", "    ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+
+	return err
+}
+
+// YAMLPrinter prints objects as YAML.
+type YAMLPrinter struct{}
+
+// PrintObj implements ResourcePrinter.
+func (p *YAMLPrinter) PrintObj(obj any, w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(obj); err != nil {
+		return err
+	}
+
+	return enc.Close()
+}
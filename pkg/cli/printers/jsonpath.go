@@ -0,0 +1,423 @@
+package printers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JSONPathPrinter evaluates a JSONPath template on the JSON form of objects.
+//
+// The supported syntax is the subset of the kubectl JSONPath templates that
+// scripts use in practice:
+//
+//	{.field.sub}        fields, '$' or '@' may start the path
+//	{.items[0]}         index, negative indices count from the end
+//	{.items[1:3]}       slice
+//	{.items[*].id}      wildcard over arrays and maps
+//	{..id}              recursive descent
+//	{.tags['a.b']}      bracketed keys
+//	{"\n"}              quoted literals
+//	{range .items[*]}{.id}{"\n"}{end}
+//
+// Several results of a path are separated by spaces. Filters, unions and
+// single-quoted literals are not supported and fail to parse.
+type JSONPathPrinter struct {
+	nodes []jpNode
+}
+
+type jpNode interface{}
+
+type jpText string
+
+type jpPath []jpStep
+
+type jpRange struct {
+	path jpPath
+	body []jpNode
+}
+
+type jpStepKind int
+
+const (
+	stepField jpStepKind = iota
+	stepRecursive
+	stepWildcard
+	stepIndex
+	stepSlice
+)
+
+type jpStep struct {
+	kind  jpStepKind
+	name  string
+	index int
+
+	// start and end bound a slice; nil means the beginning or the end.
+	start, end *int
+}
+
+// NewJSONPathPrinter parses a JSONPath template.
+func NewJSONPathPrinter(text string) (*JSONPathPrinter, error) {
+	nodes, err := parseJSONPath(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse jsonpath %q: %w", text, err)
+	}
+
+	return &JSONPathPrinter{nodes: nodes}, nil
+}
+
+// PrintObj implements ResourcePrinter.
+func (p *JSONPathPrinter) PrintObj(obj any, w io.Writer) error {
+	data, err := toGeneric(obj)
+	if err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	if err := executeJSONPath(&sb, p.nodes, data); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, sb.String())
+
+	return err
+}
+
+func parseJSONPath(text string) ([]jpNode, error) {
+	root := &jpRange{}
+	stack := []*jpRange{root}
+	add := func(n jpNode) {
+		top := stack[len(stack)-1]
+		top.body = append(top.body, n)
+	}
+
+	for len(text) > 0 {
+		open := strings.IndexByte(text, '{')
+		if open < 0 {
+			add(jpText(text))
+			break
+		}
+		if open > 0 {
+			add(jpText(text[:open]))
+		}
+
+		end, err := closing(text, open, '}')
+		if err != nil {
+			return nil, fmt.Errorf("unclosed action %q", text[open:])
+		}
+		action := strings.TrimSpace(text[open+1 : end])
+		text = text[end+1:]
+
+		switch {
+		case action == "end":
+			if len(stack) == 1 {
+				return nil, fmt.Errorf("{end} without {range}")
+			}
+			stack = stack[:len(stack)-1]
+		case strings.HasPrefix(action, "range "):
+			path, err := parsePath(strings.TrimSpace(strings.TrimPrefix(action, "range ")))
+			if err != nil {
+				return nil, err
+			}
+			r := &jpRange{path: path}
+			add(r)
+			stack = append(stack, r)
+		case strings.HasPrefix(action, `"`):
+			s, err := strconv.Unquote(action)
+			if err != nil {
+				return nil, fmt.Errorf("invalid literal %s", action)
+			}
+			add(jpText(s))
+		case strings.HasPrefix(action, "'"):
+			return nil, fmt.Errorf("invalid literal %s, literals are double-quoted", action)
+		default:
+			path, err := parsePath(action)
+			if err != nil {
+				return nil, err
+			}
+			add(path)
+		}
+	}
+	if len(stack) > 1 {
+		return nil, fmt.Errorf("{range} without {end}")
+	}
+
+	return root.body, nil
+}
+
+// closing returns the index of the closer byte ending the group opened at open,
+// skipping quoted strings.
+func closing(text string, open int, closer byte) (int, error) {
+	var quote byte
+	for i := open + 1; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+		case c == '"' || c == '\'':
+			quote = c
+		case c == closer:
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("no closing %q", closer)
+}
+
+func parsePath(expr string) (jpPath, error) {
+	expr = strings.TrimPrefix(strings.TrimPrefix(expr, "$"), "@")
+
+	var path jpPath
+	for len(expr) > 0 {
+		switch {
+		case strings.HasPrefix(expr, ".."):
+			name, rest := fieldName(expr[2:])
+			if name == "" {
+				return nil, fmt.Errorf("missing field name after '..'")
+			}
+			path = append(path, jpStep{kind: stepRecursive, name: name})
+			expr = rest
+		case expr[0] == '.':
+			name, rest := fieldName(expr[1:])
+			switch name {
+			case "":
+			case "*":
+				path = append(path, jpStep{kind: stepWildcard})
+			default:
+				path = append(path, jpStep{kind: stepField, name: name})
+			}
+			expr = rest
+		case expr[0] == '[':
+			end, err := closing(expr, 0, ']')
+			if err != nil {
+				return nil, fmt.Errorf("unclosed '[' in %q", expr)
+			}
+			step, err := parseBracket(strings.TrimSpace(expr[1:end]))
+			if err != nil {
+				return nil, err
+			}
+			path = append(path, step)
+			expr = expr[end+1:]
+		default:
+			return nil, fmt.Errorf("unexpected %q, paths start with '.'", expr)
+		}
+	}
+
+	return path, nil
+}
+
+func fieldName(expr string) (string, string) {
+	end := strings.IndexAny(expr, ".[")
+	if end < 0 {
+		return expr, ""
+	}
+
+	return expr[:end], expr[end:]
+}
+
+func parseBracket(inner string) (jpStep, error) {
+	switch {
+	case inner == "*":
+		return jpStep{kind: stepWildcard}, nil
+	case strings.HasPrefix(inner, "?"):
+		return jpStep{}, fmt.Errorf("filters are not supported: [%s]", inner)
+	case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+		return jpStep{kind: stepField, name: inner[1 : len(inner)-1]}, nil
+	case strings.Contains(inner, ","):
+		return jpStep{}, fmt.Errorf("unions are not supported: [%s]", inner)
+	case strings.Contains(inner, ":"):
+		from, to, _ := strings.Cut(inner, ":")
+		step := jpStep{kind: stepSlice}
+		for _, b := range []struct {
+			s   string
+			dst **int
+		}{{from, &step.start}, {to, &step.end}} {
+			if s := strings.TrimSpace(b.s); s != "" {
+				n, err := strconv.Atoi(s)
+				if err != nil {
+					return jpStep{}, fmt.Errorf("invalid slice [%s]", inner)
+				}
+				*b.dst = &n
+			}
+		}
+
+		return step, nil
+	default:
+		n, err := strconv.Atoi(inner)
+		if err != nil {
+			return jpStep{}, fmt.Errorf("invalid index [%s]", inner)
+		}
+
+		return jpStep{kind: stepIndex, index: n}, nil
+	}
+}
+
+func executeJSONPath(sb *strings.Builder, nodes []jpNode, data any) error {
+	for _, n := range nodes {
+		switch n := n.(type) {
+		case jpText:
+			sb.WriteString(string(n))
+		case jpPath:
+			values := n.eval(data)
+			for i, v := range values {
+				if i > 0 {
+					sb.WriteByte(' ')
+				}
+				s, err := formatValue(v)
+				if err != nil {
+					return err
+				}
+				sb.WriteString(s)
+			}
+		case *jpRange:
+			values := n.path.eval(data)
+			// Ranging over a single array ranges over its elements.
+			if len(values) == 1 {
+				if arr, ok := values[0].([]any); ok {
+					values = arr
+				}
+			}
+			for _, v := range values {
+				if err := executeJSONPath(sb, n.body, v); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p jpPath) eval(data any) []any {
+	values := []any{data}
+	for _, step := range p {
+		var next []any
+		for _, v := range values {
+			next = append(next, step.apply(v)...)
+		}
+		values = next
+	}
+
+	return values
+}
+
+func (s jpStep) apply(v any) []any {
+	switch s.kind {
+	case stepField:
+		if m, ok := v.(map[string]any); ok {
+			if field, ok := m[s.name]; ok {
+				return []any{field}
+			}
+		}
+	case stepRecursive:
+		var out []any
+		walk(v, func(x any) {
+			if m, ok := x.(map[string]any); ok {
+				if field, ok := m[s.name]; ok {
+					out = append(out, field)
+				}
+			}
+		})
+
+		return out
+	case stepWildcard:
+		switch x := v.(type) {
+		case []any:
+			return x
+		case map[string]any:
+			keys := sortedKeys(x)
+			out := make([]any, 0, len(keys))
+			for _, k := range keys {
+				out = append(out, x[k])
+			}
+
+			return out
+		}
+	case stepIndex:
+		if arr, ok := v.([]any); ok {
+			i := s.index
+			if i < 0 {
+				i += len(arr)
+			}
+			if i >= 0 && i < len(arr) {
+				return []any{arr[i]}
+			}
+		}
+	case stepSlice:
+		if arr, ok := v.([]any); ok {
+			start, end := 0, len(arr)
+			if s.start != nil {
+				start = clampIndex(*s.start, len(arr))
+			}
+			if s.end != nil {
+				end = clampIndex(*s.end, len(arr))
+			}
+			if start < end {
+				return arr[start:end]
+			}
+		}
+	}
+
+	return nil
+}
+
+func clampIndex(i, n int) int {
+	if i < 0 {
+		i += n
+	}
+	if i < 0 {
+		return 0
+	}
+	if i > n {
+		return n
+	}
+
+	return i
+}
+
+// walk calls fn on v and every value nested in it, maps in key order.
+func walk(v any, fn func(any)) {
+	fn(v)
+	switch x := v.(type) {
+	case []any:
+		for _, e := range x {
+			walk(e, fn)
+		}
+	case map[string]any:
+		for _, k := range sortedKeys(x) {
+			walk(x[k], fn)
+		}
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// formatValue prints strings and numbers as they are and everything else as JSON.
+func formatValue(v any) (string, error) {
+	switch x := v.(type) {
+	case string:
+		return x, nil
+	case json.Number:
+		return x.String(), nil
+	case nil:
+		return "", nil
+	case map[string]any, []any:
+		data, err := json.Marshal(x)
+		return string(data), err
+	default:
+		return fmt.Sprint(x), nil
+	}
+}
@@ -0,0 +1,96 @@
+package printers
+
+import (
+	"strings"
+	"testing"
+)
+
+var jsonPathObj = map[string]any{
+	"kind": "List",
+	"items": []any{
+		map[string]any{"id": "a", "cpu": 2, "tags": map[string]any{"zone": "eu", "a.b": "dotted"}},
+		map[string]any{"id": "b", "cpu": 4, "tags": map[string]any{"zone": "us"}},
+		map[string]any{"id": "c", "cpu": 8.5, "meta": map[string]any{"id": "nested"}},
+	},
+	"owner": map[string]any{"name": "ops", "id": "root"},
+	"empty": nil,
+}
+
+func TestJSONPathPrinter(t *testing.T) {
+	for _, tc := range []struct {
+		name, template, want string
+	}{
+		{"field", "{.kind}", "List"},
+		{"nested field", "{.owner.name}", "ops"},
+		{"dollar root", "{$.owner.name}", "ops"},
+		{"at root", "{@.kind}", "List"},
+		{"missing field", "{.missing.field}", ""},
+		{"null", "{.empty}", ""},
+		{"object as json", "{.owner}", `{"id":"root","name":"ops"}`},
+		{"text around actions", "kind={.kind}!", "kind=List!"},
+		{"index", "{.items[1].id}", "b"},
+		{"negative index", "{.items[-1].id}", "c"},
+		{"index out of range", "{.items[5].id}", ""},
+		{"wildcard", "{.items[*].id}", "a b c"},
+		{"dot wildcard", "{.items.*.id}", "a b c"},
+		{"map wildcard in key order", "{.owner.*}", "root ops"},
+		{"numbers", "{.items[*].cpu}", "2 4 8.5"},
+		{"slice", "{.items[0:2].id}", "a b"},
+		{"open slice", "{.items[1:].id}", "b c"},
+		{"negative slice", "{.items[-2:].id}", "b c"},
+		{"empty slice", "{.items[2:1].id}", ""},
+		{"recursive descent", "{..id}", "a b c nested root"},
+		{"recursive descent below field", "{.items..zone}", "eu us"},
+		{"bracketed key", "{.items[0].tags['a.b']}", "dotted"},
+		{"double-quoted key", `{.items[0].tags["zone"]}`, "eu"},
+		{"bracketed key with bracket", "{.items[0].tags['a]b']}", ""},
+		{"literal", `{.kind}{"\n"}`, "List\n"},
+		{"literal with brace", `{"}"}`, "}"},
+		{"range", `{range .items[*]}{.id}{"\t"}{.tags.zone}{"\n"}{end}`, "a\teu\nb\tus\nc\t\n"},
+		{"range over array", `{range .items}[{.id}]{end}`, "[a][b][c]"},
+		{"nested range", `{range .items[0:2]}{range .tags.*}{@}.{end}{end}`, "dotted.eu.us."},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := NewJSONPathPrinter(tc.template)
+			if err != nil {
+				t.Fatalf("NewJSONPathPrinter(%q): %v", tc.template, err)
+			}
+			var sb strings.Builder
+			if err := p.PrintObj(jsonPathObj, &sb); err != nil {
+				t.Fatalf("PrintObj: %v", err)
+			}
+			if got := sb.String(); got != tc.want {
+				t.Errorf("%s = %q, want %q", tc.template, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestJSONPathPrinterParseErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name, template, wantErr string
+	}{
+		{"filter", "{.items[?(@.cpu>2)].id}", "filters are not supported"},
+		{"union", "{.items[0,1].id}", "unions are not supported"},
+		{"single-quoted literal", "{'\\n'}", "literals are double-quoted"},
+		{"invalid literal", `{"\q"}`, "invalid literal"},
+		{"unclosed action", "{.kind", "unclosed action"},
+		{"unclosed bracket", "{.items[0}", "unclosed '['"},
+		{"invalid index", "{.items[x]}", "invalid index"},
+		{"invalid slice", "{.items[0:2:1]}", "invalid slice"},
+		{"recursive descent without name", "{..}", "missing field name"},
+		{"path without dot", "{kind}", "paths start with '.'"},
+		{"end without range", "{end}", "{end} without {range}"},
+		{"range without end", "{range .items[*]}{.id}", "{range} without {end}"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewJSONPathPrinter(tc.template)
+			if err == nil {
+				t.Fatalf("NewJSONPathPrinter(%q) succeeded, want an error", tc.template)
+			}
+			if !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("NewJSONPathPrinter(%q) error = %q, want it to contain %q", tc.template, err, tc.wantErr)
+			}
+		})
+	}
+}
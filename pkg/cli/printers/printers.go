@@ -0,0 +1,107 @@
+// Package printers writes the objects returned by CLI commands in the output
+// format picked by the user, either for people (table, wide) or for scripts
+// (json, yaml, go-template, jsonpath).
+package printers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Output formats accepted by NewPrinter. The template formats take their
+// argument after an equals sign, like: jsonpath={.items[*].id}.
+const (
+	Default        = ""
+	Wide           = "wide"
+	JSON           = "json"
+	YAML           = "yaml"
+	GoTemplate     = "go-template"
+	GoTemplateFile = "go-template-file"
+	JSONPath       = "jsonpath"
+	JSONPathFile   = "jsonpath-file"
+)
+
+// AllowedFormats lists the output formats for help messages.
+var AllowedFormats = []string{Wide, JSON, YAML, GoTemplate, GoTemplateFile, JSONPath, JSONPathFile}
+
+// ResourcePrinter prints an object to w.
+type ResourcePrinter interface {
+	PrintObj(obj any, w io.Writer) error
+}
+
+// ResourcePrinterFunc adapts a function to a ResourcePrinter.
+type ResourcePrinterFunc func(obj any, w io.Writer) error
+
+// PrintObj implements ResourcePrinter.
+func (f ResourcePrinterFunc) PrintObj(obj any, w io.Writer) error {
+	return f(obj, w)
+}
+
+// HumanReadable prints obj for people; wide asks for additional details.
+type HumanReadable func(obj any, w io.Writer, wide bool) error
+
+// NewPrinter returns the printer of the output format. human prints the default
+// and wide formats; commands without a human readable form pass nil and fall
+// back to yaml.
+func NewPrinter(output string, human HumanReadable) (ResourcePrinter, error) {
+	format, arg, hasArg := strings.Cut(output, "=")
+
+	switch format {
+	case Default, Wide:
+		if hasArg {
+			return nil, fmt.Errorf("output format %q takes no argument", format)
+		}
+		if human == nil {
+			return &YAMLPrinter{}, nil
+		}
+		wide := format == Wide
+
+		return ResourcePrinterFunc(func(obj any, w io.Writer) error {
+			return human(obj, w, wide)
+		}), nil
+	case JSON:
+		return &JSONPrinter{}, nil
+	case YAML:
+		return &YAMLPrinter{}, nil
+	case GoTemplate, GoTemplateFile, JSONPath, JSONPathFile:
+		if arg == "" {
+			return nil, fmt.Errorf("output format %q requires an argument, like: %s=...", format, format)
+		}
+		if format == GoTemplateFile || format == JSONPathFile {
+			data, err := os.ReadFile(arg)
+			if err != nil {
+				return nil, fmt.Errorf("read template file: %w", err)
+			}
+			arg = string(data)
+		}
+		if format == GoTemplate || format == GoTemplateFile {
+			return NewGoTemplatePrinter(arg)
+		}
+
+		return NewJSONPathPrinter(arg)
+	default:
+		return nil, fmt.Errorf("unknown output format %q, one of: %s", output, strings.Join(AllowedFormats, "|"))
+	}
+}
+
+// toGeneric converts obj into the maps, slices and scalars of its JSON form so
+// that templates address fields by their JSON names.
+func toGeneric(obj any) (any, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var out any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
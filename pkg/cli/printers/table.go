@@ -0,0 +1,64 @@
+package printers
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Column is a table column; Wide columns are only printed in the wide format.
+type Column struct {
+	Name string
+	Wide bool
+}
+
+// Table holds the rows of a table.
+type Table struct {
+	Columns []Column
+	Rows    [][]string
+}
+
+// NewTable creates a table with the given columns.
+func NewTable(columns ...Column) *Table {
+	return &Table{Columns: columns}
+}
+
+// AddRow appends a row, one cell per column.
+func (t *Table) AddRow(cells ...string) {
+	t.Rows = append(t.Rows, cells)
+}
+
+// Print writes the table aligned with tabs, leaving out the wide columns
+// unless wide is set.
+func (t *Table) Print(w io.Writer, wide bool) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+
+	visible := make([]int, 0, len(t.Columns))
+	header := make([]string, 0, len(t.Columns))
+	for i, c := range t.Columns {
+		if c.Wide && !wide {
+			continue
+		}
+		visible = append(visible, i)
+		header = append(header, c.Name)
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+	for _, row := range t.Rows {
+		cells := make([]string, 0, len(visible))
+		for _, i := range visible {
+			cell := ""
+			if i < len(row) {
+				cell = row[i]
+			}
+			if cell == "" {
+				cell = "<none>"
+			}
+			cells = append(cells, cell)
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,36 @@
+package printers
+
+import (
+	"fmt"
+	"io"
+	"text/template"
+)
+
+// GoTemplatePrinter executes a Go template on the JSON form of objects.
+type GoTemplatePrinter struct {
+	tmpl *template.Template
+}
+
+// NewGoTemplatePrinter parses text as a Go template.
+func NewGoTemplatePrinter(text string) (*GoTemplatePrinter, error) {
+	tmpl, err := template.New("output").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse go-template: %w", err)
+	}
+
+	return &GoTemplatePrinter{tmpl: tmpl}, nil
+}
+
+// PrintObj implements ResourcePrinter.
+func (p *GoTemplatePrinter) PrintObj(obj any, w io.Writer) error {
+	data, err := toGeneric(obj)
+	if err != nil {
+		return err
+	}
+
+	if err := p.tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("execute go-template: %w", err)
+	}
+
+	return nil
+}
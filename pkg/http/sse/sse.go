@@ -3,11 +3,23 @@ package sse
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/gin-contrib/sse"
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	// DefaultClientBufferSize 每个客户端队列的默认容量
+	DefaultClientBufferSize = 64
+
+	// DefaultHeartbeatInterval 默认心跳间隔
+	DefaultHeartbeatInterval = 15 * time.Second
+)
+
 // SSESender SSE发送器接口
 type SSESender interface {
 	Send(ctx context.Context, event *sse.Event) error
@@ -15,6 +27,7 @@ type SSESender interface {
 }
 
 // SSenderImpl Gin SSE发送器实现
+// 同一时刻只能由一个 goroutine 写入，Stream 保证只有客户端自身的 goroutine 调用它。
 type SSenderImpl struct {
 	c      *gin.Context
 	writer gin.ResponseWriter
@@ -27,6 +40,7 @@ func NewSSESender(c *gin.Context) *SSenderImpl {
 	c.Header("Content-Type", "text/event-stream")
 	c.Header("Cache-Control", "no-cache")
 	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
 	c.Header("Access-Control-Allow-Origin", "*")
 	c.Header("Access-Control-Allow-Headers", "Content-Type")
 
@@ -50,32 +64,32 @@ func (s *SSenderImpl) Send(ctx context.Context, event *sse.Event) error {
 	default:
 	}
 
-	// 构建SSE数据格式
-	if event.Id != "" {
-		s.writer.WriteString(fmt.Sprintf("id: %s\n", event.Id))
+	// 按 SSE 规范编码：id/event/retry/data，非字符串数据使用 JSON 序列化
+	if err := sse.Encode(s.writer, *event); err != nil {
+		return fmt.Errorf("encode SSE event failed: %w", err)
 	}
 
-	if event.Event != "" {
-		s.writer.WriteString(fmt.Sprintf("event: %s\n", event.Event))
-	}
+	// 刷新缓冲区
+	s.writer.Flush()
 
-	if event.Retry > 0 {
-		s.writer.WriteString(fmt.Sprintf("retry: %d\n", event.Retry))
+	return nil
+}
+
+// SendComment 发送注释行，客户端会忽略它，常用于保活
+func (s *SSenderImpl) SendComment(ctx context.Context, comment string) error {
+	if s.closed {
+		return fmt.Errorf("SSE connection is closed")
 	}
 
-	// 写入数据
-	switch data := event.Data.(type) {
-	case string:
-		s.writer.WriteString(fmt.Sprintf("data: %s\n\n", data))
-	case []byte:
-		s.writer.WriteString(fmt.Sprintf("data: %s\n\n", string(data)))
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
 	default:
-		// 对于其他类型，使用JSON序列化
-		s.c.SSEvent("data", data)
-		s.writer.WriteString("\n")
 	}
 
-	// 刷新缓冲区
+	if _, err := s.writer.WriteString(": " + comment + "\n\n"); err != nil {
+		return err
+	}
 	s.writer.Flush()
 
 	return nil
@@ -114,7 +128,7 @@ func (s *SSenderImpl) Close() error {
 
 	s.closed = true
 	// 发送关闭事件
-	s.writer.WriteString("event: close\ndata: \n\n")
+	_, _ = s.writer.WriteString("event: close\ndata: \n\n")
 	s.writer.Flush()
 
 	return nil
@@ -125,102 +139,261 @@ func (s *SSenderImpl) IsClosed() bool {
 	return s.closed
 }
 
+// StreamOption 配置 Stream 的可选项
+type StreamOption func(*Stream)
+
+// WithClientBufferSize 设置每个客户端队列的容量
+func WithClientBufferSize(size int) StreamOption {
+	return func(s *Stream) {
+		if size > 0 {
+			s.bufferSize = size
+		}
+	}
+}
+
+// WithHeartbeatInterval 设置心跳间隔，<=0 表示关闭心跳
+func WithHeartbeatInterval(interval time.Duration) StreamOption {
+	return func(s *Stream) {
+		s.heartbeat = interval
+	}
+}
+
 // Stream SSE流管理器
+// 每个客户端拥有独立的有界队列，由该客户端自己的 goroutine 消费并写出，
+// 慢客户端只会丢弃自己的消息，不会阻塞发布者或其他客户端。
 type Stream struct {
-	clients map[string]*SSenderImpl
+	mu      sync.RWMutex
+	clients map[string]*Client
 
-	// 用于添加/删除客户端的通道
-	addClient    chan *Client
-	removeClient chan *Client
+	bufferSize int
+	heartbeat  time.Duration
 
-	// 广播消息通道
-	broadcast chan *sse.Event
+	published atomic.Uint64
+	dropped   atomic.Uint64
 
-	// 停止信号
-	stopCh chan struct{}
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// StreamStats 流的统计信息
+type StreamStats struct {
+	Clients   int    `json:"clients"`
+	Published uint64 `json:"published"`
+	Dropped   uint64 `json:"dropped"`
 }
 
 // Client 客户端信息
 type Client struct {
 	ID     string
 	Sender *SSenderImpl
+	// Topics 为空表示订阅所有主题
+	Topics map[string]struct{}
+	// Events 客户端的有界事件队列，只由客户端自己的 goroutine 读取
 	Events chan *sse.Event
+
+	dropped atomic.Uint64
+	done    chan struct{}
+	once    sync.Once
 }
 
-// NewStream 创建新的SSE流
-func NewStream() *Stream {
-	return &Stream{
-		clients:      make(map[string]*SSenderImpl),
-		addClient:    make(chan *Client),
-		removeClient: make(chan *Client),
-		broadcast:    make(chan *sse.Event, 100),
-		stopCh:       make(chan struct{}),
+// NewClient 创建客户端，bufferSize<=0 时使用默认容量
+func NewClient(id string, sender *SSenderImpl, bufferSize int, topics ...string) *Client {
+	if bufferSize <= 0 {
+		bufferSize = DefaultClientBufferSize
+	}
+
+	c := &Client{
+		ID:     id,
+		Sender: sender,
+		Topics: make(map[string]struct{}, len(topics)),
+		Events: make(chan *sse.Event, bufferSize),
+		done:   make(chan struct{}),
+	}
+	for _, t := range topics {
+		if t = strings.TrimSpace(t); t != "" {
+			c.Topics[t] = struct{}{}
+		}
+	}
+
+	return c
+}
+
+// Subscribed 判断客户端是否订阅了指定主题
+func (c *Client) Subscribed(topic string) bool {
+	if len(c.Topics) == 0 || topic == "" {
+		return true
+	}
+	_, ok := c.Topics[topic]
+
+	return ok
+}
+
+// Dropped 返回该客户端因队列已满而丢弃的事件数
+func (c *Client) Dropped() uint64 {
+	return c.dropped.Load()
+}
+
+// Done 返回客户端被移除时关闭的通道
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// enqueue 非阻塞地把事件放入客户端队列，队列已满时丢弃并计数
+func (c *Client) enqueue(event *sse.Event) bool {
+	select {
+	case <-c.done:
+		return false
+	default:
+	}
+
+	select {
+	case c.Events <- event:
+		return true
+	default:
+		c.dropped.Add(1)
+		return false
 	}
 }
 
-// Start 启动流管理器
-func (s *Stream) Start() {
-	go s.run()
+func (c *Client) shutdown() {
+	c.once.Do(func() {
+		close(c.done)
+	})
 }
 
-// Stop 停止流管理器
+// NewStream 创建新的SSE流
+func NewStream(opts ...StreamOption) *Stream {
+	s := &Stream{
+		clients:    make(map[string]*Client),
+		bufferSize: DefaultClientBufferSize,
+		heartbeat:  DefaultHeartbeatInterval,
+		stopCh:     make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Stop 停止流管理器，通知所有客户端退出
 func (s *Stream) Stop() {
-	close(s.stopCh)
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for id, client := range s.clients {
+			client.shutdown()
+			delete(s.clients, id)
+		}
+	})
 }
 
-// AddClient 添加客户端
+// AddClient 添加客户端，同 ID 的旧客户端会被挤下线
 func (s *Stream) AddClient(client *Client) {
-	s.addClient <- client
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-s.stopCh:
+		client.shutdown()
+		return
+	default:
+	}
+
+	if old, ok := s.clients[client.ID]; ok && old != client {
+		old.shutdown()
+	}
+	s.clients[client.ID] = client
 }
 
 // RemoveClient 移除客户端
 func (s *Stream) RemoveClient(client *Client) {
-	s.removeClient <- client
+	s.mu.Lock()
+	if cur, ok := s.clients[client.ID]; ok && cur == client {
+		delete(s.clients, client.ID)
+	}
+	s.mu.Unlock()
+
+	client.shutdown()
 }
 
 // Publish 广播消息到所有客户端
 func (s *Stream) Publish(event *sse.Event) {
-	select {
-	case s.broadcast <- event:
-	default:
-		// 广播通道满了，丢弃消息
+	s.PublishTopic("", event)
+}
+
+// PublishTopic 发布消息到订阅了 topic 的客户端，topic 为空等同于 Publish
+// 返回成功入队的客户端数量
+func (s *Stream) PublishTopic(topic string, event *sse.Event) int {
+	s.published.Add(1)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	delivered := 0
+	for _, client := range s.clients {
+		if !client.Subscribed(topic) {
+			continue
+		}
+		if client.enqueue(event) {
+			delivered++
+		} else {
+			s.dropped.Add(1)
+		}
 	}
+
+	return delivered
 }
 
-// run 运行流管理器
-func (s *Stream) run() {
-	for {
-		select {
-		case client := <-s.addClient:
-			s.clients[client.ID] = client.Sender
+// Stats 返回流的统计信息
+func (s *Stream) Stats() StreamStats {
+	s.mu.RLock()
+	n := len(s.clients)
+	s.mu.RUnlock()
 
-		case client := <-s.removeClient:
-			if _, exists := s.clients[client.ID]; exists {
-				delete(s.clients, client.ID)
-				client.Sender.Close()
-			}
+	return StreamStats{
+		Clients:   n,
+		Published: s.published.Load(),
+		Dropped:   s.dropped.Load(),
+	}
+}
 
-		case event := <-s.broadcast:
-			// 广播到所有客户端
-			for id, sender := range s.clients {
-				if err := sender.Send(context.Background(), event); err != nil {
-					// 发送失败，移除客户端
-					delete(s.clients, id)
-					sender.Close()
-				}
-			}
+// Serve 在当前 goroutine 中消费客户端队列并写出事件，直到连接断开或客户端被移除
+func (s *Stream) Serve(ctx context.Context, client *Client) error {
+	s.AddClient(client)
+	defer s.RemoveClient(client)
+
+	var heartbeat <-chan time.Time
+	if s.heartbeat > 0 {
+		ticker := time.NewTicker(s.heartbeat)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
 
-		case <-s.stopCh:
-			// 关闭所有客户端连接
-			for _, sender := range s.clients {
-				sender.Close()
+	for {
+		select {
+		case <-ctx.Done():
+			// 客户端断开连接
+			return nil
+		case <-client.done:
+			// 被服务端移除或流已停止
+			return client.Sender.Close()
+		case event := <-client.Events:
+			if err := client.Sender.Send(ctx, event); err != nil {
+				return err
+			}
+		case <-heartbeat:
+			if err := client.Sender.SendComment(ctx, "keep-alive"); err != nil {
+				return err
 			}
-			return
 		}
 	}
 }
 
 // SSEHandler 创建SSE处理器的便捷函数
+// 客户端可以通过 topic 查询参数（可重复或逗号分隔）订阅指定主题。
 func SSEHandler(stream *Stream) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 获取客户端ID（可以从查询参数或头部获取）
@@ -233,45 +406,36 @@ func SSEHandler(stream *Stream) gin.HandlerFunc {
 			clientID = fmt.Sprintf("client_%d", generateClientID())
 		}
 
+		var topics []string
+		for _, t := range c.QueryArray("topic") {
+			topics = append(topics, strings.Split(t, ",")...)
+		}
+
 		// 创建SSE发送器
 		sender := NewSSESender(c)
 
 		// 创建客户端
-		client := &Client{
-			ID:     clientID,
-			Sender: sender,
-			Events: make(chan *sse.Event, 10),
-		}
-
-		// 添加到流中
-		stream.AddClient(client)
+		client := NewClient(clientID, sender, stream.bufferSize, topics...)
 
 		// 发送连接成功消息
-		sender.SendString(c.Request.Context(), "connected", "Connection established")
-
-		// 保持连接活跃
 		ctx := c.Request.Context()
-		for {
-			select {
-			case <-ctx.Done():
-				// 客户端断开连接
-				stream.RemoveClient(client)
-				return
-			case event := <-client.Events:
-				// 发送特定于客户端的事件
-				if err := sender.Send(ctx, event); err != nil {
-					stream.RemoveClient(client)
-					return
-				}
-			}
+		if err := sender.SendString(ctx, "connected", "Connection established"); err != nil {
+			return
 		}
+
+		// 保持连接活跃，直到客户端断开
+		_ = stream.Serve(ctx, client)
 	}
 }
 
-// generateClientID 生成客户端ID的简单函数
+var (
+	clientIDBase = time.Now().UnixNano()
+	clientSeq    atomic.Int64
+)
+
+// generateClientID 生成进程内唯一的客户端ID
 func generateClientID() int64 {
-	// 简单的时间戳ID，实际使用中可以使用UUID
-	return 1000000 // 这里应该使用实际的ID生成逻辑
+	return clientIDBase + clientSeq.Add(1)
 }
 
 // 使用示例函数
@@ -279,8 +443,8 @@ func ExampleUsage() {
 	r := gin.Default()
 
 	// 创建SSE流
-	sseStream := NewStream()
-	sseStream.Start()
+	sseStream := NewStream(WithHeartbeatInterval(10 * time.Second))
+	defer sseStream.Stop()
 
 	// SSE端点
 	r.GET("/events", SSEHandler(sseStream))
@@ -288,6 +452,7 @@ func ExampleUsage() {
 	// 触发广播的端点
 	r.POST("/broadcast", func(c *gin.Context) {
 		var req struct {
+			Topic string      `json:"topic"`
 			Event string      `json:"event"`
 			Data  interface{} `json:"data"`
 		}
@@ -298,12 +463,12 @@ func ExampleUsage() {
 		}
 
 		// 广播事件
-		sseStream.Publish(&sse.Event{
+		delivered := sseStream.PublishTopic(req.Topic, &sse.Event{
 			Event: req.Event,
 			Data:  req.Data,
 		})
 
-		c.JSON(200, gin.H{"message": "Event broadcasted"})
+		c.JSON(200, gin.H{"message": "Event broadcasted", "delivered": delivered})
 	})
 
 	// 启动服务器
@@ -0,0 +1,296 @@
+package sse
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// newTestServer serves stream on /events.
+func newTestServer(t *testing.T, stream *Stream) *httptest.Server {
+	t.Helper()
+
+	r := gin.New()
+	r.GET("/events", SSEHandler(stream))
+	srv := httptest.NewServer(r)
+	t.Cleanup(func() {
+		stream.Stop()
+		srv.Close()
+	})
+
+	return srv
+}
+
+// testEvent is an event or a comment read from the wire.
+type testEvent struct {
+	event   string
+	data    string
+	comment string
+}
+
+// connect opens an event stream and returns the events read from it. The
+// connection is closed when the test ends.
+func connect(t *testing.T, srv *httptest.Server, query string) <-chan testEvent {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/events?"+query, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		cancel()
+		resp.Body.Close()
+	})
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/event-stream") {
+		t.Fatalf("Content-Type = %q, want text/event-stream", ct)
+	}
+
+	events := make(chan testEvent, 1024)
+	go func() {
+		defer close(events)
+		var ev testEvent
+		sc := bufio.NewScanner(resp.Body)
+		for sc.Scan() {
+			line := sc.Text()
+			switch {
+			case line == "":
+				if ev != (testEvent{}) {
+					events <- ev
+				}
+				ev = testEvent{}
+			case strings.HasPrefix(line, ":"):
+				ev.comment = strings.TrimSpace(strings.TrimPrefix(line, ":"))
+			case strings.HasPrefix(line, "event:"):
+				ev.event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+			case strings.HasPrefix(line, "data:"):
+				ev.data = strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+			}
+		}
+	}()
+
+	return events
+}
+
+func next(t *testing.T, events <-chan testEvent) testEvent {
+	t.Helper()
+
+	select {
+	case ev, ok := <-events:
+		if !ok {
+			t.Fatal("event stream closed")
+		}
+		return ev
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an event")
+	}
+
+	return testEvent{}
+}
+
+// waitClients waits until n clients are registered on stream.
+func waitClients(t *testing.T, stream *Stream, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for stream.Stats().Clients != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("clients = %d, want %d", stream.Stats().Clients, n)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// blockingWriter is a ResponseWriter whose writes block until unblock is
+// closed, like a client that stopped reading.
+type blockingWriter struct {
+	header  http.Header
+	unblock chan struct{}
+}
+
+func (w *blockingWriter) Header() http.Header { return w.header }
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	<-w.unblock
+	return len(p), nil
+}
+
+func (w *blockingWriter) WriteHeader(int) {}
+
+func (w *blockingWriter) Flush() {}
+
+func TestStreamOrderingPerClient(t *testing.T) {
+	stream := NewStream(WithClientBufferSize(256), WithHeartbeatInterval(0))
+	srv := newTestServer(t, stream)
+
+	clients := []<-chan testEvent{
+		connect(t, srv, "client_id=a"),
+		connect(t, srv, "client_id=b"),
+	}
+	for _, events := range clients {
+		if ev := next(t, events); ev.event != "connected" {
+			t.Fatalf("first event = %+v, want connected", ev)
+		}
+	}
+	waitClients(t, stream, len(clients))
+
+	const n = 100
+	for i := 0; i < n; i++ {
+		if got := stream.PublishTopic("", &sse.Event{Event: "tick", Data: strconv.Itoa(i)}); got != len(clients) {
+			t.Fatalf("event %d delivered to %d clients, want %d", i, got, len(clients))
+		}
+	}
+
+	for c, events := range clients {
+		for i := 0; i < n; i++ {
+			ev := next(t, events)
+			if ev.event != "tick" || ev.data != strconv.Itoa(i) {
+				t.Fatalf("client %d: event %d = %+v, want tick %d", c, i, ev, i)
+			}
+		}
+	}
+}
+
+func TestStreamTopics(t *testing.T) {
+	stream := NewStream(WithHeartbeatInterval(0))
+	srv := newTestServer(t, stream)
+
+	tasks := connect(t, srv, "client_id=tasks&topic=tasks")
+	all := connect(t, srv, "client_id=all")
+	next(t, tasks)
+	next(t, all)
+	waitClients(t, stream, 2)
+
+	if got := stream.PublishTopic("nodes", &sse.Event{Event: "node", Data: "n1"}); got != 1 {
+		t.Fatalf("nodes event delivered to %d clients, want 1", got)
+	}
+	if got := stream.PublishTopic("tasks", &sse.Event{Event: "task", Data: "t1"}); got != 2 {
+		t.Fatalf("tasks event delivered to %d clients, want 2", got)
+	}
+
+	if ev := next(t, tasks); ev.data != "t1" {
+		t.Fatalf("tasks client got %+v, want t1", ev)
+	}
+	if ev := next(t, all); ev.data != "n1" {
+		t.Fatalf("all client got %+v, want n1", ev)
+	}
+	if ev := next(t, all); ev.data != "t1" {
+		t.Fatalf("all client got %+v, want t1", ev)
+	}
+}
+
+func TestStreamSlowClientIsolation(t *testing.T) {
+	const bufferSize = 4
+	stream := NewStream(WithClientBufferSize(bufferSize), WithHeartbeatInterval(0))
+	srv := newTestServer(t, stream)
+
+	fast := connect(t, srv, "client_id=fast")
+	next(t, fast)
+
+	// The slow client is stuck writing to a peer that does not read.
+	w := &blockingWriter{header: make(http.Header), unblock: make(chan struct{})}
+	c, _ := gin.CreateTestContext(w)
+	slow := NewClient("slow", NewSSESender(c), bufferSize)
+	served := make(chan struct{})
+	go func() {
+		defer close(served)
+		_ = stream.Serve(context.Background(), slow)
+	}()
+	t.Cleanup(func() {
+		close(w.unblock)
+		stream.RemoveClient(slow)
+		<-served
+	})
+	waitClients(t, stream, 2)
+
+	// Wait until the slow client holds its first event in the blocked write.
+	stream.Publish(&sse.Event{Event: "tick", Data: "0"})
+	deadline := time.Now().Add(5 * time.Second)
+	for len(slow.Events) != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("the slow client never picked up its first event")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// Publishing never waits for the slow client: the fast one receives
+	// every event in turn while the slow one drops what does not fit.
+	const n = 50
+	if ev := next(t, fast); ev.data != "0" {
+		t.Fatalf("fast client: event 0 = %+v", ev)
+	}
+	for i := 1; i < n; i++ {
+		published := make(chan struct{})
+		go func() {
+			defer close(published)
+			stream.Publish(&sse.Event{Event: "tick", Data: strconv.Itoa(i)})
+		}()
+		select {
+		case <-published:
+		case <-time.After(5 * time.Second):
+			t.Fatal("publishing blocked on the slow client")
+		}
+		if ev := next(t, fast); ev.data != strconv.Itoa(i) {
+			t.Fatalf("fast client: event %d = %+v", i, ev)
+		}
+	}
+
+	// One event is stuck in the write and bufferSize wait in the queue.
+	wantDropped := uint64(n - 1 - bufferSize)
+	if got := slow.Dropped(); got != wantDropped {
+		t.Fatalf("slow client dropped %d events, want %d", got, wantDropped)
+	}
+	stats := stream.Stats()
+	if stats.Dropped != wantDropped || stats.Published != n || stats.Clients != 2 {
+		t.Fatalf("stats = %+v, want %d published and %d dropped for 2 clients", stats, n, wantDropped)
+	}
+}
+
+func TestStreamHeartbeat(t *testing.T) {
+	stream := NewStream(WithHeartbeatInterval(20 * time.Millisecond))
+	srv := newTestServer(t, stream)
+
+	events := connect(t, srv, "client_id=idle")
+	next(t, events)
+	for i := 0; i < 2; i++ {
+		if ev := next(t, events); ev.comment != "keep-alive" {
+			t.Fatalf("got %+v, want a keep-alive comment", ev)
+		}
+	}
+}
+
+func TestStreamReplacesClientWithSameID(t *testing.T) {
+	stream := NewStream(WithHeartbeatInterval(0))
+	srv := newTestServer(t, stream)
+
+	first := connect(t, srv, "client_id=dup")
+	next(t, first)
+	waitClients(t, stream, 1)
+	second := connect(t, srv, "client_id=dup")
+	next(t, second)
+
+	if ev := next(t, first); ev.event != "close" {
+		t.Fatalf("replaced client got %+v, want close", ev)
+	}
+	waitClients(t, stream, 1)
+	stream.Publish(&sse.Event{Event: "tick", Data: "x"})
+	if ev := next(t, second); ev.data != "x" {
+		t.Fatalf("new client got %+v, want x", ev)
+	}
+}
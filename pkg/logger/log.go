@@ -154,3 +154,48 @@ func InitLog(output string) (err error) {
 	})
 	return
 }
+
+// CtxDebug logs at debug level with the log ID carried by ctx.
+func CtxDebug(ctx context.Context, format string, args ...interface{}) {
+	ctxLog(ctx, logrus.DebugLevel, format, args...)
+}
+
+// CtxInfo logs at info level with the log ID carried by ctx.
+func CtxInfo(ctx context.Context, format string, args ...interface{}) {
+	ctxLog(ctx, logrus.InfoLevel, format, args...)
+}
+
+// CtxWarn logs at warn level with the log ID carried by ctx.
+func CtxWarn(ctx context.Context, format string, args ...interface{}) {
+	ctxLog(ctx, logrus.WarnLevel, format, args...)
+}
+
+// CtxError logs at error level with the log ID carried by ctx.
+func CtxError(ctx context.Context, format string, args ...interface{}) {
+	ctxLog(ctx, logrus.ErrorLevel, format, args...)
+}
+
+// ctxLog keeps the same call depth as Info and friends so that the caller
+// prettifier reports the right source line.
+func ctxLog(ctx context.Context, level logrus.Level, format string, args ...interface{}) {
+	var entry *logrus.Entry
+	if instance == nil {
+		entry = logrus.NewEntry(logrus.StandardLogger())
+	} else {
+		entry = logrus.NewEntry(instance.Logger)
+	}
+	if logID := instance.GetLogID(ctx); logID != "" {
+		entry = entry.WithField("logid", logID)
+	}
+
+	switch level {
+	case logrus.DebugLevel:
+		entry.Debugf(format, args...)
+	case logrus.WarnLevel:
+		entry.Warnf(format, args...)
+	case logrus.ErrorLevel:
+		entry.Errorf(format, args...)
+	default:
+		entry.Infof(format, args...)
+	}
+}
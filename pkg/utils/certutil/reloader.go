@@ -0,0 +1,135 @@
+// Package certutil loads, hot-reloads and issues the X.509 material used by
+// the eidolon TLS listeners and clients.
+package certutil
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/kiosk404/eidolon/pkg/logger"
+	"github.com/kiosk404/eidolon/pkg/utils/fswatch"
+)
+
+const logModule = "certutil"
+
+// Reloader keeps a certificate key pair and an optional CA bundle in memory
+// and reloads them whenever the files change on disk. A failed reload keeps
+// the previous material.
+type Reloader struct {
+	certFile string
+	keyFile  string
+	caFile   string
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+	pool *x509.CertPool
+}
+
+// NewReloader loads the key pair and, when caFile is not empty, the CA bundle.
+// certFile and keyFile may be empty for a CA-only reloader.
+func NewReloader(certFile, keyFile, caFile string) (*Reloader, error) {
+	r := &Reloader{certFile: certFile, keyFile: keyFile, caFile: caFile}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Certificate returns the current key pair, nil if none is configured.
+func (r *Reloader) Certificate() *tls.Certificate {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.cert
+}
+
+// CertPool returns the current CA bundle, nil if none is configured.
+func (r *Reloader) CertPool() *x509.CertPool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.pool
+}
+
+// GetCertificate implements tls.Config.GetCertificate.
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	if cert := r.Certificate(); cert != nil {
+		return cert, nil
+	}
+
+	return nil, fmt.Errorf("no server certificate configured")
+}
+
+// GetClientCertificate implements tls.Config.GetClientCertificate.
+func (r *Reloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	if cert := r.Certificate(); cert != nil {
+		return cert, nil
+	}
+
+	// An empty certificate tells the server that we have none.
+	return &tls.Certificate{}, nil
+}
+
+// Run watches the files and reloads them on change until ctx is done.
+// The parent directories are watched so that atomic renames and symlink
+// swaps, as done by Kubernetes secret volumes, are noticed too.
+func (r *Reloader) Run(ctx context.Context) error {
+	return fswatch.Watch(ctx, []string{r.certFile, r.keyFile, r.caFile}, fswatch.DefaultDebounce, func() {
+		if err := r.reload(); err != nil {
+			logger.ErrorX(logModule, "reload certificates failed, keeping the previous ones: %s", err.Error())
+			return
+		}
+		logger.InfoX(logModule, "reloaded certificates from %s", r.describe())
+	}, func(err error) {
+		logger.WarnX(logModule, "watch certificates failed: %s", err.Error())
+	})
+}
+
+func (r *Reloader) reload() error {
+	var (
+		cert *tls.Certificate
+		pool *x509.CertPool
+	)
+
+	if r.certFile != "" || r.keyFile != "" {
+		pair, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+		if err != nil {
+			return fmt.Errorf("load key pair %s, %s: %w", r.certFile, r.keyFile, err)
+		}
+		cert = &pair
+	}
+
+	if r.caFile != "" {
+		data, err := os.ReadFile(r.caFile)
+		if err != nil {
+			return fmt.Errorf("read CA bundle: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return fmt.Errorf("no certificates found in CA bundle %s", r.caFile)
+		}
+	}
+
+	r.mu.Lock()
+	r.cert, r.pool = cert, pool
+	r.mu.Unlock()
+
+	return nil
+}
+
+func (r *Reloader) describe() string {
+	s := r.certFile
+	if r.caFile != "" {
+		if s != "" {
+			s += ", "
+		}
+		s += r.caFile
+	}
+
+	return s
+}
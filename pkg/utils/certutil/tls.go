@@ -0,0 +1,57 @@
+package certutil
+
+import (
+	"crypto/tls"
+	"fmt"
+)
+
+// ParseTLSVersion converts "1.2" or "1.3" into the matching tls.VersionTLS*
+// constant. Older versions are rejected.
+func ParseTLSVersion(v string) (uint16, error) {
+	switch v {
+	case "", "1.2", "VersionTLS12":
+		return tls.VersionTLS12, nil
+	case "1.3", "VersionTLS13":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported TLS version %q, expected 1.2 or 1.3", v)
+	}
+}
+
+// ServerConfig returns a TLS server configuration that serves the reloader's
+// current certificate. When the reloader carries a CA bundle, client
+// certificates are verified against it following clientAuth, either
+// tls.RequireAndVerifyClientCert or tls.VerifyClientCertIfGiven for servers
+// that decide per request whether a verified certificate is mandatory.
+func ServerConfig(r *Reloader, minVersion uint16, clientAuth tls.ClientAuthType) *tls.Config {
+	base := &tls.Config{
+		MinVersion:     minVersion,
+		GetCertificate: r.GetCertificate,
+	}
+
+	// GetConfigForClient picks up a rotated CA bundle on every handshake.
+	base.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		cfg := base.Clone()
+		cfg.GetConfigForClient = nil
+		if pool := r.CertPool(); pool != nil {
+			cfg.ClientCAs = pool
+			cfg.ClientAuth = clientAuth
+		}
+
+		return cfg, nil
+	}
+
+	return base
+}
+
+// ClientConfig returns a TLS client configuration that trusts the reloader's
+// CA bundle (or the system roots when it has none) and presents its key pair,
+// if any, as client certificate.
+func ClientConfig(r *Reloader, serverName string) *tls.Config {
+	return &tls.Config{
+		MinVersion:           tls.VersionTLS12,
+		ServerName:           serverName,
+		RootCAs:              r.CertPool(),
+		GetClientCertificate: r.GetClientCertificate,
+	}
+}
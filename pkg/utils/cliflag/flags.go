@@ -39,3 +39,22 @@ func PrintFlags(flags *pflag.FlagSet) {
 		logger.Debug("FLAG: --%s=%q", flag.Name, flag.Value)
 	})
 }
+
+// ConfigKeyAnnotation is the annotation of the flags that set a configuration
+// key other than their name.
+const ConfigKeyAnnotation = "cliflag_config_key"
+
+// SetConfigKey records that the flag name of fs sets the configuration key.
+func SetConfigKey(fs *pflag.FlagSet, name, key string) {
+	_ = fs.SetAnnotation(name, ConfigKeyAnnotation, []string{key})
+}
+
+// ConfigKey returns the configuration key flag sets, its name unless
+// SetConfigKey recorded another one.
+func ConfigKey(flag *pflag.Flag) string {
+	if keys := flag.Annotations[ConfigKeyAnnotation]; len(keys) > 0 {
+		return keys[0]
+	}
+
+	return flag.Name
+}
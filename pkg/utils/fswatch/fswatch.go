@@ -0,0 +1,83 @@
+// Package fswatch notices changes to files on disk, such as hot-reloaded
+// certificates and configuration catalogs.
+package fswatch
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultDebounce collapses the burst of events produced by a single save or
+// rotation (write, chmod, rename) into one change.
+const DefaultDebounce = 200 * time.Millisecond
+
+// Watch calls onChange once the files have been left alone for debounce after
+// a change, until ctx is done. Empty file names are ignored. The parent
+// directories are watched so that atomic renames and symlink swaps, as done by
+// editors and Kubernetes secret and config map volumes, are noticed too. The
+// errors of the watcher are passed to onError, which may be nil.
+func Watch(ctx context.Context, files []string, debounce time.Duration, onChange func(), onError func(error)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	watched := make(map[string]struct{})
+	for _, f := range files {
+		if f == "" {
+			continue
+		}
+		dir := filepath.Dir(f)
+		if _, ok := watched[dir]; ok {
+			continue
+		}
+		if err := watcher.Add(dir); err != nil {
+			return fmt.Errorf("watch %s: %w", dir, err)
+		}
+		watched[dir] = struct{}{}
+	}
+
+	var timer <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if relevant(files, event.Name) {
+				timer = time.After(debounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			if onError != nil {
+				onError(err)
+			}
+		case <-timer:
+			timer = nil
+			onChange()
+		}
+	}
+}
+
+// relevant reports whether an event on name may affect the files. Any event
+// on a "..data" style symlink counts, since it swaps every file at once.
+func relevant(files []string, name string) bool {
+	for _, f := range files {
+		if f != "" && filepath.Clean(f) == filepath.Clean(name) {
+			return true
+		}
+	}
+	base := filepath.Base(name)
+
+	return len(base) > 2 && strings.HasPrefix(base, "..")
+}
@@ -0,0 +1,111 @@
+package fswatch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testDebounce = 50 * time.Millisecond
+
+// startWatch watches files until the test ends and returns the number of
+// changes seen so far.
+func startWatch(t *testing.T, files ...string) *atomic.Int32 {
+	t.Helper()
+
+	var changes atomic.Int32
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- Watch(ctx, files, testDebounce, func() { changes.Add(1) }, nil)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Watch: %v", err)
+		}
+	})
+	// Give the watcher time to register the directories.
+	time.Sleep(testDebounce)
+
+	return &changes
+}
+
+func waitChanges(t *testing.T, changes *atomic.Int32, want int32) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for changes.Load() < want && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	// Let a spurious extra change show up.
+	time.Sleep(3 * testDebounce)
+	if got := changes.Load(); got != want {
+		t.Fatalf("changes = %d, want %d", got, want)
+	}
+}
+
+func TestWatchDebouncesWrites(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "conf.json")
+	if err := os.WriteFile(path, []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	changes := startWatch(t, path)
+
+	for i := 0; i < 5; i++ {
+		if err := os.WriteFile(path, []byte("{}"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	waitChanges(t, changes, 1)
+}
+
+func TestWatchRename(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "conf.json")
+	if err := os.WriteFile(path, []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	changes := startWatch(t, path)
+
+	tmp := filepath.Join(dir, "conf.json.tmp")
+	if err := os.WriteFile(tmp, []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+	waitChanges(t, changes, 1)
+}
+
+func TestWatchIgnoresOtherFiles(t *testing.T) {
+	dir := t.TempDir()
+	changes := startWatch(t, filepath.Join(dir, "conf.json"), "")
+
+	if err := os.WriteFile(filepath.Join(dir, "other.json"), []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	waitChanges(t, changes, 0)
+}
+
+func TestRelevant(t *testing.T) {
+	files := []string{"/etc/eidolon/tls.crt", ""}
+	for name, want := range map[string]bool{
+		"/etc/eidolon/tls.crt":          true,
+		"/etc/eidolon/./tls.crt":        true,
+		"/etc/eidolon/..data":           true,
+		"/etc/eidolon/..2026_10_18_tmp": true,
+		"/etc/eidolon/tls.key":          false,
+		"/etc/eidolon/..":               false,
+		"/etc/eidolon/tls.crt.swp":      false,
+		"/etc/eidolon/other/tls.crt":    false,
+	} {
+		if got := relevant(files, name); got != want {
+			t.Errorf("relevant(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
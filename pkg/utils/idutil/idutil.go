@@ -0,0 +1,24 @@
+package idutil
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// NewID returns a random identifier with the given prefix, like: wh-5f1c0a9b3e2d4c17.
+func NewID(prefix string) string {
+	return prefix + "-" + RandomHex(8)
+}
+
+// RandomHex returns n random bytes encoded as a hex string.
+func RandomHex(n int) string {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		// crypto/rand never fails on supported platforms, fall back to the clock just in case.
+		return fmt.Sprintf("%0*x", n*2, time.Now().UnixNano())
+	}
+
+	return hex.EncodeToString(buf)
+}